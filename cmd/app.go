@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"sweet/internal/global"
+	"sweet/internal/models/query"
+	"sweet/pkg/config"
+	"sweet/pkg/logger"
+	"sweet/pkg/metrics"
+	"sweet/pkg/tracing"
+)
+
+// redisConfig Redis 连接配置，与配置文件 redis 节点对应
+// pkg/auth 依赖 *redis.Client，因此只支持单机与哨兵模式
+type redisConfig struct {
+	Mode   string `yaml:"mode"` // single、sentinel
+	Single struct {
+		Addr     string `yaml:"addr"`
+		Password string `yaml:"password"`
+		DB       int    `yaml:"db"`
+	} `yaml:"single"`
+	Sentinel struct {
+		MasterName       string   `yaml:"master_name"`
+		Addrs            []string `yaml:"addrs"`
+		Password         string   `yaml:"password"`
+		SentinelPassword string   `yaml:"sentinel_password"`
+		DB               int      `yaml:"db"`
+	} `yaml:"sentinel"`
+	PoolSize     int           `yaml:"pool_size"`
+	MinIdleConns int           `yaml:"min_idle_conns"`
+	DialTimeout  time.Duration `yaml:"dial_timeout"`
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	PoolTimeout  time.Duration `yaml:"pool_timeout"`
+}
+
+// initLogger 根据配置中的 logger 节点初始化全局日志，返回关闭函数
+func initLogger(m *config.Manager) (func(), error) {
+	cfg := logger.DefaultConfig()
+	if err := unmarshalKey(m, "logger", cfg); err != nil {
+		return nil, fmt.Errorf("解析日志配置失败: %w", err)
+	}
+	l, err := logger.NewLogger(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("初始化日志失败: %w", err)
+	}
+	logger.SetGlobalLogger(l)
+	global.Logger = l
+	return func() { l.Close() }, nil
+}
+
+// initTracing 根据配置中的 monitoring.tracing 节点设置全局 TracerProvider，返回关闭函数
+// 未配置服务名称与版本时使用日志配置的 service_name、service_version
+func initTracing(ctx context.Context, m *config.Manager) (func(), error) {
+	cfg := tracing.DefaultConfig()
+	if err := unmarshalKey(m, "monitoring.tracing", cfg); err != nil {
+		return nil, fmt.Errorf("解析链路追踪配置失败: %w", err)
+	}
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = m.GetString("logger.service_name")
+	}
+	if cfg.ServiceVersion == "" {
+		cfg.ServiceVersion = m.GetString("logger.service_version")
+	}
+	provider, err := tracing.Setup(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("初始化链路追踪失败: %w", err)
+	}
+	return func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := provider.Shutdown(shutdownCtx); err != nil {
+			global.Logger.Warn("关闭链路追踪失败", zap.Error(err))
+		}
+	}, nil
+}
+
+// initDatabase 连接数据库并初始化 global.DBClient 与 global.Query，返回关闭函数
+func initDatabase(m *config.Manager) (func(), error) {
+	client, err := openDatabase(m)
+	if err != nil {
+		return nil, err
+	}
+	global.DBClient = client
+	global.Query = query.Use(client.DB())
+	query.SetDefault(client.DB())
+	return func() { client.Close() }, nil
+}
+
+// initMetrics 根据配置中的 monitoring.metrics 节点创建指标注册表，并为 global.DBClient 注册数据库操作与连接池指标
+// 未启用时返回空，调用方据此跳过各组件的指标采集
+func initMetrics(m *config.Manager) (*metrics.Registry, error) {
+	cfg := metrics.DefaultConfig()
+	if err := unmarshalKey(m, "monitoring.metrics", cfg); err != nil {
+		return nil, fmt.Errorf("解析指标配置失败: %w", err)
+	}
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	registry := metrics.NewRegistry(cfg)
+	if err := global.DBClient.DB().Use(metrics.NewGormPlugin(registry)); err != nil {
+		return nil, fmt.Errorf("注册数据库指标失败: %w", err)
+	}
+	if err := registry.RegisterDBStats("master", global.DBClient); err != nil {
+		return nil, fmt.Errorf("注册连接池指标失败: %w", err)
+	}
+	return registry, nil
+}
+
+// openRedis 根据配置中的 redis 节点连接 Redis
+func openRedis(ctx context.Context, m *config.Manager) (*redis.Client, error) {
+	var cfg redisConfig
+	if err := unmarshalKey(m, "redis", &cfg); err != nil {
+		return nil, fmt.Errorf("解析Redis配置失败: %w", err)
+	}
+
+	var client *redis.Client
+	switch cfg.Mode {
+	case "", "single":
+		client = redis.NewClient(&redis.Options{
+			Addr:         cfg.Single.Addr,
+			Password:     cfg.Single.Password,
+			DB:           cfg.Single.DB,
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+			DialTimeout:  cfg.DialTimeout,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
+			PoolTimeout:  cfg.PoolTimeout,
+		})
+	case "sentinel":
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.Sentinel.MasterName,
+			SentinelAddrs:    cfg.Sentinel.Addrs,
+			Password:         cfg.Sentinel.Password,
+			SentinelPassword: cfg.Sentinel.SentinelPassword,
+			DB:               cfg.Sentinel.DB,
+			PoolSize:         cfg.PoolSize,
+			MinIdleConns:     cfg.MinIdleConns,
+			DialTimeout:      cfg.DialTimeout,
+			ReadTimeout:      cfg.ReadTimeout,
+			WriteTimeout:     cfg.WriteTimeout,
+			PoolTimeout:      cfg.PoolTimeout,
+		})
+	default:
+		return nil, fmt.Errorf("不支持的Redis模式: %s", cfg.Mode)
+	}
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("连接Redis失败: %w", err)
+	}
+	return client, nil
+}
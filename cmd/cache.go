@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// flushBatch 每批扫描与删除的键数量
+const flushBatch = 500
+
+func init() {
+	register(&command{
+		name:  "cache",
+		short: "缓存运维: cache flush",
+		run: func(ctx context.Context, args []string) error {
+			return runGroup(ctx, "cache", cacheCommands, args)
+		},
+	})
+}
+
+// cacheCommands cache 命令组的子命令
+var cacheCommands = []*command{
+	{name: "flush", short: "按模式删除缓存键，清空整个库需 --yes", run: runCacheFlush},
+}
+
+// runCacheFlush 执行 cache flush 子命令
+// 使用 SCAN + UNLINK 分批删除，不阻塞 Redis；不使用 FLUSHDB 以免误删同库其他应用的数据
+func runCacheFlush(ctx context.Context, args []string) error {
+	fs := newFlagSet("cache flush")
+	pattern := fs.String("pattern", "", "要删除的键模式，如 jwt:*；为空时删除当前库全部键")
+	yes := fs.Bool("yes", false, "确认删除当前库全部键")
+	addRedisFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *pattern == "" && !*yes {
+		return errors.New("未指定 --pattern 时将删除当前库全部键，请添加 --yes 确认")
+	}
+	if *pattern == "" {
+		*pattern = "*"
+	}
+
+	m, err := loadConfig(fs)
+	if err != nil {
+		return err
+	}
+	rdb, err := openRedis(ctx, m)
+	if err != nil {
+		return err
+	}
+	defer rdb.Close()
+
+	var deleted int64
+	iter := rdb.Scan(ctx, 0, *pattern, flushBatch).Iterator()
+	keys := make([]string, 0, flushBatch)
+	flush := func() error {
+		if len(keys) == 0 {
+			return nil
+		}
+		n, err := rdb.Unlink(ctx, keys...).Result()
+		if err != nil {
+			return fmt.Errorf("删除缓存失败: %w", err)
+		}
+		deleted += n
+		keys = keys[:0]
+		return nil
+	}
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == flushBatch {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("扫描缓存失败: %w", err)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("已删除 %d 个缓存键（模式 %s）\n", deleted, *pattern)
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted 脱敏后的占位值
+const redacted = "******"
+
+// sensitiveKeyParts 键名包含这些片段时整体脱敏
+var sensitiveKeyParts = []string{"password", "secret", "token", "private", "credential", "dsn"}
+
+// credentialPattern 连接串中的 user:password@ 部分
+var credentialPattern = regexp.MustCompile(`([^:/@\s]+):([^@/\s]+)@`)
+
+func init() {
+	register(&command{
+		name:  "config",
+		short: "配置查看: config print",
+		run: func(ctx context.Context, args []string) error {
+			return runGroup(ctx, "config", configCommands, args)
+		},
+	})
+}
+
+// configCommands config 命令组的子命令
+var configCommands = []*command{
+	{name: "print", short: "打印合并命令行参数与环境变量后的最终配置，敏感项已脱敏", run: runConfigPrint},
+}
+
+// runConfigPrint 执行 config print 子命令
+func runConfigPrint(ctx context.Context, args []string) error {
+	fs := newFlagSet("config print")
+	format := fs.String("format", "yaml", "输出格式 yaml|json")
+	addDatabaseFlags(fs)
+	addRedisFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	m, err := loadConfig(fs)
+	if err != nil {
+		return err
+	}
+	settings := redactSettings(m.AllSettings())
+
+	switch *format {
+	case "yaml":
+		enc := yaml.NewEncoder(os.Stdout)
+		enc.SetIndent(2)
+		defer enc.Close()
+		return enc.Encode(settings)
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(settings)
+	default:
+		return fmt.Errorf("不支持的输出格式: %s", *format)
+	}
+}
+
+// redactSettings 递归脱敏配置：敏感键的值替换为占位符，其余字符串中的连接串密码被隐藏
+func redactSettings(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			if isSensitiveKey(k) && item != nil && item != "" {
+				out[k] = redacted
+				continue
+			}
+			out[k] = redactSettings(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = redactSettings(item)
+		}
+		return out
+	case []string:
+		out := make([]string, len(val))
+		for i, item := range val {
+			out[i] = credentialPattern.ReplaceAllString(item, "$1:"+redacted+"@")
+		}
+		return out
+	case string:
+		return credentialPattern.ReplaceAllString(val, "$1:"+redacted+"@")
+	default:
+		return v
+	}
+}
+
+// isSensitiveKey 判断配置键是否为敏感项，如 password、secret_key、api_key
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if key == "key" || strings.HasSuffix(key, "_key") {
+		return true
+	}
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
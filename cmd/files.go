@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"sweet/internal/service/basic"
+)
+
+func init() {
+	register(&command{
+		name:  "files",
+		short: "文件运维: files cleanup",
+		run: func(ctx context.Context, args []string) error {
+			return runGroup(ctx, "files", fileCommands, args)
+		},
+	})
+}
+
+// fileCommands files 命令组的子命令
+var fileCommands = []*command{
+	{name: "cleanup", short: "删除软删除超过指定天数的文件及其记录", run: runFilesCleanup},
+}
+
+// runFilesCleanup 执行 files cleanup 子命令
+func runFilesCleanup(ctx context.Context, args []string) error {
+	fs := newFlagSet("files cleanup")
+	days := fs.Int("days", 30, "软删除超过该天数的文件会被清理")
+	addDatabaseFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	m, err := loadConfig(fs)
+	if err != nil {
+		return err
+	}
+	closeLogger, err := initLogger(m)
+	if err != nil {
+		return err
+	}
+	defer closeLogger()
+	closeDatabase, err := initDatabase(m)
+	if err != nil {
+		return err
+	}
+	defer closeDatabase()
+
+	count, err := basic.NewFileService().CleanupExpiredFiles(ctx, *days)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("已清理 %d 个过期文件\n", count)
+	return nil
+}
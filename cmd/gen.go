@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sweet/scripts"
+)
+
+func init() {
+	register(&command{
+		name:  "gen",
+		short: "根据数据库表结构生成 entity 与 query 代码: gen [--file gen.yaml] [--dry-run]",
+		run:   runGen,
+	})
+}
+
+// runGen 执行 gen 子命令
+func runGen(ctx context.Context, args []string) error {
+	fs := newFlagSet("gen")
+	file := fs.String("file", "", "生成配置文件，默认使用内置的 scripts/gen.yaml")
+	dryRun := fs.Bool("dry-run", false, "只输出与现有代码的差异，不写入文件")
+	addDatabaseFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		cfg *scripts.GenConfig
+		err error
+	)
+	if *file == "" {
+		cfg, err = scripts.DefaultGenConfig()
+	} else {
+		cfg, err = scripts.LoadGenConfig(*file)
+	}
+	if err != nil {
+		return err
+	}
+
+	// 生成配置未指定 dsn 时使用应用配置的主库及其驱动
+	if cfg.DSN == "" {
+		m, err := loadConfig(fs)
+		if err != nil {
+			return err
+		}
+		cfg.DSN = m.GetString("database.master")
+		if cfg.Driver == "" {
+			cfg.Driver = m.GetString("database.driver")
+		}
+	}
+	if cfg.DSN == "" {
+		return errors.New("未配置 dsn 或 database.master")
+	}
+
+	db, err := scripts.Open(cfg)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		diff, err := scripts.DryRun(cfg, db)
+		if err != nil {
+			return err
+		}
+		if diff == "" {
+			fmt.Println("生成结果与现有代码一致")
+			return nil
+		}
+		fmt.Print(diff)
+		return nil
+	}
+
+	if err := scripts.NewGeneratorWithDB(cfg, db).GenerateModelsWithRelations(); err != nil {
+		return err
+	}
+	fmt.Printf("代码已生成到 %s 与 %s\n", cfg.OutPath, cfg.ModelPkgPath)
+	return nil
+}
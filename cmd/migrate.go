@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"gorm.io/gorm"
+
+	"sweet/pkg/config"
+	"sweet/pkg/migrate"
+	"sweet/resource"
+)
+
+func init() {
+	register(&command{
+		name:  "migrate",
+		short: "数据库迁移: migrate up|down|status [--steps N]",
+		run:   runMigrate,
+	})
+}
+
+// runMigrate 执行 migrate 子命令
+func runMigrate(ctx context.Context, args []string) error {
+	fs := newFlagSet("migrate")
+	steps := fs.Int("steps", 0, "执行的版本数，up 默认全部，down 默认1")
+	addDatabaseFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "用法: sweet migrate up|down|status [--steps N] [--config path]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("缺少迁移操作")
+	}
+
+	m, err := loadConfig(fs)
+	if err != nil {
+		return err
+	}
+	client, err := openDatabase(m)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	migrator, err := newMigrator(m, client.DB())
+	if err != nil {
+		return err
+	}
+
+	switch action := fs.Arg(0); action {
+	case "up":
+		done, err := migrator.Up(ctx, *steps)
+		printMigrations("已执行", done)
+		return err
+	case "down":
+		done, err := migrator.Down(ctx, *steps)
+		printMigrations("已回滚", done)
+		return err
+	case "status":
+		list, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(list)
+		return nil
+	default:
+		return fmt.Errorf("未知迁移操作: %s", action)
+	}
+}
+
+// AutoMigrate 服务启动时按 migrate.auto_migrate 配置执行未应用的迁移
+// 多实例同时启动时由迁移锁保证只有一个实例执行，其余实例等待后校验通过即返回
+func AutoMigrate(ctx context.Context, m *config.Manager, db *gorm.DB) error {
+	if !m.GetBool("migrate.auto_migrate") {
+		return nil
+	}
+	migrator, err := newMigrator(m, db)
+	if err != nil {
+		return err
+	}
+	_, err = migrator.Up(ctx, 0)
+	return err
+}
+
+// newMigrator 按数据库方言加载嵌入的迁移脚本并创建执行器
+func newMigrator(m *config.Manager, db *gorm.DB) (*migrate.Migrator, error) {
+	cfg := migrate.DefaultConfig()
+	if err := unmarshalKey(m, "migrate", cfg); err != nil {
+		return nil, fmt.Errorf("解析迁移配置失败: %w", err)
+	}
+	fsys, err := resource.MigrationsFor(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := migrate.Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(db, migrations, cfg), nil
+}
+
+// printMigrations 打印本次执行的迁移
+func printMigrations(action string, list []*migrate.Migration) {
+	if len(list) == 0 {
+		fmt.Println("没有需要执行的迁移")
+		return
+	}
+	for _, m := range list {
+		fmt.Printf("%s %06d_%s\n", action, m.Version, m.Name)
+	}
+}
+
+// printStatus 打印迁移状态表
+func printStatus(list []*migrate.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, s := range list {
+		state, appliedAt := "pending", "-"
+		if s.Applied {
+			state = "applied"
+			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		if s.Modified {
+			state = "modified"
+		}
+		if s.Missing {
+			state = "missing"
+		}
+		fmt.Fprintf(w, "%06d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+	}
+	w.Flush()
+}
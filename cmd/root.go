@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/pflag"
+
+	"sweet/pkg/config"
+	"sweet/pkg/database"
+)
+
+// command 子命令
+type command struct {
+	name  string
+	short string
+	run   func(ctx context.Context, args []string) error
+}
+
+// commands 已注册的子命令
+var commands = map[string]*command{}
+
+// register 注册子命令
+func register(c *command) {
+	commands[c.name] = c
+}
+
+// Execute 解析命令行并执行子命令，返回进程退出码
+func Execute(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		usage()
+		return 0
+	}
+
+	c, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "未知命令: %s\n\n", args[0])
+		usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := c.run(ctx, args[1:]); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "%s: %v\n", c.name, err)
+		return 1
+	}
+	return 0
+}
+
+// usage 打印命令列表
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "用法: sweet <命令> [参数]")
+	fmt.Fprintln(os.Stderr, "\n命令:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", name, commands[name].short)
+	}
+}
+
+// newFlagSet 创建子命令参数集，包含公共的 --config 参数
+func newFlagSet(name string) *pflag.FlagSet {
+	fs := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	fs.String("config", "", "配置文件路径，默认在当前目录及 ./config 中查找 config.yaml")
+	return fs
+}
+
+// runGroup 将命令组的第一个参数分发到对应子命令，如 sweet user create
+func runGroup(ctx context.Context, group string, subs []*command, args []string) error {
+	if len(args) > 0 {
+		for _, sub := range subs {
+			if sub.name == args[0] {
+				return sub.run(ctx, args[1:])
+			}
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "用法: sweet %s <子命令> [参数]\n\n子命令:\n", group)
+	for _, sub := range subs {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", sub.name, sub.short)
+	}
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		return pflag.ErrHelp
+	}
+	return fmt.Errorf("未知子命令: %s", args[0])
+}
+
+// addDatabaseFlags 添加覆盖数据库配置的参数
+func addDatabaseFlags(fs *pflag.FlagSet) {
+	fs.String("database.master", "", "主库DSN，覆盖配置文件中的 database.master")
+}
+
+// addRedisFlags 添加覆盖 Redis 配置的参数
+func addRedisFlags(fs *pflag.FlagSet) {
+	fs.String("redis.single.addr", "", "Redis 地址，覆盖配置文件中的 redis.single.addr")
+	fs.Int("redis.single.db", 0, "Redis 数据库，覆盖配置文件中的 redis.single.db")
+}
+
+// loadConfig 加载 --config 指定的配置文件
+// 名称中带 . 的参数视为配置项（如 --database.master），通过 BindPFlags 覆盖配置文件与环境变量
+func loadConfig(fs *pflag.FlagSet) (*config.Manager, error) {
+	path, _ := fs.GetString("config")
+
+	var (
+		m   *config.Manager
+		err error
+	)
+	if path != "" {
+		m, err = config.WithFile(path)
+	} else {
+		m, err = config.Default()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := m.Load(); err != nil {
+		return nil, err
+	}
+
+	overrides := pflag.NewFlagSet(fs.Name(), pflag.ContinueOnError)
+	fs.VisitAll(func(f *pflag.Flag) {
+		if strings.Contains(f.Name, ".") {
+			overrides.AddFlag(f)
+		}
+	})
+	if err := m.BindPFlags(overrides); err != nil {
+		return nil, fmt.Errorf("绑定命令行参数失败: %w", err)
+	}
+	return m, nil
+}
+
+// unmarshalKey 按 yaml 标签解析配置项，与配置文件字段命名保持一致
+// viper 的 UnmarshalKey 不会合并绑定在子键上的命令行参数，这里从 AllSettings 中取值
+func unmarshalKey(m *config.Manager, key string, rawVal interface{}) error {
+	var input interface{} = m.AllSettings()
+	for _, part := range strings.Split(strings.ToLower(key), ".") {
+		section, _ := input.(map[string]interface{})
+		input = section[part]
+	}
+	if input == nil {
+		return nil
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		TagName:          "yaml",
+		Result:           rawVal,
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+		),
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(input)
+}
+
+// openDatabase 根据配置中的 database 节点连接数据库
+func openDatabase(m *config.Manager) (*database.Client, error) {
+	cfg := database.DefaultConfig()
+	if err := unmarshalKey(m, "database", cfg); err != nil {
+		return nil, fmt.Errorf("解析数据库配置失败: %w", err)
+	}
+	return database.NewClient(cfg)
+}
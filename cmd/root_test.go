@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sweet/pkg/auth"
+	"sweet/pkg/database"
+)
+
+const testConfig = `
+database:
+  master: "root:secret@tcp(db:3306)/sweet"
+  pool:
+    max_open_conns: 20
+    conn_max_lifetime: 30m
+redis:
+  single:
+    addr: "cache:6379"
+    password: "redis-pass"
+auth:
+  jwt:
+    secret: "jwt-secret"
+    issuer: "sweet"
+  oidc:
+    - name: "keycloak"
+      issuer: "https://sso.example.com/realms/sweet"
+      client_id: "sweet-admin"
+      scopes: ["openid", "email"]
+      provision:
+        enabled: true
+        default_role_id: 2
+        rules:
+          - claim: "groups"
+            value: "admins"
+            role_id: 1
+`
+
+func TestLoadConfig_FlagOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testConfig), 0o600))
+
+	fs := newFlagSet("test")
+	addDatabaseFlags(fs)
+	addRedisFlags(fs)
+	require.NoError(t, fs.Parse([]string{"--config", path, "--redis.single.addr", "localhost:6380"}))
+
+	m, err := loadConfig(fs)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:6380", m.GetString("redis.single.addr"), "命令行参数覆盖配置文件")
+	assert.Equal(t, "root:secret@tcp(db:3306)/sweet", m.GetString("database.master"), "未指定的参数保留配置文件的值")
+	assert.False(t, m.IsSet("config"), "非配置项参数不绑定")
+
+	var rc redisConfig
+	require.NoError(t, unmarshalKey(m, "redis", &rc))
+	assert.Equal(t, "localhost:6380", rc.Single.Addr)
+	assert.Equal(t, "redis-pass", rc.Single.Password)
+
+	cfg := database.DefaultConfig()
+	require.NoError(t, unmarshalKey(m, "database", cfg))
+	assert.Equal(t, 20, cfg.Pool.MaxOpenConns)
+	assert.Equal(t, 30*time.Minute, cfg.Pool.ConnMaxLifetime)
+
+	var oidc []*auth.OIDCConfig
+	require.NoError(t, unmarshalKey(m, "auth.oidc", &oidc))
+	require.Len(t, oidc, 1)
+	assert.Equal(t, "sweet-admin", oidc[0].ClientID)
+	assert.Equal(t, []string{"openid", "email"}, oidc[0].Scopes)
+	assert.True(t, oidc[0].Provision.Enabled)
+	assert.Equal(t, int64(2), oidc[0].Provision.DefaultRoleID)
+	assert.Equal(t, []auth.OIDCClaimRule{{Claim: "groups", Value: "admins", RoleID: 1}}, oidc[0].Provision.Rules)
+
+	require.NoError(t, fs.Set("database.master", "root:other@tcp(replica:3306)/sweet"))
+	require.NoError(t, unmarshalKey(m, "database", cfg))
+	assert.Equal(t, "root:other@tcp(replica:3306)/sweet", cfg.Master)
+}
+
+func TestRedactSettings(t *testing.T) {
+	settings := map[string]interface{}{
+		"database": map[string]interface{}{
+			"master": "root:secret@tcp(db:3306)/sweet",
+			"slaves": []interface{}{"reader:pass@tcp(r1:3306)/sweet"},
+		},
+		"auth": map[string]interface{}{
+			"jwt": map[string]interface{}{"secret": "jwt-secret", "issuer": "sweet"},
+		},
+		"crypto": map[string]interface{}{
+			"field": map[string]interface{}{
+				"keys":      []interface{}{map[string]interface{}{"id": "v1", "key": "base64"}},
+				"index_key": "base64",
+			},
+			"envelope": map[string]interface{}{"private_key": "PEM", "key_bits": 2048},
+		},
+		"redis": map[string]interface{}{"password": ""},
+	}
+
+	out := redactSettings(settings).(map[string]interface{})
+	db := out["database"].(map[string]interface{})
+	assert.Equal(t, "root:******@tcp(db:3306)/sweet", db["master"])
+	assert.Equal(t, []interface{}{"reader:******@tcp(r1:3306)/sweet"}, db["slaves"])
+
+	jwt := out["auth"].(map[string]interface{})["jwt"].(map[string]interface{})
+	assert.Equal(t, redacted, jwt["secret"])
+	assert.Equal(t, "sweet", jwt["issuer"])
+
+	crypto := out["crypto"].(map[string]interface{})
+	field := crypto["field"].(map[string]interface{})
+	assert.Equal(t, redacted, field["index_key"])
+	assert.Equal(t, redacted, field["keys"].([]interface{})[0].(map[string]interface{})["key"])
+	assert.Equal(t, "v1", field["keys"].([]interface{})[0].(map[string]interface{})["id"])
+	envelope := crypto["envelope"].(map[string]interface{})
+	assert.Equal(t, redacted, envelope["private_key"])
+	assert.Equal(t, 2048, envelope["key_bits"])
+
+	assert.Equal(t, "", out["redis"].(map[string]interface{})["password"], "空值不需要脱敏")
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sweet/scripts"
+)
+
+func init() {
+	register(&command{
+		name:  "scaffold",
+		short: "根据 entity 生成 DTO、服务、接口、路由与API种子数据: scaffold --entity SysPost",
+		run:   runScaffold,
+	})
+}
+
+// runScaffold 执行 scaffold 子命令
+func runScaffold(ctx context.Context, args []string) error {
+	fs := newFlagSet("scaffold")
+	var opts scripts.ScaffoldOptions
+	fs.StringVar(&opts.Entity, "entity", "", "entity 结构体名，如 SysPost")
+	fs.StringVar(&opts.Module, "module", "system", "业务模块")
+	fs.StringVar(&opts.Name, "name", "", "资源名，默认去掉 entity 的 Sys 前缀")
+	fs.StringVar(&opts.Title, "title", "", "中文名称，默认取 entity 注释")
+	fs.StringVar(&opts.Path, "path", "", "路由路径（不含 /api/v1），默认为资源名的复数形式")
+	fs.StringVar(&opts.Root, "root", ".", "项目根目录")
+	fs.BoolVar(&opts.Force, "force", false, "覆盖已存在的文件")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if opts.Entity == "" {
+		return errors.New("缺少 --entity")
+	}
+
+	files, err := scripts.Scaffold(opts)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.Skipped {
+			fmt.Printf("跳过 %s（已存在，使用 --force 覆盖）\n", f.Path)
+			continue
+		}
+		fmt.Printf("生成 %s\n", f.Path)
+	}
+	fmt.Println("请在路由中调用生成的 Register 函数，并执行 sweet seed --file 写入API数据")
+	return nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sweet/internal/seed"
+	"sweet/resource"
+)
+
+func init() {
+	register(&command{
+		name:  "seed",
+		short: "写入初始化数据（内置角色、管理员、菜单、API），可重复执行",
+		run:   runSeed,
+	})
+}
+
+// runSeed 执行 seed 子命令
+func runSeed(ctx context.Context, args []string) error {
+	fs := newFlagSet("seed")
+	file := fs.String("file", "", "初始化数据文件（YAML/JSON），默认使用内置数据")
+	addDatabaseFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		fixture *seed.Fixture
+		err     error
+	)
+	if *file == "" {
+		fixture, err = seed.Load(resource.Seeds(), "default.yaml")
+	} else {
+		fixture, err = seed.Load(os.DirFS(filepath.Dir(*file)), filepath.Base(*file))
+	}
+	if err != nil {
+		return err
+	}
+
+	m, err := loadConfig(fs)
+	if err != nil {
+		return err
+	}
+	client, err := openDatabase(m)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := seed.New(client.DB()).Run(ctx, fixture)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("新建角色 %d 个，API分组 %d 个，API %d 个，菜单 %d 个\n", result.Roles, result.ApiGroups, result.Apis, result.Menus)
+	if result.Admin {
+		fmt.Printf("已创建管理员 %s，首次登录后需修改密码\n", fixture.Admin.Username)
+	}
+	if result.AdminPassword != "" {
+		fmt.Printf("初始密码: %s（仅显示一次，请妥善保存）\n", result.AdminPassword)
+	}
+	return nil
+}
@@ -1 +1,237 @@
 package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"sweet/common"
+	"sweet/internal/global"
+	"sweet/internal/listing"
+	"sweet/internal/middleware"
+	"sweet/pkg/auth"
+	"sweet/pkg/config"
+	"sweet/pkg/crypto"
+	"sweet/pkg/metrics"
+	"sweet/pkg/tracing"
+)
+
+// shutdownTimeout 优雅停机等待进行中请求的最长时间
+const shutdownTimeout = 15 * time.Second
+
+// serverConfig HTTP 服务配置，与配置文件 server 节点对应
+type serverConfig struct {
+	Host           string        `yaml:"host"`
+	Port           int           `yaml:"port"`
+	ReadTimeout    time.Duration `yaml:"read_timeout"`
+	WriteTimeout   time.Duration `yaml:"write_timeout"`
+	IdleTimeout    time.Duration `yaml:"idle_timeout"`
+	MaxHeaderBytes int           `yaml:"max_header_bytes"`
+}
+
+// jwtConfig 令牌配置，与配置文件 auth.jwt 节点对应
+type jwtConfig struct {
+	Secret            string `yaml:"secret"`
+	Issuer            string `yaml:"issuer"`
+	Subject           string `yaml:"subject"`
+	BufferTime        string `yaml:"buffer_time"`         // 刷新阈值，默认1h
+	AccessTokenExpire string `yaml:"access_token_expire"` // 令牌有效期，默认24h
+}
+
+func init() {
+	register(&command{
+		name:  "serve",
+		short: "启动 HTTP 服务",
+		run:   runServe,
+	})
+}
+
+// runServe 执行 serve 子命令，收到 SIGINT/SIGTERM 后优雅停机
+func runServe(ctx context.Context, args []string) error {
+	fs := newFlagSet("serve")
+	fs.String("server.host", "0.0.0.0", "监听地址")
+	fs.Int("server.port", 8080, "监听端口")
+	fs.String("logger.level", "info", "日志级别")
+	addDatabaseFlags(fs)
+	addRedisFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	m, err := loadConfig(fs)
+	if err != nil {
+		return err
+	}
+	closeLogger, err := initLogger(m)
+	if err != nil {
+		return err
+	}
+	defer closeLogger()
+	closeTracing, err := initTracing(ctx, m)
+	if err != nil {
+		return err
+	}
+	defer closeTracing()
+	closeDatabase, err := initDatabase(m)
+	if err != nil {
+		return err
+	}
+	defer closeDatabase()
+	registry, err := initMetrics(m)
+	if err != nil {
+		return err
+	}
+	if err := AutoMigrate(ctx, m, global.DBClient.DB()); err != nil {
+		return fmt.Errorf("自动迁移失败: %w", err)
+	}
+
+	rdb, err := openRedis(ctx, m)
+	if err != nil {
+		return err
+	}
+	defer rdb.Close()
+	if registry != nil {
+		rdb.AddHook(metrics.NewRedisHook(registry))
+	}
+	rdb.AddHook(tracing.NewRedisHook(rdb.Options().Addr))
+	if err := initSecurity(ctx, m, rdb); err != nil {
+		return err
+	}
+
+	var cfg serverConfig
+	if err := unmarshalKey(m, "server", &cfg); err != nil {
+		return fmt.Errorf("解析服务配置失败: %w", err)
+	}
+	srv := &http.Server{
+		Addr:           net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Handler:        newEngine(registry),
+		ReadTimeout:    cfg.ReadTimeout,
+		WriteTimeout:   cfg.WriteTimeout,
+		IdleTimeout:    cfg.IdleTimeout,
+		MaxHeaderBytes: cfg.MaxHeaderBytes,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		global.Logger.Info("HTTP 服务启动", zap.String("addr", srv.Addr))
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	global.Logger.Info("HTTP 服务停止中")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// initSecurity 初始化令牌、OIDC、请求签名、字段加密与密码信封
+func initSecurity(ctx context.Context, m *config.Manager, rdb *redis.Client) error {
+	var jwt jwtConfig
+	if err := unmarshalKey(m, "auth.jwt", &jwt); err != nil {
+		return fmt.Errorf("解析JWT配置失败: %w", err)
+	}
+	if jwt.BufferTime == "" {
+		jwt.BufferTime = "1h"
+	}
+	if jwt.AccessTokenExpire == "" {
+		jwt.AccessTokenExpire = "24h"
+	}
+	if err := auth.NewJwt(&auth.JwtConfig{
+		SecretKey:  jwt.Secret,
+		Issuer:     jwt.Issuer,
+		Subject:    jwt.Subject,
+		BufferTime: jwt.BufferTime,
+		ExpireTime: jwt.AccessTokenExpire,
+	}, rdb); err != nil {
+		return fmt.Errorf("初始化JWT失败: %w", err)
+	}
+
+	// 启动时拉取各提供方的发现文档，未配置时单点登录不可用
+	var oidc []*auth.OIDCConfig
+	if err := unmarshalKey(m, "auth.oidc", &oidc); err != nil {
+		return fmt.Errorf("解析OIDC配置失败: %w", err)
+	}
+	if len(oidc) > 0 {
+		if err := auth.NewOIDC(ctx, oidc, rdb); err != nil {
+			return err
+		}
+	}
+
+	// 未配置签名客户端时不初始化，使用 middleware.Signature 的接口均会拒绝
+	var signature auth.SignatureConfig
+	if err := unmarshalKey(m, "auth.signature", &signature); err != nil {
+		return fmt.Errorf("解析请求签名配置失败: %w", err)
+	}
+	if len(signature.Clients) > 0 {
+		if err := auth.NewSignature(&signature, rdb); err != nil {
+			return fmt.Errorf("初始化请求签名失败: %w", err)
+		}
+	}
+
+	// 未配置字段加密密钥时不初始化，读写加密字段会返回错误
+	if m.IsSet("crypto.field.active_key") {
+		var field crypto.FieldConfig
+		if err := unmarshalKey(m, "crypto.field", &field); err != nil {
+			return fmt.Errorf("解析字段加密配置失败: %w", err)
+		}
+		if err := crypto.NewFieldCrypto(&field); err != nil {
+			return fmt.Errorf("初始化字段加密失败: %w", err)
+		}
+	} else {
+		global.Logger.Warn("未配置 crypto.field，加密字段不可用")
+	}
+
+	// 列表游标签名密钥，未配置时使用进程内随机密钥，多实例或重启后旧游标失效
+	if key := m.GetString("crypto.cursor_key"); key != "" {
+		listing.SetCursorKey([]byte(key))
+	} else {
+		global.Logger.Warn("未配置 crypto.cursor_key，分页游标仅在当前进程内有效")
+	}
+
+	var envelope crypto.EnvelopeConfig
+	if err := unmarshalKey(m, "crypto.envelope", &envelope); err != nil {
+		return fmt.Errorf("解析密码信封配置失败: %w", err)
+	}
+	if err := crypto.NewPasswordEnvelope(&envelope); err != nil {
+		return fmt.Errorf("初始化密码信封失败: %w", err)
+	}
+	return nil
+}
+
+// newEngine 创建 gin 引擎，业务路由在此注册；registry 为空时不采集 HTTP 指标
+func newEngine(registry *metrics.Registry) *gin.Engine {
+	common.NewGinUtils(global.Logger)
+
+	engine := gin.New()
+	// 链路追踪在最外层，panic 恢复后的 500 状态码同样记录到 Span
+	engine.Use(middleware.Tracing(), gin.Recovery())
+	if registry != nil {
+		engine.Use(middleware.Metrics(registry))
+		engine.GET(registry.Config().Path, gin.WrapH(registry.Handler()))
+	}
+	engine.GET("/health", func(c *gin.Context) {
+		if err := global.DBClient.HealthCheck(c.Request.Context()); err != nil {
+			global.Logger.Error("健康检查失败", zap.Error(err))
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "down"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "up"})
+	})
+	return engine
+}
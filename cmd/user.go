@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"gorm.io/gorm"
+
+	"sweet/internal/models/entity"
+	"sweet/internal/models/query"
+	"sweet/pkg/crypto"
+	"sweet/pkg/utils"
+)
+
+func init() {
+	register(&command{
+		name:  "user",
+		short: "账号运维: user create|reset-password|unlock",
+		run: func(ctx context.Context, args []string) error {
+			return runGroup(ctx, "user", userCommands, args)
+		},
+	})
+}
+
+// userCommands user 命令组的子命令
+var userCommands = []*command{
+	{name: "create", short: "创建账号，首次登录后需修改密码", run: runUserCreate},
+	{name: "reset-password", short: "重置密码，下次登录后需修改密码", run: runUserResetPassword},
+	{name: "unlock", short: "解除账号禁用", run: runUserUnlock},
+}
+
+// runUserCreate 执行 user create 子命令
+func runUserCreate(ctx context.Context, args []string) error {
+	fs := newFlagSet("user create")
+	username := fs.String("username", "", "登录用户名（必填）")
+	password := fs.String("password", "", "初始密码，为空时随机生成")
+	role := fs.String("role", "", "角色标识（必填），如 super_admin")
+	nickname := fs.String("nickname", "", "昵称，默认与用户名相同")
+	realname := fs.String("realname", "", "真实姓名")
+	addDatabaseFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *username == "" || *role == "" {
+		return errors.New("--username 和 --role 不能为空")
+	}
+
+	generated, err := choosePassword(password)
+	if err != nil {
+		return err
+	}
+	q, closeDB, err := openQuery(fs)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	roleDao, userDao := q.SysRole, q.SysUser
+	r, err := roleDao.WithContext(ctx).Where(roleDao.Code.Eq(*role)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("角色 %s 不存在", *role)
+	} else if err != nil {
+		return fmt.Errorf("查询角色失败: %w", err)
+	}
+	if _, err := userDao.WithContext(ctx).Unscoped().Where(userDao.Username.Eq(*username)).First(); err == nil {
+		return fmt.Errorf("用户名 %s 已存在", *username)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("查询账号失败: %w", err)
+	}
+
+	if *nickname == "" {
+		*nickname = *username
+	}
+	salt := crypto.Salt()
+	user := &entity.SysUser{
+		Username:      *username,
+		Password:      crypto.MD5(*password + salt),
+		Salt:          salt,
+		Realname:      *realname,
+		Nickname:      *nickname,
+		Status:        utils.Ptr[int64](1),
+		PasswordReset: utils.Ptr[int64](1),
+		RoleID:        &r.ID,
+	}
+	if err := userDao.WithContext(ctx).Create(user); err != nil {
+		return fmt.Errorf("创建账号失败: %w", err)
+	}
+
+	fmt.Printf("已创建账号 %s（ID %d，角色 %s），首次登录后需修改密码\n", user.Username, user.ID, r.Code)
+	printGeneratedPassword(generated, *password)
+	return nil
+}
+
+// runUserResetPassword 执行 user reset-password 子命令
+func runUserResetPassword(ctx context.Context, args []string) error {
+	fs := newFlagSet("user reset-password")
+	username := fs.String("username", "", "登录用户名（必填）")
+	password := fs.String("password", "", "新密码，为空时随机生成")
+	addDatabaseFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *username == "" {
+		return errors.New("--username 不能为空")
+	}
+
+	generated, err := choosePassword(password)
+	if err != nil {
+		return err
+	}
+	q, closeDB, err := openQuery(fs)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	salt := crypto.Salt()
+	dao := q.SysUser
+	info, err := dao.WithContext(ctx).Where(dao.Username.Eq(*username)).Updates(&entity.SysUser{
+		Password:      crypto.MD5(*password + salt),
+		Salt:          salt,
+		PasswordReset: utils.Ptr[int64](1),
+	})
+	if err != nil {
+		return fmt.Errorf("重置密码失败: %w", err)
+	}
+	if info.RowsAffected == 0 {
+		return fmt.Errorf("账号 %s 不存在", *username)
+	}
+
+	fmt.Printf("已重置账号 %s 的密码，下次登录后需修改密码\n", *username)
+	printGeneratedPassword(generated, *password)
+	return nil
+}
+
+// runUserUnlock 执行 user unlock 子命令，将禁用的账号恢复为正常状态
+func runUserUnlock(ctx context.Context, args []string) error {
+	fs := newFlagSet("user unlock")
+	username := fs.String("username", "", "登录用户名（必填）")
+	addDatabaseFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *username == "" {
+		return errors.New("--username 不能为空")
+	}
+
+	q, closeDB, err := openQuery(fs)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	dao := q.SysUser
+	user, err := dao.WithContext(ctx).Where(dao.Username.Eq(*username)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("账号 %s 不存在", *username)
+	} else if err != nil {
+		return fmt.Errorf("查询账号失败: %w", err)
+	}
+	if utils.Deref(user.Status) == 1 {
+		fmt.Printf("账号 %s 状态正常，无需解除\n", *username)
+		return nil
+	}
+	if _, err := dao.WithContext(ctx).Where(dao.ID.Eq(user.ID)).Update(dao.Status, 1); err != nil {
+		return fmt.Errorf("解除禁用失败: %w", err)
+	}
+
+	fmt.Printf("已解除账号 %s 的禁用\n", *username)
+	return nil
+}
+
+// openQuery 加载配置并连接数据库，返回查询对象与关闭函数
+func openQuery(fs *pflag.FlagSet) (*query.Query, func(), error) {
+	m, err := loadConfig(fs)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := openDatabase(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	return query.Use(client.DB()), func() { client.Close() }, nil
+}
+
+// choosePassword 校验指定的密码，未指定时随机生成并返回 true
+func choosePassword(password *string) (bool, error) {
+	if *password == "" {
+		generated, err := crypto.RandomHex(6)
+		if err != nil {
+			return false, err
+		}
+		*password = generated
+		return true, nil
+	}
+	if n := len(*password); n < 6 || n > 15 {
+		return false, errors.New("密码长度需为6-15位")
+	}
+	return false, nil
+}
+
+// printGeneratedPassword 打印随机生成的密码
+func printGeneratedPassword(generated bool, password string) {
+	if generated {
+		fmt.Printf("密码: %s（仅显示一次，请妥善保存）\n", password)
+	}
+}
@@ -33,7 +33,7 @@ func NewGinUtils(l logger.Logger) {
 // Bind 绑定参数
 func (g *ginUtils) Bind(ctx *gin.Context, obj interface{}) error {
 	if err := ctx.ShouldBind(obj); err != nil {
-		g.l.Error("Bind error", zap.Error(err))
+		g.l.WithContext(ctx.Request.Context()).Error("Bind error", zap.Error(err))
 		return errs.ErrParams
 	}
 	return nil
@@ -42,7 +42,7 @@ func (g *ginUtils) Bind(ctx *gin.Context, obj interface{}) error {
 // BindQuery 绑定Query参数
 func (g *ginUtils) BindQuery(ctx *gin.Context, obj interface{}) error {
 	if err := ctx.ShouldBindQuery(obj); err != nil {
-		g.l.Error("BindQuery error", zap.Error(err))
+		g.l.WithContext(ctx.Request.Context()).Error("BindQuery error", zap.Error(err))
 		return errs.ErrParams
 	}
 	return nil
@@ -51,7 +51,7 @@ func (g *ginUtils) BindQuery(ctx *gin.Context, obj interface{}) error {
 // BindHeader 绑定Header参数
 func (g *ginUtils) BindHeader(ctx *gin.Context, obj interface{}) error {
 	if err := ctx.ShouldBindHeader(obj); err != nil {
-		g.l.Error("BindHeader error", zap.Error(err))
+		g.l.WithContext(ctx.Request.Context()).Error("BindHeader error", zap.Error(err))
 		return errs.ErrParams
 	}
 	return nil
@@ -60,7 +60,7 @@ func (g *ginUtils) BindHeader(ctx *gin.Context, obj interface{}) error {
 // BindUri 绑定Uri参数
 func (g *ginUtils) BindUri(ctx *gin.Context, obj interface{}) error {
 	if err := ctx.ShouldBindUri(obj); err != nil {
-		g.l.Error("BindUri error", zap.Error(err))
+		g.l.WithContext(ctx.Request.Context()).Error("BindUri error", zap.Error(err))
 		return errs.ErrParams
 	}
 	return nil
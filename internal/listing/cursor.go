@@ -0,0 +1,223 @@
+package listing
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"sweet/internal/models"
+	"sweet/pkg/crypto"
+	"sweet/pkg/database"
+	"sweet/pkg/errs"
+)
+
+const (
+	// CountCap 近似总数的计数上限，超过时返回该值
+	CountCap = 10000
+
+	cursorCreatedAt = "created_at"
+	cursorID        = "id"
+)
+
+var (
+	cursorKey   []byte
+	cursorKeyMu sync.RWMutex
+)
+
+func init() {
+	// 未配置密钥时使用进程内随机密钥，重启后旧游标失效
+	key, err := crypto.RandomBytes(32)
+	if err != nil {
+		panic(err)
+	}
+	cursorKey = key
+}
+
+// SetCursorKey 设置游标签名密钥，多实例部署需配置相同的密钥
+func SetCursorKey(key []byte) {
+	cursorKeyMu.Lock()
+	defer cursorKeyMu.Unlock()
+	cursorKey = append([]byte(nil), key...)
+}
+
+// Cursor 游标位置：上一页最后一条记录的创建时间与ID
+type Cursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// EncodeCursor 生成签名游标，scope 区分不同列表，避免游标混用
+func EncodeCursor(scope string, c Cursor) string {
+	payload := strconv.FormatInt(c.CreatedAt.UnixNano(), 36) + "." + strconv.FormatInt(c.ID, 36)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(signCursor(scope, payload))
+}
+
+// DecodeCursor 校验签名并解析游标，无效时返回 errs.ErrCursorInvalid
+func DecodeCursor(scope, token string) (Cursor, error) {
+	idx := strings.LastIndexByte(token, '.')
+	if idx < 0 {
+		return Cursor{}, errs.ErrCursorInvalid
+	}
+	payload := token[:idx]
+	mac, err := base64.RawURLEncoding.DecodeString(token[idx+1:])
+	if err != nil || !crypto.HMACVerify(currentCursorKey(), []byte(scope+"\n"+payload), mac) {
+		return Cursor{}, errs.ErrCursorInvalid
+	}
+
+	nanos, id, ok := strings.Cut(payload, ".")
+	if !ok {
+		return Cursor{}, errs.ErrCursorInvalid
+	}
+	n, err := strconv.ParseInt(nanos, 36, 64)
+	if err != nil {
+		return Cursor{}, errs.ErrCursorInvalid
+	}
+	i, err := strconv.ParseInt(id, 36, 64)
+	if err != nil {
+		return Cursor{}, errs.ErrCursorInvalid
+	}
+	return Cursor{CreatedAt: time.Unix(0, n), ID: i}, nil
+}
+
+// signCursor 计算游标签名
+func signCursor(scope, payload string) []byte {
+	return crypto.HMACSign(currentCursorKey(), []byte(scope+"\n"+payload))
+}
+
+// currentCursorKey 当前的游标签名密钥
+func currentCursorKey() []byte {
+	cursorKeyMu.RLock()
+	defer cursorKeyMu.RUnlock()
+	return cursorKey
+}
+
+// CursorDao gen 生成的 I<X>Do 接口中游标分页用到的方法
+type CursorDao[D any, E any] interface {
+	Where(conds ...gen.Condition) D
+	Order(conds ...field.Expr) D
+	Limit(limit int) D
+	Find() ([]*E, error)
+	UnderlyingDB() *gorm.DB
+}
+
+// FindByCursor 按 (created_at, id) 倒序的键集分页查询，适用于日志等大表
+// 请求需内嵌 models.CursorReq，过滤条件与 Find 相同；依赖 created_at 上的索引（InnoDB 二级索引隐含主键）
+func FindByCursor[D CursorDao[D, E], E any, T any](do D, table Table, scope string, req any, convert func(*E) *T) (*models.CursorRes[T], error) {
+	spec, err := Parse(table, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(spec.Conds) > 0 {
+		do = do.Where(spec.Conds...)
+	}
+
+	res := &models.CursorRes[T]{}
+	if spec.Cursor.Cursor == "" {
+		if spec.Cursor.WithTotal {
+			total, err := approximateCount(do.UnderlyingDB())
+			if err != nil {
+				return nil, err
+			}
+			res.Total = &total
+		}
+	} else {
+		c, err := DecodeCursor(scope, spec.Cursor.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		// 行值比较 (created_at, id) < (?, ?) 可直接使用索引范围扫描
+		do = do.Where(field.NewUnsafeFieldRaw("(?, ?) < (?, ?)",
+			clause.Column{Name: cursorCreatedAt}, clause.Column{Name: cursorID}, c.CreatedAt, c.ID))
+	}
+
+	createdAt, ok := table.GetFieldByName(cursorCreatedAt)
+	if !ok {
+		return nil, fmt.Errorf("listing: 游标分页需要 %s 列", cursorCreatedAt)
+	}
+	id, ok := table.GetFieldByName(cursorID)
+	if !ok {
+		return nil, fmt.Errorf("listing: 游标分页需要 %s 列", cursorID)
+	}
+
+	size := spec.Cursor.Size
+	if size <= 0 {
+		size = DefaultSize
+	}
+	if size > MaxSize {
+		size = MaxSize
+	}
+	// 多查一条判断是否还有下一页
+	records, err := do.Order(createdAt.Desc(), id.Desc()).Limit(size + 1).Find()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > size {
+		records = records[:size]
+		res.HasMore = true
+	}
+
+	res.List = make([]*T, 0, len(records))
+	for _, record := range records {
+		res.List = append(res.List, convert(record))
+	}
+	if res.HasMore {
+		c, err := cursorOf(records[len(records)-1])
+		if err != nil {
+			return nil, err
+		}
+		res.NextCursor = EncodeCursor(scope, c)
+	}
+	return res, nil
+}
+
+// cursorOf 读取实体的 CreatedAt 与 ID 字段
+func cursorOf(record any) (Cursor, error) {
+	v := reflect.Indirect(reflect.ValueOf(record))
+	idField, createdAtField := v.FieldByName("ID"), v.FieldByName("CreatedAt")
+	if !idField.IsValid() || idField.Kind() != reflect.Int64 {
+		return Cursor{}, fmt.Errorf("listing: %s 缺少 int64 类型的 ID 字段", v.Type())
+	}
+	if !createdAtField.IsValid() {
+		return Cursor{}, fmt.Errorf("listing: %s 缺少 CreatedAt 字段", v.Type())
+	}
+	id := idField.Int()
+	var createdAt time.Time
+	switch t := createdAtField.Interface().(type) {
+	case time.Time:
+		createdAt = t
+	case *time.Time:
+		if t != nil {
+			createdAt = *t
+		}
+	default:
+		return Cursor{}, fmt.Errorf("listing: %s 缺少 time.Time 类型的 CreatedAt 字段", v.Type())
+	}
+	return Cursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// approximateCount 近似总数：MySQL 无过滤条件且不限租户时读取表统计信息，否则计数至 CountCap 为止
+// 租户条件由插件在执行时追加，此时尚未出现在 WHERE 中，表统计信息是全部租户的行数，不能返回给普通租户
+func approximateCount(db *gorm.DB) (int64, error) {
+	var total int64
+	_, filtered := db.Statement.Clauses["WHERE"]
+	_, restricted := database.TenantFrom(db.Statement.Context)
+	if !filtered && !restricted && db.Dialector.Name() == "mysql" && db.Statement.Schema != nil {
+		err := db.Session(&gorm.Session{NewDB: true}).
+			Raw("SELECT TABLE_ROWS FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?", db.Statement.Schema.Table).
+			Scan(&total).Error
+		return total, err
+	}
+
+	sub := db.Session(&gorm.Session{}).Select("1").Limit(CountCap)
+	err := db.Session(&gorm.Session{NewDB: true}).Table("(?) AS t", sub).Count(&total).Error
+	return total, err
+}
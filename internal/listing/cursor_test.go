@@ -0,0 +1,103 @@
+package listing
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sweet/internal/models"
+	"sweet/internal/models/entity"
+	"sweet/pkg/errs"
+)
+
+// roleCursorReq 测试用的游标分页请求
+type roleCursorReq struct {
+	IsSuper *int64 `filter:"is_super"`
+	models.CursorReq
+}
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	c := Cursor{CreatedAt: time.Date(2024, 5, 1, 8, 30, 0, 123, time.UTC), ID: 42}
+	token := EncodeCursor("login_log", c)
+
+	got, err := DecodeCursor("login_log", token)
+	require.NoError(t, err)
+	assert.True(t, c.CreatedAt.Equal(got.CreatedAt))
+	assert.Equal(t, c.ID, got.ID)
+
+	_, err = DecodeCursor("operation_log", token)
+	assert.ErrorIs(t, err, errs.ErrCursorInvalid, "不同列表的游标不能混用")
+
+	tampered := strings.Replace(token, ".", "0.", 1)
+	_, err = DecodeCursor("login_log", tampered)
+	assert.ErrorIs(t, err, errs.ErrCursorInvalid)
+
+	for _, token := range []string{"", "abc", "a.b.c"} {
+		_, err = DecodeCursor("login_log", token)
+		assert.ErrorIs(t, err, errs.ErrCursorInvalid, token)
+	}
+
+	// 更换密钥后旧游标失效
+	old := currentCursorKey()
+	t.Cleanup(func() { SetCursorKey(old) })
+	SetCursorKey([]byte("another-key"))
+	_, err = DecodeCursor("login_log", token)
+	assert.ErrorIs(t, err, errs.ErrCursorInvalid)
+}
+
+func TestFindByCursor(t *testing.T) {
+	q := newTestQuery(t)
+	dao := q.SysRole
+	ctx := context.Background()
+
+	// 与已有记录创建时间相同的数据，按 ID 区分先后
+	same := time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)
+	normal := int64(2)
+	require.NoError(t, dao.WithContext(ctx).Create(&entity.SysRole{Name: "开发", Code: "dev", IsSuper: &normal, CreatedAt: &same, UpdatedAt: &same}))
+
+	toItem := func(role *entity.SysRole) *roleItem { return &roleItem{Code: role.Code} }
+	var (
+		codes []string
+		req   = roleCursorReq{CursorReq: models.CursorReq{Size: 2, WithTotal: true}}
+		pages int
+	)
+	for {
+		res, err := FindByCursor(dao.WithContext(ctx), &dao, "role", &req, toItem)
+		require.NoError(t, err)
+		if pages == 0 {
+			require.NotNil(t, res.Total)
+			assert.Equal(t, int64(5), *res.Total)
+		} else {
+			assert.Nil(t, res.Total, "只有首页返回总数")
+		}
+		for _, item := range res.List {
+			codes = append(codes, item.Code)
+		}
+		pages++
+		if !res.HasMore {
+			assert.Empty(t, res.NextCursor)
+			break
+		}
+		req.Cursor = res.NextCursor
+	}
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, []string{"dev", "guest", "ops", "auditor", "admin"}, codes)
+
+	// 过滤条件在每一页都生效
+	req = roleCursorReq{IsSuper: &normal, CursorReq: models.CursorReq{Size: 3}}
+	res, err := FindByCursor(dao.WithContext(ctx), &dao, "role", &req, toItem)
+	require.NoError(t, err)
+	assert.True(t, res.HasMore)
+	req.Cursor = res.NextCursor
+	res, err = FindByCursor(dao.WithContext(ctx), &dao, "role", &req, toItem)
+	require.NoError(t, err)
+	assert.Equal(t, []*roleItem{{Code: "auditor"}}, res.List)
+
+	req.Cursor = EncodeCursor("other", Cursor{ID: 1})
+	_, err = FindByCursor(dao.WithContext(ctx), &dao, "role", &req, toItem)
+	assert.ErrorIs(t, err, errs.ErrCursorInvalid)
+}
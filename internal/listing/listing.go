@@ -0,0 +1,348 @@
+// Package listing 基于 gorm gen DAO 的通用列表查询：按 DTO 的 filter 标签生成条件，按白名单排序并分页
+//
+// 标签格式为 `filter:"列名[|列名...][,操作]"`，操作支持 eq（默认）、neq、like、in、gt、gte、lt、lte；
+// 多个列名之间为 OR 关系。零值字段（nil、空字符串、空切片、0）不参与过滤。
+// 内嵌的 models.PageReq、models.SortReq、models.TimeRangeReq 分别用于分页、排序和时间范围，
+// 时间范围默认作用于 created_at，可通过内嵌字段的 filter 标签指定其它列。
+// 日志等大表使用 FindByCursor 按 (created_at, id) 键集分页，请求内嵌 models.CursorReq。
+package listing
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"sweet/internal/models"
+)
+
+// 过滤操作
+const (
+	OpEq   = "eq"
+	OpNeq  = "neq"
+	OpLike = "like"
+	OpIn   = "in"
+	OpGt   = "gt"
+	OpGte  = "gte"
+	OpLt   = "lt"
+	OpLte  = "lte"
+)
+
+// opMethods 过滤操作对应的 gen 字段方法
+var opMethods = map[string]string{
+	OpEq:   "Eq",
+	OpNeq:  "Neq",
+	OpLike: "Like",
+	OpIn:   "In",
+	OpGt:   "Gt",
+	OpGte:  "Gte",
+	OpLt:   "Lt",
+	OpLte:  "Lte",
+}
+
+const (
+	// DefaultSize 默认每页数量
+	DefaultSize = 10
+	// MaxSize 默认每页最大数量
+	MaxSize = 100
+	// timeRangeColumn 时间范围默认作用的列
+	timeRangeColumn = "created_at"
+)
+
+var (
+	pageReqType      = reflect.TypeOf(models.PageReq{})
+	sortReqType      = reflect.TypeOf(models.SortReq{})
+	cursorReqType    = reflect.TypeOf(models.CursorReq{})
+	timeRangeReqType = reflect.TypeOf(models.TimeRangeReq{})
+	timeType         = reflect.TypeOf(time.Time{})
+)
+
+// Dao gen 生成的 I<X>Do 接口中列表查询用到的方法
+type Dao[D any, E any] interface {
+	Where(conds ...gen.Condition) D
+	Order(conds ...field.Expr) D
+	FindByPage(offset int, limit int) (result []*E, count int64, err error)
+}
+
+// Table gen 生成的表结构（如 global.Query.SysFile），按列名查找字段
+type Table interface {
+	GetFieldByName(fieldName string) (field.OrderExpr, bool)
+}
+
+// Options 列表查询选项
+type Options struct {
+	Sorts       []string     // 允许排序的列名，SortReq.Field 不在其中时使用 DefaultSort
+	DefaultSort []field.Expr // 默认排序
+	MaxSize     int          // 每页最大数量，默认 MaxSize
+}
+
+// Find 按 req 过滤、排序、分页查询，并用 convert 转换为列表项
+func Find[D Dao[D, E], E any, T any](do D, table Table, req any, opts Options, convert func(*E) *T) (*models.PageRes[T], error) {
+	spec, err := Parse(table, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(spec.Conds) > 0 {
+		do = do.Where(spec.Conds...)
+	}
+	orders, err := spec.Orders(table, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) > 0 {
+		do = do.Order(orders...)
+	}
+
+	offset, limit := spec.Limit(opts.MaxSize)
+	records, total, err := do.FindByPage(offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*T, 0, len(records))
+	for _, record := range records {
+		list = append(list, convert(record))
+	}
+	return models.NewPageRes(total, list), nil
+}
+
+// Spec 从请求中解析出的查询条件
+type Spec struct {
+	Conds  []gen.Condition
+	Page   models.PageReq
+	Sort   models.SortReq
+	Cursor models.CursorReq
+}
+
+// Parse 解析请求结构体的 filter 标签与内嵌的分页、排序、时间范围
+func Parse(table Table, req any) (*Spec, error) {
+	v := reflect.ValueOf(req)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return &Spec{}, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("listing: 请求必须是结构体，实际为 %s", v.Kind())
+	}
+	spec := &Spec{}
+	if err := spec.parseStruct(table, v); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// parseStruct 遍历结构体字段，内嵌结构体递归处理
+func (s *Spec) parseStruct(table Table, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+		tag := sf.Tag.Get("filter")
+
+		switch sf.Type {
+		case pageReqType:
+			s.Page = fv.Interface().(models.PageReq)
+			continue
+		case sortReqType:
+			s.Sort = fv.Interface().(models.SortReq)
+			continue
+		case cursorReqType:
+			s.Cursor = fv.Interface().(models.CursorReq)
+			continue
+		case timeRangeReqType:
+			column := tag
+			if column == "" {
+				column = timeRangeColumn
+			}
+			if err := s.timeRange(table, column, fv.Interface().(models.TimeRangeReq)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if tag == "-" || !sf.IsExported() {
+			continue
+		}
+		if tag == "" {
+			if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+				if err := s.parseStruct(table, fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := s.filter(table, sf.Name, tag, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filter 根据标签生成单个字段的过滤条件
+func (s *Spec) filter(table Table, name, tag string, fv reflect.Value) error {
+	if fv.IsZero() {
+		return nil
+	}
+	if fv.Kind() == reflect.Pointer {
+		fv = fv.Elem()
+	}
+	if fv.Kind() == reflect.Slice && fv.Len() == 0 {
+		return nil
+	}
+
+	columns, op, _ := strings.Cut(tag, ",")
+	if op == "" {
+		op = OpEq
+	}
+	method, ok := opMethods[op]
+	if !ok {
+		return fmt.Errorf("listing: 字段 %s 的过滤操作 %q 不支持", name, op)
+	}
+	if op == OpLike {
+		if fv.Kind() != reflect.String {
+			return fmt.Errorf("listing: 字段 %s 使用 like 时必须为字符串", name)
+		}
+		fv = reflect.ValueOf("%" + fv.String() + "%")
+	}
+
+	var exprs []field.Expr
+	for _, column := range strings.Split(columns, "|") {
+		expr, err := call(table, column, method, fv)
+		if err != nil {
+			return fmt.Errorf("listing: 字段 %s: %w", name, err)
+		}
+		exprs = append(exprs, expr)
+	}
+	if len(exprs) == 1 {
+		s.Conds = append(s.Conds, exprs[0])
+	} else {
+		s.Conds = append(s.Conds, field.Or(exprs...))
+	}
+	return nil
+}
+
+// timeRange 将 Unix 秒时间范围转换为闭区间条件
+func (s *Spec) timeRange(table Table, column string, r models.TimeRangeReq) error {
+	bounds := []struct {
+		value  int64
+		method string
+	}{
+		{r.StartTime, "Gte"},
+		{r.EndTime, "Lte"},
+	}
+	for _, b := range bounds {
+		if b.value <= 0 {
+			continue
+		}
+		expr, err := call(table, column, b.method, reflect.ValueOf(time.Unix(b.value, 0)))
+		if err != nil {
+			return fmt.Errorf("listing: 时间范围: %w", err)
+		}
+		s.Conds = append(s.Conds, expr)
+	}
+	return nil
+}
+
+// call 反射调用 gen 字段的比较方法（如 field.String.Like、field.Int64.In）
+func call(table Table, column, method string, value reflect.Value) (field.Expr, error) {
+	f, ok := table.GetFieldByName(column)
+	if !ok {
+		return nil, fmt.Errorf("列 %s 不存在", column)
+	}
+	m := reflect.ValueOf(f).MethodByName(method)
+	if !m.IsValid() {
+		return nil, fmt.Errorf("列 %s 不支持 %s", column, method)
+	}
+
+	mt := m.Type()
+	var args []reflect.Value
+	if mt.IsVariadic() {
+		// In(values ...T)：请求值为切片时逐个转换
+		elem := mt.In(mt.NumIn() - 1).Elem()
+		if value.Kind() != reflect.Slice {
+			value = reflect.Append(reflect.MakeSlice(reflect.SliceOf(value.Type()), 0, 1), value)
+		}
+		for i := 0; i < value.Len(); i++ {
+			arg, err := convert(value.Index(i), elem)
+			if err != nil {
+				return nil, fmt.Errorf("列 %s: %w", column, err)
+			}
+			args = append(args, arg)
+		}
+	} else {
+		if mt.NumIn() != 1 {
+			return nil, fmt.Errorf("列 %s 的 %s 方法签名不支持", column, method)
+		}
+		arg, err := convert(value, mt.In(0))
+		if err != nil {
+			return nil, fmt.Errorf("列 %s: %w", column, err)
+		}
+		args = append(args, arg)
+	}
+
+	out := m.Call(args)
+	expr, ok := out[0].Interface().(field.Expr)
+	if !ok {
+		return nil, fmt.Errorf("列 %s 的 %s 方法未返回条件", column, method)
+	}
+	return expr, nil
+}
+
+// convert 将请求值转换为字段方法的参数类型
+func convert(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Type() == t {
+		return v, nil
+	}
+	if t.Kind() == reflect.Interface && v.Type().Implements(t) {
+		return v, nil
+	}
+	// 时间字段只接受 time.Time，避免数值被当作时间
+	if t == timeType || !v.Type().ConvertibleTo(t) || (v.Kind() == reflect.String) != (t.Kind() == reflect.String) {
+		return reflect.Value{}, fmt.Errorf("%s 无法转换为 %s", v.Type(), t)
+	}
+	return v.Convert(t), nil
+}
+
+// Orders 按 SortReq 与白名单生成排序，未命中时使用默认排序
+func (s *Spec) Orders(table Table, opts Options) ([]field.Expr, error) {
+	if s.Sort.Field != "" {
+		for _, column := range opts.Sorts {
+			if column != s.Sort.Field {
+				continue
+			}
+			f, ok := table.GetFieldByName(column)
+			if !ok {
+				return nil, fmt.Errorf("listing: 排序列 %s 不存在", column)
+			}
+			if s.Sort.Order == "asc" {
+				return []field.Expr{f.Asc()}, nil
+			}
+			return []field.Expr{f.Desc()}, nil
+		}
+	}
+	return opts.DefaultSort, nil
+}
+
+// Limit 规范化分页参数，返回 offset 与 limit
+func (s *Spec) Limit(maxSize int) (int, int) {
+	if maxSize <= 0 {
+		maxSize = MaxSize
+	}
+	if s.Page.Page <= 0 {
+		s.Page.Page = 1
+	}
+	if s.Page.Size <= 0 {
+		s.Page.Size = DefaultSize
+	}
+	if s.Page.Size > maxSize {
+		s.Page.Size = maxSize
+	}
+	return (s.Page.Page - 1) * s.Page.Size, s.Page.Size
+}
@@ -0,0 +1,156 @@
+package listing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+
+	"sweet/internal/models"
+	"sweet/internal/models/entity"
+	"sweet/internal/models/query"
+	_ "sweet/pkg/crypto" // 注册实体使用的 encrypted 序列化器
+)
+
+// roleListReq 测试用的列表请求
+type roleListReq struct {
+	Keyword string   `filter:"name|code,like"`
+	Codes   []string `filter:"code,in"`
+	MinSort int      `filter:"sort,gte"`
+	IsSuper *int64   `filter:"is_super"`
+	Ignored string
+	models.TimeRangeReq
+	models.PageReq
+	models.SortReq
+}
+
+type roleItem struct {
+	Code string
+}
+
+// newTestQuery 创建内存数据库并写入角色数据
+func newTestQuery(t *testing.T) *query.Query {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	// SQLite 只有 INTEGER PRIMARY KEY 才能自增
+	stmt := &gorm.Statement{DB: db}
+	require.NoError(t, stmt.Parse(&entity.SysRole{}))
+	stmt.Schema.PrioritizedPrimaryField.DataType = schema.Int
+	require.NoError(t, db.AutoMigrate(&entity.SysRole{}))
+
+	super, normal := int64(1), int64(2)
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	roles := []*entity.SysRole{
+		{Name: "超级管理员", Code: "admin", Sort: 1, IsSuper: &super},
+		{Name: "审计员", Code: "auditor", Sort: 2, IsSuper: &normal},
+		{Name: "运维", Code: "ops", Sort: 3, IsSuper: &normal},
+		{Name: "访客", Code: "guest", Sort: 4, IsSuper: &normal},
+	}
+	for i, role := range roles {
+		created := base.AddDate(0, 0, i)
+		role.CreatedAt, role.UpdatedAt = &created, &created
+	}
+	require.NoError(t, db.Create(roles).Error)
+	return query.Use(db)
+}
+
+func TestFind(t *testing.T) {
+	q := newTestQuery(t)
+	dao := q.SysRole
+	ctx := context.Background()
+	opts := Options{Sorts: []string{"sort", "created_at"}, DefaultSort: []field.Expr{dao.Sort}}
+	toItem := func(role *entity.SysRole) *roleItem { return &roleItem{Code: role.Code} }
+	codes := func(res *models.PageRes[roleItem]) []string {
+		out := make([]string, 0, len(res.List))
+		for _, item := range res.List {
+			out = append(out, item.Code)
+		}
+		return out
+	}
+
+	normal := int64(2)
+	cases := []struct {
+		name  string
+		req   roleListReq
+		codes []string
+		total int64
+	}{
+		{"无条件使用默认排序", roleListReq{}, []string{"admin", "auditor", "ops", "guest"}, 4},
+		{"多列模糊匹配为或关系", roleListReq{Keyword: "o"}, []string{"auditor", "ops"}, 2},
+		{"in 条件", roleListReq{Codes: []string{"ops", "guest"}}, []string{"ops", "guest"}, 2},
+		{"范围与等值条件", roleListReq{MinSort: 2, IsSuper: &normal}, []string{"auditor", "ops", "guest"}, 3},
+		{"时间范围为闭区间", roleListReq{TimeRangeReq: models.TimeRangeReq{
+			StartTime: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).Unix(),
+			EndTime:   time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC).Unix(),
+		}}, []string{"auditor", "ops"}, 2},
+		{"白名单排序", roleListReq{SortReq: models.SortReq{Field: "created_at", Order: "desc"}}, []string{"guest", "ops", "auditor", "admin"}, 4},
+		{"不在白名单的排序使用默认排序", roleListReq{SortReq: models.SortReq{Field: "name", Order: "desc"}}, []string{"admin", "auditor", "ops", "guest"}, 4},
+		{"分页", roleListReq{PageReq: models.PageReq{Page: 2, Size: 3}}, []string{"guest"}, 4},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			res, err := Find(dao.WithContext(ctx), &dao, &c.req, opts, toItem)
+			require.NoError(t, err)
+			assert.Equal(t, c.codes, codes(res))
+			assert.Equal(t, c.total, res.Total)
+		})
+	}
+
+	// 与调用方已有的条件组合
+	res, err := Find(dao.WithContext(ctx).Where(dao.IsSuper.Eq(1)), &dao, &roleListReq{Keyword: "a"}, opts, toItem)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, codes(res))
+}
+
+func TestParse_Errors(t *testing.T) {
+	dao := newTestQuery(t).SysRole
+	cases := map[string]any{
+		"列不存在": &struct {
+			Name string `filter:"missing"`
+		}{Name: "a"},
+		"操作不支持": &struct {
+			Name string `filter:"name,between"`
+		}{Name: "a"},
+		"like 非字符串": &struct {
+			Sort int64 `filter:"sort,like"`
+		}{Sort: 1},
+		"类型不匹配": &struct {
+			Sort string `filter:"sort"`
+		}{Sort: "1"},
+		"时间范围列不存在": &struct {
+			models.TimeRangeReq `filter:"missing"`
+		}{models.TimeRangeReq{StartTime: 1}},
+	}
+	for name, req := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(&dao, req)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := Parse(&dao, "name")
+	assert.Error(t, err)
+}
+
+func TestSpec_Limit(t *testing.T) {
+	spec := &Spec{}
+	offset, limit := spec.Limit(0)
+	assert.Equal(t, 0, offset)
+	assert.Equal(t, DefaultSize, limit)
+
+	spec = &Spec{Page: models.PageReq{Page: 3, Size: 500}}
+	offset, limit = spec.Limit(50)
+	assert.Equal(t, 100, offset)
+	assert.Equal(t, 50, limit)
+}
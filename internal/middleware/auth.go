@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"sweet/common"
+	"sweet/internal/global"
+	systemDTO "sweet/internal/models/dto/system"
+	"sweet/internal/service/system"
+	"sweet/pkg/auth"
+	"sweet/pkg/database"
+	"sweet/pkg/errs"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// AuthTypeJwt JWT认证
+	AuthTypeJwt = "jwt"
+	// AuthTypeApiKey API密钥认证
+	AuthTypeApiKey = "api_key"
+
+	// NewTokenHeader 令牌刷新后返回新令牌的响应头
+	NewTokenHeader = "X-New-Token"
+	// ImpersonatedByHeader 模拟登录时返回发起模拟的管理员ID，供前端展示提示
+	ImpersonatedByHeader = "X-Impersonated-By"
+	// TenantHeader 超级租户指定要操作的租户，其他租户忽略该请求头
+	TenantHeader = "X-Tenant-ID"
+)
+
+// PasswordResetRoutes 需先修改密码的令牌可访问的接口（方法 + 路由模板），其余接口返回 ErrPasswordResetRequired
+var PasswordResetRoutes = map[string]bool{
+	"PUT /api/v1/auth/password": true,
+	"POST /api/v1/auth/logout":  true,
+	"GET /api/v1/auth/profile":  true,
+}
+
+// Auth 认证中间件
+// 支持 Authorization: Bearer <jwt>，以及 X-API-Key: <key> 或 Authorization: ApiKey <key> 两种API密钥传递方式
+func Auth(apiKeys system.IApiKeyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key := apiKeyFromRequest(c); key != "" {
+			authApiKey(c, apiKeys, key)
+			return
+		}
+		authJwt(c)
+	}
+}
+
+// authJwt 使用JWT认证
+func authJwt(c *gin.Context) {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		abort(c, errs.ErrAuthorization)
+		return
+	}
+
+	result, err := auth.CheckToken(c.Request.Context(), token)
+	if err != nil {
+		if errors.Is(err, auth.ErrUserAlreadyLogin) {
+			abort(c, errs.ErrLoginFromOther)
+			return
+		}
+		global.Logger.WithContext(c.Request.Context()).Debug("令牌校验失败", zap.Error(err))
+		abort(c, errs.ErrAuthorization)
+		return
+	}
+
+	if result.NeedRefresh {
+		c.Header(NewTokenHeader, result.Token)
+	}
+	loginJwt(c, result.Claims)
+}
+
+// loginJwt 写入令牌的认证主体后继续处理，需先修改密码的令牌仅放行 PasswordResetRoutes
+func loginJwt(c *gin.Context, claims *auth.Claims) {
+	if claims.PasswordReset && !PasswordResetRoutes[c.Request.Method+" "+c.FullPath()] {
+		abort(c, errs.ErrPasswordResetRequired)
+		return
+	}
+	setPrincipal(c, claims, AuthTypeJwt)
+	c.Next()
+}
+
+// authApiKey 使用API密钥认证
+func authApiKey(c *gin.Context, apiKeys system.IApiKeyService, key string) {
+	// 使用路由模板匹配sw_sys_api中登记的路径
+	path := c.FullPath()
+	if path == "" {
+		path = c.Request.URL.Path
+	}
+
+	principal, err := apiKeys.Authenticate(c.Request.Context(), &systemDTO.ApiKeyAuthReq{
+		Key:    key,
+		Method: c.Request.Method,
+		Path:   path,
+		IP:     c.ClientIP(),
+	})
+	if err != nil {
+		abort(c, err)
+		return
+	}
+
+	c.Set("api_key_id", principal.KeyID)
+	setPrincipal(c, &auth.Claims{
+		Uid:        principal.Uid,
+		TenantID:   principal.TenantID,
+		Username:   principal.Username,
+		Rid:        principal.RoleID,
+		DeviceType: AuthTypeApiKey,
+		UserType:   auth.BackendUser,
+	}, AuthTypeApiKey)
+	c.Next()
+}
+
+// NoImpersonation 禁止模拟登录状态访问的敏感接口
+// 适用于修改密码、管理API密钥、发起模拟登录等操作，需放在 Auth 之后
+func NoImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if claims, ok := common.Gin.GetClaims(c); ok && claims.Impersonated {
+			global.Logger.WithContext(c.Request.Context()).Warn(
+				"模拟登录访问受限接口",
+				zap.Int64("impersonator_id", claims.ImpersonatorID),
+				zap.Int64("uid", claims.Uid),
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.FullPath()),
+			)
+			abort(c, errs.ErrImpersonationRestricted)
+			return
+		}
+		c.Next()
+	}
+}
+
+// apiKeyFromRequest 从请求头中提取API密钥
+func apiKeyFromRequest(c *gin.Context) string {
+	if key := c.GetHeader(auth.APIKeyHeader); key != "" {
+		return key
+	}
+	if key, ok := strings.CutPrefix(c.GetHeader("Authorization"), auth.APIKeyScheme+" "); ok {
+		return key
+	}
+	return ""
+}
+
+// setPrincipal 将认证主体写入上下文
+func setPrincipal(c *gin.Context, claims *auth.Claims, authType string) {
+	c.Set("claims", claims)
+	c.Set("uid", claims.Uid)
+	c.Set("user_id", claims.Uid)
+	c.Set("auth_type", authType)
+
+	// 类型化的认证主体同时写入 gin.Context 与请求上下文，服务层及数据库插件据此识别当前用户
+	principal := auth.NewPrincipal(claims, authType)
+	principal.APIKeyID = c.GetInt64("api_key_id")
+	c.Set(auth.PrincipalKey, principal)
+	ctx := auth.WithPrincipal(c.Request.Context(), principal)
+	if principal.IsSuperTenant() {
+		if tenantID, err := strconv.ParseInt(c.GetHeader(TenantHeader), 10, 64); err == nil && tenantID > 0 {
+			ctx = database.WithTenant(ctx, tenantID)
+		}
+	}
+	c.Request = c.Request.WithContext(ctx)
+	if claims.Impersonated {
+		c.Set("impersonator_id", claims.ImpersonatorID)
+		c.Header(ImpersonatedByHeader, strconv.FormatInt(claims.ImpersonatorID, 10))
+	}
+}
+
+// abort 返回错误并终止请求
+func abort(c *gin.Context, err error) {
+	var e *errs.Error
+	if !errors.As(err, &e) {
+		err = errs.ErrServer
+	}
+	common.Gin.Res(c, err)
+	c.Abort()
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sweet/common"
+	"sweet/internal/models"
+	"sweet/pkg/auth"
+	"sweet/pkg/errs"
+	"sweet/pkg/logger"
+)
+
+func TestLoginJwt_PasswordReset(t *testing.T) {
+	l, err := logger.NewLogger(logger.DevelopmentConfig())
+	require.NoError(t, err)
+	common.NewGinUtils(l)
+
+	gin.SetMode(gin.TestMode)
+	claims := &auth.Claims{Uid: 1, TenantID: auth.SuperTenantID, Username: "admin", UserType: auth.BackendUser, PasswordReset: true}
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) { loginJwt(c, claims) })
+	for _, route := range [][2]string{
+		{http.MethodPut, "/api/v1/auth/password"},
+		{http.MethodPost, "/api/v1/auth/logout"},
+		{http.MethodGet, "/api/v1/users"},
+		{http.MethodPost, "/api/v1/api-keys"},
+	} {
+		engine.Handle(route[0], route[1], func(c *gin.Context) { common.Gin.Res(c, nil) })
+	}
+
+	do := func(method, path string) int {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		var res models.Response
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		return res.Code
+	}
+	assert.Equal(t, 200, do(http.MethodPut, "/api/v1/auth/password"))
+	assert.Equal(t, 200, do(http.MethodPost, "/api/v1/auth/logout"))
+	assert.Equal(t, errs.ErrPasswordResetRequired.Code, do(http.MethodGet, "/api/v1/users"), "修改密码前拒绝其他接口")
+	assert.Equal(t, errs.ErrPasswordResetRequired.Code, do(http.MethodPost, "/api/v1/api-keys"))
+
+	claims.PasswordReset = false
+	assert.Equal(t, 200, do(http.MethodGet, "/api/v1/users"))
+}
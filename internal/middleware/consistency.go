@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"fmt"
+	"time"
+
+	"sweet/internal/global"
+	"sweet/pkg/auth"
+	"sweet/pkg/database"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ReadYourWrites 读己之写中间件，需放在 Auth 之后
+// 为每个请求创建读己之写会话，请求内写入后的读操作走主库；已认证用户的写入时间按用户记录在 store 中，
+// 窗口期内该用户的后续请求同样读主库。未配置从库或未启用时不做处理
+func ReadYourWrites(store database.WriteStore) gin.HandlerFunc {
+	cfg := global.DBClient.GetConfig()
+	if len(cfg.Slaves) == 0 || !cfg.Consistency.Enabled {
+		return func(c *gin.Context) { c.Next() }
+	}
+	window := cfg.Consistency.Window
+
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		key := ""
+		if uid, ok := auth.UidFrom(ctx); ok {
+			key = fmt.Sprintf(database.LastWriteCache, uid)
+		}
+
+		session := database.NewSession(lastWrite(c, store, key))
+		c.Request = c.Request.WithContext(database.WithSession(ctx, session))
+		c.Next()
+
+		if key == "" || !session.Written() {
+			return
+		}
+		if err := store.MarkWrite(ctx, key, session.LastWrite(), window); err != nil {
+			global.Logger.WithContext(c.Request.Context()).Warn("记录最近写入时间失败", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
+
+// lastWrite 读取用户最近一次写入时间，读取失败时按无写入处理
+func lastWrite(c *gin.Context, store database.WriteStore, key string) time.Time {
+	if key == "" {
+		return time.Time{}
+	}
+	at, err := store.LastWrite(c.Request.Context(), key)
+	if err != nil {
+		global.Logger.WithContext(c.Request.Context()).Warn("读取最近写入时间失败", zap.String("key", key), zap.Error(err))
+	}
+	return at
+}
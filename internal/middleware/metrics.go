@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"sweet/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics HTTP 指标中间件，按请求方法、路由模板与状态码统计请求数与耗时
+// 未匹配路由的请求统一记为 unmatched，避免按实际路径产生过多标签
+func Metrics(registry *metrics.Registry) gin.HandlerFunc {
+	path := registry.Config().Path
+	return func(c *gin.Context) {
+		if c.Request.URL.Path == path {
+			c.Next()
+			return
+		}
+
+		done := registry.RequestStarted()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		done(c.Request.Method, route, c.Writer.Status())
+	}
+}
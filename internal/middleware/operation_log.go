@@ -0,0 +1,212 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"sweet/common"
+	"sweet/internal/global"
+	"sweet/internal/models"
+	"sweet/internal/models/entity"
+	"sweet/pkg/crypto"
+	"sweet/pkg/database"
+	"sweet/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// operationLogMaxBody 请求参数与响应数据的最大记录长度
+	operationLogMaxBody = 2000
+	// operationLogMasked 敏感字段脱敏后的值
+	operationLogMasked = "******"
+)
+
+// operationLogSensitiveKeys 需要脱敏的请求字段（包含匹配，忽略大小写）
+var operationLogSensitiveKeys = []string{"password", "secret", "token", "key"}
+
+// bodyWriter 记录响应内容的ResponseWriter
+type bodyWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *bodyWriter) Write(b []byte) (int, error) {
+	if w.body.Len() < operationLogMaxBody {
+		w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// OperationLog 操作日志中间件，需放在 Auth 之后
+// 记录已认证用户的写操作；模拟登录期间的请求无论读写均会记录，并标记发起模拟的管理员ID
+func OperationLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := common.Gin.GetClaims(c)
+		if !ok || (!claims.Impersonated && isReadMethod(c.Request.Method)) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		params := readRequestParams(c)
+		writer := &bodyWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = writer
+
+		c.Next()
+
+		path := c.FullPath()
+		if path == "" {
+			path = c.Request.URL.Path
+		}
+		// IP 加密存储，盲索引用于按IP筛选；字段加密未初始化时写入同样会失败，不再记录
+		ip := c.ClientIP()
+		ipIndex, err := crypto.BlindIndex(&ip)
+		if err != nil {
+			global.Logger.WithContext(c.Request.Context()).Error(
+				"计算操作日志IP盲索引失败",
+				zap.Int64("uid", claims.Uid),
+				zap.Error(err),
+			)
+			return
+		}
+		log := &entity.SysOperationLog{
+			UserID:        utils.Ptr(claims.Uid),
+			Username:      utils.Ptr(claims.Username),
+			Module:        operationModule(path),
+			Operation:     operationName(c.Request.Method),
+			Method:        c.Request.Method,
+			URL:           truncate(c.Request.URL.RequestURI(), 255),
+			IP:            ip,
+			IPIndex:       ipIndex,
+			UserAgent:     utils.Ptr(truncate(c.Request.UserAgent(), 500)),
+			RequestParams: utils.Ptr(params),
+			ResponseData:  utils.Ptr(truncate(writer.body.String(), operationLogMaxBody)),
+			Status:        utils.Ptr(int64(1)),
+			CostTime:      utils.Ptr(time.Since(start).Milliseconds()),
+		}
+		if claims.Impersonated {
+			log.ImpersonatorID = utils.Ptr(claims.ImpersonatorID)
+		}
+		if code, msg := responseResult(c, writer.body.Bytes()); code != 200 {
+			log.Status = utils.Ptr(int64(2))
+			log.ErrorMsg = utils.Ptr(truncate(msg, 500))
+		}
+
+		// 异步写入，不阻塞响应；请求结束后上下文会被取消，租户需在此之前确定，否则日志会落入超级租户
+		reqCtx := c.Request.Context()
+		logCtx := database.WithTenant(context.WithoutCancel(reqCtx), database.CurrentTenant(reqCtx))
+		go func() {
+			ctx, cancel := context.WithTimeout(logCtx, 5*time.Second)
+			defer cancel()
+			if err := global.Query.SysOperationLog.WithContext(ctx).Create(log); err != nil {
+				global.Logger.WithContext(ctx).Error(
+					"写入操作日志失败",
+					zap.Int64("uid", claims.Uid),
+					zap.String("url", log.URL),
+					zap.Error(err),
+				)
+			}
+		}()
+	}
+}
+
+// isReadMethod 是否为只读请求
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// operationModule 从路由中解析操作模块，如 /api/v1/users/:id -> users
+func operationModule(path string) string {
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		// 跳过 api 前缀与版本号
+		if segment == "api" || len(segment) > 1 && segment[0] == 'v' && strings.Trim(segment[1:], "0123456789") == "" {
+			continue
+		}
+		if segment != "" {
+			return truncate(segment, 50)
+		}
+	}
+	return "unknown"
+}
+
+// operationName 根据HTTP方法确定操作类型
+func operationName(method string) string {
+	switch method {
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut, http.MethodPatch:
+		return "update"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return "query"
+	}
+}
+
+// readRequestParams 读取请求参数并脱敏，读取后回填请求体
+func readRequestParams(c *gin.Context) string {
+	if c.Request.Body == nil || strings.HasPrefix(c.ContentType(), "multipart/") {
+		return c.Request.URL.RawQuery
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return ""
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) == 0 {
+		return c.Request.URL.RawQuery
+	}
+
+	var params map[string]any
+	if err = json.Unmarshal(body, &params); err != nil {
+		return truncate(string(body), operationLogMaxBody)
+	}
+	maskParams(params)
+	masked, _ := json.Marshal(params)
+	return truncate(string(masked), operationLogMaxBody)
+}
+
+// maskParams 递归脱敏敏感字段
+func maskParams(params map[string]any) {
+	for k := range params {
+		lower := strings.ToLower(k)
+		for _, key := range operationLogSensitiveKeys {
+			if strings.Contains(lower, key) {
+				params[k] = operationLogMasked
+				break
+			}
+		}
+		if nested, ok := params[k].(map[string]any); ok {
+			maskParams(nested)
+		}
+	}
+}
+
+// responseResult 解析响应中的业务状态码
+func responseResult(c *gin.Context, body []byte) (int, string) {
+	if c.Writer.Status() >= http.StatusBadRequest {
+		return c.Writer.Status(), http.StatusText(c.Writer.Status())
+	}
+	var res models.Response
+	if err := json.Unmarshal(body, &res); err != nil || res.Code == 0 {
+		return 200, ""
+	}
+	return res.Code, res.Message
+}
+
+// truncate 截断字符串，按字符处理避免截断多字节字符
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
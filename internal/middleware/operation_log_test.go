@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"sweet/common"
+	"sweet/internal/global"
+	"sweet/internal/models/entity"
+	"sweet/internal/models/query"
+	"sweet/pkg/auth"
+	"sweet/pkg/crypto"
+	"sweet/pkg/database"
+	"sweet/pkg/logger"
+	"sweet/pkg/migrate"
+	"sweet/resource"
+)
+
+// newTestDB 创建启用多租户插件的内存数据库，并初始化中间件依赖的全局对象
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: gormlogger.Discard})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, db.Use(database.NewTenantPlugin()))
+
+	fsys, err := resource.MigrationsFor("sqlite")
+	require.NoError(t, err)
+	migrations, err := migrate.Load(fsys)
+	require.NoError(t, err)
+	_, err = migrate.New(db, migrations, migrate.DefaultConfig()).Up(context.Background(), 0)
+	require.NoError(t, err)
+
+	l, err := logger.NewLogger(logger.DevelopmentConfig())
+	require.NoError(t, err)
+	oldQuery, oldLogger := global.Query, global.Logger
+	global.Query, global.Logger = query.Use(db), l
+	t.Cleanup(func() { global.Query, global.Logger = oldQuery, oldLogger })
+	common.NewGinUtils(l)
+
+	// IP、用户代理加密存储
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	require.NoError(t, crypto.NewFieldCrypto(&crypto.FieldConfig{
+		ActiveKey: "k1",
+		Keys:      []crypto.FieldKey{{ID: "k1", Key: key}},
+		IndexKey:  key,
+	}))
+	return db
+}
+
+// newTestEngine 模拟 Auth 中间件写入认证主体后挂载操作日志中间件
+func newTestEngine(claims *auth.Claims) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		setPrincipal(c, claims, AuthTypeJwt)
+		c.Next()
+	}, OperationLog())
+	engine.POST("/api/v1/users", func(c *gin.Context) {
+		common.Gin.Res(c, nil)
+	})
+	return engine
+}
+
+func TestOperationLog_TenantID(t *testing.T) {
+	db := newTestDB(t)
+	engine := newTestEngine(&auth.Claims{Uid: 7, TenantID: 3, Username: "tenant-admin", UserType: auth.BackendUser})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/users", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// 日志在请求结束后异步写入
+	var log entity.SysOperationLog
+	require.Eventually(t, func() bool {
+		return db.Session(&gorm.Session{NewDB: true}).Where("user_id = ?", 7).Take(&log).Error == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(3), log.TenantID, "操作日志归属请求用户的租户")
+
+	// 按IP筛选使用盲索引，中间件写入的日志同样需要
+	ipIndex, err := crypto.BlindIndex(&log.IP)
+	require.NoError(t, err)
+	require.NotNil(t, log.IPIndex)
+	assert.Equal(t, *ipIndex, *log.IPIndex)
+}
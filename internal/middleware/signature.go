@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"errors"
+
+	"sweet/internal/global"
+	"sweet/pkg/auth"
+	"sweet/pkg/errs"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AuthTypeSignature 请求签名认证
+const AuthTypeSignature = "signature"
+
+// Signature 请求签名中间件，用于内部服务间调用
+// 需先调用 auth.NewSignature 初始化，校验通过后在上下文中写入 sign_client
+func Signature() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clientID, err := auth.VerifySignature(c.Request.Context(), c.Request)
+		if err != nil {
+			global.Logger.WithContext(c.Request.Context()).Warn(
+				"请求签名校验失败",
+				zap.String("client", c.GetHeader(auth.SignClientHeader)),
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("ip", c.ClientIP()),
+				zap.Error(err),
+			)
+			abort(c, signatureError(err))
+			return
+		}
+
+		c.Set("sign_client", clientID)
+		c.Set("auth_type", AuthTypeSignature)
+		c.Next()
+	}
+}
+
+// signatureError 将签名校验错误转换为业务错误
+func signatureError(err error) error {
+	switch {
+	case errors.Is(err, auth.ErrSignatureExpired):
+		return errs.ErrSignatureExpired
+	case errors.Is(err, auth.ErrSignatureReplay):
+		return errs.ErrSignatureReplay
+	case errors.Is(err, auth.ErrSignatureBodyTooLarge):
+		return errs.ErrSignatureBodyTooLarge
+	case errors.Is(err, auth.ErrSignatureMissing),
+		errors.Is(err, auth.ErrSignatureClient),
+		errors.Is(err, auth.ErrSignatureBody),
+		errors.Is(err, auth.ErrSignatureInvalid):
+		return errs.ErrSignatureInvalid
+	default:
+		return err
+	}
+}
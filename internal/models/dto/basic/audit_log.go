@@ -0,0 +1,28 @@
+package basic
+
+import (
+	"encoding/json"
+	"sweet/internal/models"
+	"time"
+)
+
+// ListAuditLogReq 获取实体变更记录请求
+type ListAuditLogReq struct {
+	Entity   string `form:"entity" binding:"required"`    // 表名，如 sw_sys_user
+	EntityID string `form:"entity_id" binding:"required"` // 主键值
+	models.PageReq
+}
+
+// AuditLogItem 实体变更记录
+type AuditLogItem struct {
+	ID        int64           `json:"id"`         // 审计ID
+	Action    string          `json:"action"`     // 动作：update / delete
+	Before    json.RawMessage `json:"before"`     // 变更前快照
+	After     json.RawMessage `json:"after"`      // 变更后快照，删除时为空
+	Changes   json.RawMessage `json:"changes"`    // 字段差异 {列名: {old, new}}
+	UserID    *int64          `json:"user_id"`    // 操作用户ID
+	CreatedAt time.Time       `json:"created_at"` // 变更时间
+}
+
+// ListAuditLogRes 获取实体变更记录响应
+type ListAuditLogRes models.PageRes[AuditLogItem]
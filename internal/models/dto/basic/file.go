@@ -3,6 +3,8 @@ package basic
 import (
 	"mime/multipart"
 	"time"
+
+	"sweet/internal/models"
 )
 
 // UploadFileReq 上传文件请求
@@ -41,20 +43,18 @@ type GetFileReq struct {
 	ID int64 `uri:"id" binding:"required" json:"id"` // 文件ID
 }
 
-// ListFileReq 文件列表查询请求
+// ListFileReq 文件列表查询请求，filter 标签由 listing 包转换为查询条件
+// 时间范围作用于创建时间，排序字段支持 created_at、updated_at、file_size
 type ListFileReq struct {
-	Page        int    `form:"page" json:"page"`                                                      // 页码
-	PageSize    int    `form:"page_size" json:"page_size"`                                            // 每页数量
-	Name        string `form:"name" json:"name"`                                                      // 文件名称（模糊查询）
-	FileType    string `form:"file_type" json:"file_type"`                                            // 文件类型
-	FileExt     string `form:"file_ext" json:"file_ext"`                                              // 文件扩展名
-	StorageType *int64 `form:"storage_type" json:"storage_type"`                                      // 存储类型
-	UserID      *int64 `form:"user_id" json:"user_id"`                                                // 上传用户ID
-	Status      *int64 `form:"status" json:"status"`                                                  // 状态
-	StartTime   string `form:"start_time" json:"start_time" validate:"omitempty,datetime=2006-01-02"` // 开始时间
-	EndTime     string `form:"end_time" json:"end_time" validate:"omitempty,datetime=2006-01-02"`     // 结束时间
-	OrderBy     string `form:"order_by" json:"order_by"`                                              // 排序字段
-	OrderType   string `form:"order_type" json:"order_type"`                                          // 排序方式：asc/desc
+	Name        string `form:"name" json:"name" filter:"name|original_name,like"`      // 文件名称或原始文件名（模糊查询）
+	FileType    string `form:"file_type" json:"file_type" filter:"file_type"`          // 文件类型
+	FileExt     string `form:"file_ext" json:"file_ext" filter:"file_ext"`             // 文件扩展名
+	StorageType *int64 `form:"storage_type" json:"storage_type" filter:"storage_type"` // 存储类型
+	UserID      *int64 `form:"user_id" json:"user_id" filter:"upload_user_id"`         // 上传用户ID
+	Status      *int64 `form:"status" json:"status" filter:"status"`                   // 状态
+	models.TimeRangeReq
+	models.PageReq
+	models.SortReq
 }
 
 // ListFileItem 文件列表项
@@ -74,10 +74,7 @@ type ListFileItem struct {
 }
 
 // ListFileRes 文件列表响应
-type ListFileRes struct {
-	List  []ListFileItem `json:"list"`  // 文件列表
-	Total int64          `json:"total"` // 总数
-}
+type ListFileRes models.PageRes[ListFileItem]
 
 // FileDetailRes 文件详情响应
 type FileDetailRes struct {
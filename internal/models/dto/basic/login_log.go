@@ -33,6 +33,7 @@ type ListLoginLogReq struct {
 	LoginType           int64               `json:"login_type"`  // 登录类型 （可选 根据登录类型查询）
 	ClientType          int64               `json:"client_type"` // 客户端类型 （可选 根据客户端类型查询）
 	Status              int64               `json:"status"`      // 登录状态 （可选 根据登录状态查询）
+	IP                  string              `json:"ip"`          // 登录IP （可选 精确匹配）
 	models.PageReq      `json:"page"`       // 分页参数
 	models.SortReq      `json:"sort"`       // 排序参数
 	models.TimeRangeReq `json:"time_range"` // 时间范围参数
@@ -54,6 +55,20 @@ type ListLoginLogItem struct {
 // ListLoginLogRes 获取登录日志列表响应
 type ListLoginLogRes models.PageRes[ListLoginLogItem]
 
+// CursorLoginLogReq 游标分页获取登录日志请求，用于无限滚动
+type CursorLoginLogReq struct {
+	Uid        int64  `json:"uid" form:"uid" filter:"user_id"`                     // 用户ID （可选 根据用户ID查询）
+	LoginType  int64  `json:"login_type" form:"login_type" filter:"login_type"`    // 登录类型 （可选 根据登录类型查询）
+	ClientType int64  `json:"client_type" form:"client_type" filter:"client_type"` // 客户端类型 （可选 根据客户端类型查询）
+	Status     int64  `json:"status" form:"status" filter:"status"`                // 登录状态 （可选 根据登录状态查询）
+	IP         string `json:"ip" form:"ip"`                                        // 登录IP （可选 精确匹配，使用盲索引）
+	models.TimeRangeReq
+	models.CursorReq
+}
+
+// CursorLoginLogRes 游标分页获取登录日志响应
+type CursorLoginLogRes models.CursorRes[ListLoginLogItem]
+
 // LoginLogDetailRes 获取登录日志详情响应
 type LoginLogDetailRes struct {
 	ID         int64      `json:"id"`          // 日志ID
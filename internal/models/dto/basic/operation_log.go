@@ -36,6 +36,7 @@ type ListOperationLogReq struct {
 	Operation string `json:"operation"` // 操作类型 （可选 根据操作类型查询）
 	Method    string `json:"method"`    // HTTP方法 （可选 根据方法查询）
 	Status    int64  `json:"status"`    // 操作状态 （可选 根据状态查询）
+	IP        string `json:"ip"`        // 操作IP （可选 精确匹配）
 	models.TimeRangeReq
 	models.PageReq // 分页参数
 	models.SortReq // 排序参数
@@ -59,6 +60,21 @@ type ListOperationLogItem struct {
 // ListOperationLogRes 获取操作日志列表响应
 type ListOperationLogRes models.PageRes[ListOperationLogItem]
 
+// CursorOperationLogReq 游标分页获取操作日志请求，用于无限滚动
+type CursorOperationLogReq struct {
+	Uid       int64  `json:"uid" form:"uid" filter:"user_id"`               // 用户ID （可选 根据用户ID查询）
+	Module    string `json:"module" form:"module" filter:"module"`          // 操作模块 （可选 根据模块查询）
+	Operation string `json:"operation" form:"operation" filter:"operation"` // 操作类型 （可选 根据操作类型查询）
+	Method    string `json:"method" form:"method" filter:"method"`          // HTTP方法 （可选 根据方法查询）
+	Status    int64  `json:"status" form:"status" filter:"status"`          // 操作状态 （可选 根据状态查询）
+	IP        string `json:"ip" form:"ip"`                                  // 操作IP （可选 精确匹配，使用盲索引）
+	models.TimeRangeReq
+	models.CursorReq
+}
+
+// CursorOperationLogRes 游标分页获取操作日志响应
+type CursorOperationLogRes models.CursorRes[ListOperationLogItem]
+
 // OperationLogDetailRes 获取操作日志详情响应
 type OperationLogDetailRes struct {
 	ID        int64      `json:"id"`         // 日志ID
@@ -0,0 +1,30 @@
+package basic
+
+import "time"
+
+// ListSlowQueryReq 获取慢查询统计请求
+type ListSlowQueryReq struct {
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`            // 返回的SQL指纹数，默认20
+	Sort  string `form:"sort" binding:"omitempty,oneof=total count max p99"` // 排序方式，默认按累计耗时
+}
+
+// SlowQueryItem 单个SQL指纹的慢查询统计
+type SlowQueryItem struct {
+	Fingerprint string    `json:"fingerprint"` // 归一化后的SQL
+	Example     string    `json:"example"`     // 最近一次的原始SQL
+	Count       int64     `json:"count"`       // 次数
+	Errors      int64     `json:"errors"`      // 执行出错的次数
+	Rows        int64     `json:"rows"`        // 累计影响行数
+	TotalMs     float64   `json:"total_ms"`    // 累计耗时（毫秒）
+	AvgMs       float64   `json:"avg_ms"`      // 平均耗时（毫秒）
+	P50Ms       float64   `json:"p50_ms"`      // P50耗时（毫秒）
+	P99Ms       float64   `json:"p99_ms"`      // P99耗时（毫秒）
+	MaxMs       float64   `json:"max_ms"`      // 最大耗时（毫秒）
+	FirstSeen   time.Time `json:"first_seen"`  // 首次出现时间
+	LastSeen    time.Time `json:"last_seen"`   // 最近出现时间
+}
+
+// ListSlowQueryRes 获取慢查询统计响应
+type ListSlowQueryRes struct {
+	List []*SlowQueryItem `json:"list"`
+}
@@ -0,0 +1,84 @@
+package system
+
+import (
+	"sweet/internal/models"
+	"time"
+)
+
+// CreateApiKeyReq 创建API密钥
+type CreateApiKeyReq struct {
+	Name        string  `json:"name" binding:"required,max=64"`              // 密钥名称
+	OwnerType   int64   `json:"owner_type" binding:"required,oneof=1 2"`     // 归属类型：1=用户，2=服务
+	UserID      *int64  `json:"user_id"`                                     // 归属用户ID，为空时归属当前用户
+	ServiceName *string `json:"service_name" binding:"omitempty,max=64"`     // 归属服务名称（归属类型为服务时必填）
+	ApiIds      []int64 `json:"api_ids" binding:"required,min=1,dive,min=1"` // 授权的ApiID列表
+	ExpiresAt   *int64  `json:"expires_at"`                                  // 过期时间（秒级时间戳，为空表示永不过期）
+	Remark      *string `json:"remark" binding:"omitempty,max=255"`          // 备注
+}
+
+// CreateApiKeyRes 创建API密钥响应
+type CreateApiKeyRes struct {
+	ID     int64  `json:"id"`     // 密钥ID
+	Key    string `json:"key"`    // 完整密钥，仅在创建时返回一次
+	Prefix string `json:"prefix"` // 密钥前缀
+}
+
+// RevokeApiKeyReq 吊销API密钥
+type RevokeApiKeyReq models.IdsReq
+
+// AssignApiKeyApiIdsReq 修改API密钥授权范围
+type AssignApiKeyApiIdsReq struct {
+	models.IDReq         // 密钥ID
+	ApiIds       []int64 `json:"api_ids" binding:"required,min=1,dive,min=1"` // ApiID列表
+}
+
+// ApiKeyListReq API密钥列表
+type ApiKeyListReq struct {
+	Name      string `json:"name"`       // 密钥名称
+	Prefix    string `json:"prefix"`     // 密钥前缀
+	OwnerType *int64 `json:"owner_type"` // 归属类型：1=用户，2=服务
+	UserID    *int64 `json:"user_id"`    // 归属用户ID
+	Status    *int64 `json:"status"`     // 状态：1=正常，2=已吊销
+	models.TimeRangeReq
+	models.PageReq
+}
+
+// ApiKeyListItem API密钥列表项
+type ApiKeyListItem struct {
+	ID          int64      `json:"id"`           // 密钥ID
+	Name        string     `json:"name"`         // 密钥名称
+	Prefix      string     `json:"prefix"`       // 密钥前缀
+	OwnerType   *int64     `json:"owner_type"`   // 归属类型：1=用户，2=服务
+	UserID      *int64     `json:"user_id"`      // 归属用户ID
+	ServiceName *string    `json:"service_name"` // 归属服务名称
+	Status      *int64     `json:"status"`       // 状态：1=正常，2=已吊销
+	ApiIds      []int64    `json:"api_ids"`      // 授权的ApiID列表
+	ExpiresAt   *time.Time `json:"expires_at"`   // 过期时间
+	LastUsedAt  *time.Time `json:"last_used_at"` // 最后使用时间
+	LastUsedIP  *string    `json:"last_used_ip"` // 最后使用IP
+	RevokedAt   *time.Time `json:"revoked_at"`   // 吊销时间
+	Remark      *string    `json:"remark"`       // 备注
+	CreatedAt   *time.Time `json:"created_at"`   // 创建时间
+}
+
+// ApiKeyListRes API密钥列表响应
+type ApiKeyListRes models.PageRes[ApiKeyListItem]
+
+// ApiKeyAuthReq API密钥认证请求
+type ApiKeyAuthReq struct {
+	Key    string // 完整密钥
+	Method string // 请求方法
+	Path   string // 路由路径（与sw_sys_api.path一致）
+	IP     string // 客户端IP
+}
+
+// ApiKeyPrincipal API密钥认证主体
+type ApiKeyPrincipal struct {
+	KeyID       int64  // 密钥ID
+	TenantID    int64  // 密钥所属租户ID
+	OwnerType   int64  // 归属类型：1=用户，2=服务
+	Uid         int64  // 归属用户ID（服务密钥为0）
+	Username    string // 归属用户名（服务密钥为服务名称）
+	RoleID      int64  // 归属用户角色ID
+	ServiceName string // 归属服务名称
+}
@@ -0,0 +1,90 @@
+package system
+
+import "time"
+
+// OIDCAuthURLReq 获取第三方授权地址请求
+type OIDCAuthURLReq struct {
+	Provider string `json:"provider" form:"provider" uri:"provider" binding:"required"` // 身份提供方名称
+}
+
+// OIDCAuthURLRes 获取第三方授权地址响应
+type OIDCAuthURLRes struct {
+	URL string `json:"url"` // 授权地址
+}
+
+// OIDCLoginReq 第三方登录回调请求
+type OIDCLoginReq struct {
+	Provider   string `json:"provider" form:"provider" uri:"provider" binding:"required"` // 身份提供方名称
+	Code       string `json:"code" form:"code" binding:"required"`                        // 授权码
+	State      string `json:"state" form:"state" binding:"required"`                      // 状态值
+	DeviceType string `json:"device_type" form:"device_type"`                             // 设备类型（pc,ios,android）
+}
+
+// PasswordKeyRes 登录密码加密公钥响应
+type PasswordKeyRes struct {
+	KeyID     string `json:"key_id"`     // 公钥指纹，登录时回传
+	Algorithm string `json:"algorithm"`  // 加密算法（RSA-OAEP-256）
+	PublicKey string `json:"public_key"` // PEM格式公钥
+}
+
+// LoginReq 账号密码登录请求
+// Password 为 {"password","timestamp","nonce"} JSON 经公钥加密后的Base64密文，timestamp为毫秒级时间戳
+type LoginReq struct {
+	Tenant     string `json:"tenant" binding:"omitempty,max=64"`  // 租户编码，为空时登录超级租户
+	Username   string `json:"username" binding:"required,max=32"` // 登录用户名
+	Password   string `json:"password" binding:"required"`        // 密码密文
+	KeyID      string `json:"key_id"`                             // 加密使用的公钥指纹
+	DeviceType string `json:"device_type"`                        // 设备类型（pc,ios,android）
+}
+
+// LoginRes 登录响应
+type LoginRes struct {
+	Token         string `json:"token"`          // 访问令牌
+	Uid           int64  `json:"uid"`            // 用户ID
+	Username      string `json:"username"`       // 用户名
+	PasswordReset bool   `json:"password_reset"` // 是否需要先修改密码
+}
+
+// ChangePasswordReq 修改密码请求
+// OldPassword 与 NewPassword 均为公钥加密后的密文，格式同 LoginReq.Password
+type ChangePasswordReq struct {
+	OldPassword string `json:"old_password" binding:"required"` // 原密码密文
+	NewPassword string `json:"new_password" binding:"required"` // 新密码密文
+	KeyID       string `json:"key_id"`                          // 加密使用的公钥指纹
+}
+
+// OAuthBindingItem 第三方账号绑定项
+type OAuthBindingItem struct {
+	ID          int64      `json:"id"`            // 绑定ID
+	Provider    string     `json:"provider"`      // 身份提供方名称
+	Subject     string     `json:"subject"`       // 外部用户标识
+	Email       *string    `json:"email"`         // 外部邮箱
+	Nickname    *string    `json:"nickname"`      // 外部昵称
+	LastLoginAt *time.Time `json:"last_login_at"` // 最后登录时间
+	CreatedAt   *time.Time `json:"created_at"`    // 绑定时间
+}
+
+// OAuthBindingRes 第三方账号绑定列表响应
+type OAuthBindingRes []*OAuthBindingItem
+
+// OAuthUnbindReq 解除第三方账号绑定请求
+type OAuthUnbindReq struct {
+	Provider string `json:"provider" binding:"required"` // 身份提供方名称
+}
+
+// ImpersonateReq 模拟登录请求
+type ImpersonateReq struct {
+	UserID   int64  `json:"user_id" binding:"required,min=1"`           // 被模拟用户ID
+	Duration int64  `json:"duration" binding:"omitempty,min=1,max=120"` // 有效期（分钟），默认30分钟
+	Reason   string `json:"reason" binding:"required,max=255"`          // 模拟原因
+}
+
+// ImpersonateRes 模拟登录响应
+type ImpersonateRes struct {
+	Token          string `json:"token"`           // 模拟登录令牌
+	Uid            int64  `json:"uid"`             // 被模拟用户ID
+	Username       string `json:"username"`        // 被模拟用户名
+	Impersonated   bool   `json:"impersonated"`    // 模拟登录标识
+	ImpersonatorID int64  `json:"impersonator_id"` // 发起模拟的管理员ID
+	ExpiresAt      int64  `json:"expires_at"`      // 过期时间（秒级时间戳）
+}
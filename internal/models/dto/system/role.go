@@ -21,12 +21,13 @@ type DeleteRoleReq models.IdsReq
 // UpdateRoleReq 更新角色
 type UpdateRoleReq struct {
 	models.IDReq
-	Name    string  `json:"name"`     // 角色名称
-	Code    string  `json:"code"`     // 角色标识
-	Sort    int64   `json:"sort"`     // 排序
-	IsSuper *int64  `json:"is_super"` // 是否超级管理员：1=是，2否
-	Status  *int64  `json:"status"`   // 状态：1=正常，2=禁用
-	Remark  *string `json:"remark"`   // 备注
+	Name    string  `json:"name"`                       // 角色名称
+	Code    string  `json:"code"`                       // 角色标识
+	Sort    int64   `json:"sort"`                       // 排序
+	IsSuper *int64  `json:"is_super"`                   // 是否超级管理员：1=是，2否
+	Status  *int64  `json:"status"`                     // 状态：1=正常，2=禁用
+	Remark  *string `json:"remark"`                     // 备注
+	Version int64   `json:"version" binding:"required"` // 读取时的版本号，数据已被修改时更新失败
 }
 
 // RoleListReq 角色列表
@@ -49,6 +50,7 @@ type RoleListItem struct {
 	IsSystem  *int64     `json:"is_system"`  // 是否系统内置：1=是，2否
 	IsSuper   *int64     `json:"is_super"`   // 是否超级管理员：1=是，2否
 	Status    *int64     `json:"status"`     // 状态：1=正常，2=禁用
+	Version   int64      `json:"version"`    // 版本号
 	CreatedAt *time.Time `json:"created_at"` // 创建时间
 }
 
@@ -65,6 +67,7 @@ type RoleDetailRes struct {
 	IsSuper   *int64     `json:"is_super"`   // 是否超级管理员：1=是，2否
 	Status    *int64     `json:"status"`     // 状态：1=正常，2=禁用
 	Remark    *string    `json:"remark"`     // 备注
+	Version   int64      `json:"version"`    // 版本号
 	CreatedAt *time.Time `json:"created_at"` // 创建时间
 	UpdatedAt *time.Time `json:"updated_at"` // 更新时间
 }
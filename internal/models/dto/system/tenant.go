@@ -0,0 +1,43 @@
+package system
+
+import (
+	"sweet/internal/models"
+	"time"
+)
+
+// CreateTenantReq 创建租户
+type CreateTenantReq struct {
+	Name   string  `json:"name" binding:"required,max=64"`          // 租户名称
+	Code   string  `json:"code" binding:"required,max=64,alphanum"` // 租户编码，登录时使用
+	Status *int64  `json:"status" binding:"omitempty,oneof=1 2"`    // 状态：1=正常，2=禁用
+	Remark *string `json:"remark" binding:"omitempty,max=255"`      // 备注
+}
+
+// UpdateTenantReq 更新租户
+type UpdateTenantReq struct {
+	models.IDReq
+	Name   string  `json:"name" binding:"omitempty,max=64"`      // 租户名称
+	Status *int64  `json:"status" binding:"omitempty,oneof=1 2"` // 状态：1=正常，2=禁用
+	Remark *string `json:"remark" binding:"omitempty,max=255"`   // 备注
+}
+
+// TenantListReq 租户列表
+type TenantListReq struct {
+	Keyword string `form:"keyword" filter:"name|code,like"` // 名称或编码
+	Status  *int64 `form:"status" filter:"status"`          // 状态：1=正常，2=禁用
+	models.PageReq
+	models.SortReq
+}
+
+// TenantListItem 租户列表项
+type TenantListItem struct {
+	ID        int64      `json:"id"`         // 租户ID
+	Name      string     `json:"name"`       // 租户名称
+	Code      string     `json:"code"`       // 租户编码
+	Status    *int64     `json:"status"`     // 状态：1=正常，2=禁用
+	Remark    *string    `json:"remark"`     // 备注
+	CreatedAt *time.Time `json:"created_at"` // 创建时间
+}
+
+// TenantListRes 租户列表响应
+type TenantListRes models.PageRes[TenantListItem]
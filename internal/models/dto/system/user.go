@@ -27,17 +27,18 @@ type DeleteUserReq models.IdsReq
 // UpdateUserReq 更新用户请求
 type UpdateUserReq struct {
 	models.IDReq
-	Password string  `json:"password"` // 登录密码
-	Realname string  `json:"realname"` // 真实姓名
-	Nickname string  `json:"nickname"` // 昵称
-	Avatar   *string `json:"avatar"`   // 头像
-	Email    *string `json:"email"`    // 邮箱
-	Phone    *string `json:"phone"`    // 手机号
-	Status   *int64  `json:"status"`   // 状态：1=正常，2=禁用
-	RoleID   *int64  `json:"role_id"`  // 角色ID
-	DeptID   *int64  `json:"dept_id"`  // 部门ID
-	PostID   *int64  `json:"post_id"`  // 岗位ID
-	Remark   *string `json:"remark"`   // 备注
+	Password string  `json:"password"`                   // 登录密码
+	Realname string  `json:"realname"`                   // 真实姓名
+	Nickname string  `json:"nickname"`                   // 昵称
+	Avatar   *string `json:"avatar"`                     // 头像
+	Email    *string `json:"email"`                      // 邮箱
+	Phone    *string `json:"phone"`                      // 手机号
+	Status   *int64  `json:"status"`                     // 状态：1=正常，2=禁用
+	RoleID   *int64  `json:"role_id"`                    // 角色ID
+	DeptID   *int64  `json:"dept_id"`                    // 部门ID
+	PostID   *int64  `json:"post_id"`                    // 岗位ID
+	Remark   *string `json:"remark"`                     // 备注
+	Version  int64   `json:"version" binding:"required"` // 读取时的版本号，数据已被修改时更新失败
 }
 
 // ListUserReq 获取用户列表请求
@@ -62,6 +63,7 @@ type ListUserItem struct {
 	Status    *int64     `json:"status"`     // 状态：1=正常，2=禁用
 	RoleID    int64      `json:"role_id"`    // 角色ID
 	RoleName  string     `json:"role_name"`  // 角色名称
+	Version   int64      `json:"version"`    // 版本号
 	CreatedAt *time.Time `json:"created_at"` // 创建时间
 }
 
@@ -85,6 +87,7 @@ type UserDetailRes struct {
 	PostID    int64      `json:"post_id"`    // 岗位ID
 	PostName  string     `json:"post_name"`  // 岗位名称
 	Remark    *string    `json:"remark"`     // 备注
+	Version   int64      `json:"version"`    // 版本号
 	CreatedAt *time.Time `json:"created_at"` // 创建时间
 	UpdatedAt *time.Time `json:"updated_at"` // 更新时间
 }
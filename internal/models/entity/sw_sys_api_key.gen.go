@@ -0,0 +1,41 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const TableNameSysApiKey = "sw_sys_api_key"
+
+// SysApiKey API密钥表
+type SysApiKey struct {
+	ID          int64          `gorm:"column:id;type:bigint unsigned;primaryKey;autoIncrement:true;comment:密钥ID" json:"id"`                 // 密钥ID
+	TenantID    int64          `gorm:"column:tenant_id;type:bigint unsigned;not null;default:1;comment:租户ID" json:"tenant_id"`              // 租户ID
+	Name        string         `gorm:"column:name;type:varchar(64);not null;comment:密钥名称" json:"name"`                                      // 密钥名称
+	Prefix      string         `gorm:"column:prefix;type:varchar(16);not null;comment:密钥前缀（明文，用于查找）" json:"prefix"`                         // 密钥前缀（明文，用于查找）
+	SecretHash  string         `gorm:"column:secret_hash;type:varchar(64);not null;comment:密钥哈希（SHA256）" json:"secret_hash"`                // 密钥哈希（SHA256）
+	OwnerType   *int64         `gorm:"column:owner_type;type:tinyint unsigned;not null;default:1;comment:归属类型：1=用户，2=服务" json:"owner_type"` // 归属类型：1=用户，2=服务
+	UserID      *int64         `gorm:"column:user_id;type:bigint unsigned;comment:归属用户ID（归属类型为用户时）" json:"user_id"`                         // 归属用户ID（归属类型为用户时）
+	ServiceName *string        `gorm:"column:service_name;type:varchar(64);comment:归属服务名称（归属类型为服务时）" json:"service_name"`                   // 归属服务名称（归属类型为服务时）
+	Status      *int64         `gorm:"column:status;type:tinyint unsigned;not null;default:1;comment:状态：1=正常，2=已吊销" json:"status"`          // 状态：1=正常，2=已吊销
+	ExpiresAt   *time.Time     `gorm:"column:expires_at;type:datetime;comment:过期时间（为空表示永不过期）" json:"expires_at"`                            // 过期时间（为空表示永不过期）
+	LastUsedAt  *time.Time     `gorm:"column:last_used_at;type:datetime;comment:最后使用时间" json:"last_used_at"`                                // 最后使用时间
+	LastUsedIP  *string        `gorm:"column:last_used_ip;type:varchar(45);comment:最后使用IP" json:"last_used_ip"`                             // 最后使用IP
+	RevokedAt   *time.Time     `gorm:"column:revoked_at;type:datetime;comment:吊销时间" json:"revoked_at"`                                      // 吊销时间
+	Remark      *string        `gorm:"column:remark;type:varchar(255);comment:备注" json:"remark"`                                            // 备注
+	CreateBy    *int64         `gorm:"column:create_by;type:bigint unsigned;comment:创建者" json:"create_by"`                                  // 创建者
+	CreatedAt   *time.Time     `gorm:"column:created_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"`   // 创建时间
+	UpdatedAt   *time.Time     `gorm:"column:updated_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:更新时间" json:"updated_at"`   // 更新时间
+	DeletedAt   gorm.DeletedAt `gorm:"column:deleted_at;type:datetime;comment:删除时间" json:"deleted_at"`                                      // 删除时间
+	User        *SysUser       `gorm:"foreignKey:UserID;references:ID" json:"user"`
+}
+
+// TableName SysApiKey's table name
+func (*SysApiKey) TableName() string {
+	return TableNameSysApiKey
+}
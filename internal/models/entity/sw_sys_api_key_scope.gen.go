@@ -0,0 +1,18 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package entity
+
+const TableNameSysApiKeyScope = "sw_sys_api_key_scope"
+
+// SysApiKeyScope API密钥授权范围表
+type SysApiKeyScope struct {
+	KeyID int64 `gorm:"column:key_id;type:bigint unsigned;primaryKey;comment:密钥ID" json:"key_id"`   // 密钥ID
+	APIID int64 `gorm:"column:api_id;type:bigint unsigned;primaryKey;comment:API ID" json:"api_id"` // API ID
+}
+
+// TableName SysApiKeyScope's table name
+func (*SysApiKeyScope) TableName() string {
+	return TableNameSysApiKeyScope
+}
@@ -15,6 +15,7 @@ const TableNameSysDept = "sw_sys_dept"
 // SysDept 部门表
 type SysDept struct {
 	ID        int64          `gorm:"column:id;type:bigint unsigned;primaryKey;autoIncrement:true;comment:部门ID" json:"id"`               // 部门ID
+	TenantID  int64          `gorm:"column:tenant_id;type:bigint unsigned;not null;default:1;comment:租户ID" json:"tenant_id"`            // 租户ID
 	ParentID  *int64         `gorm:"column:parent_id;type:bigint unsigned;comment:父部门ID" json:"parent_id"`                              // 父部门ID
 	Name      string         `gorm:"column:name;type:varchar(64);not null;comment:部门名称" json:"name"`                                    // 部门名称
 	Code      *string        `gorm:"column:code;type:varchar(64);comment:部门编码" json:"code"`                                             // 部门编码
@@ -15,6 +15,7 @@ const TableNameSysFile = "sw_sys_file"
 // SysFile 系统文件表
 type SysFile struct {
 	ID           int64          `gorm:"column:id;type:bigint unsigned;primaryKey;autoIncrement:true;comment:文件ID" json:"id"`                                          // 文件ID
+	TenantID     int64          `gorm:"column:tenant_id;type:bigint unsigned;not null;default:1;comment:租户ID" json:"tenant_id"`                                       // 租户ID
 	Name         string         `gorm:"column:name;type:varchar(64);not null;comment:文件名称" json:"name"`                                                               // 文件名称
 	OriginalName string         `gorm:"column:original_name;type:varchar(64);not null;comment:原始文件名" json:"original_name"`                                            // 原始文件名
 	FilePath     string         `gorm:"column:file_path;type:varchar(255);not null;comment:文件路径" json:"file_path"`                                                    // 文件路径
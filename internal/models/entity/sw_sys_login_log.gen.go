@@ -13,13 +13,15 @@ const TableNameSysLoginLog = "sw_sys_login_log"
 // SysLoginLog 系统登录日志表
 type SysLoginLog struct {
 	ID            int64      `gorm:"column:id;type:bigint unsigned;primaryKey;autoIncrement:true;comment:日志ID" json:"id"`                                          // 日志ID
+	TenantID      int64      `gorm:"column:tenant_id;type:bigint unsigned;not null;default:1;comment:租户ID" json:"tenant_id"`                                       // 租户ID
 	UserID        *int64     `gorm:"column:user_id;type:bigint unsigned;comment:用户ID" json:"user_id"`                                                              // 用户ID
 	Username      string     `gorm:"column:username;type:varchar(64);not null;comment:登录用户名" json:"username"`                                                      // 登录用户名
 	LoginType     *int64     `gorm:"column:login_type;type:tinyint(1);not null;default:1;comment:登录类型（1账号密码 2手机验证码 3邮箱验证码 4第三方登录 5微信 6QQ 7支付宝）" json:"login_type"` // 登录类型（1账号密码 2手机验证码 3邮箱验证码 4第三方登录 5微信 6QQ 7支付宝）
 	ClientType    *int64     `gorm:"column:client_type;type:tinyint(1);not null;default:1;comment:客户端类型（1Web 2移动端 3小程序 4API 5管理后台）" json:"client_type"`            // 客户端类型（1Web 2移动端 3小程序 4API 5管理后台）
-	IP            string     `gorm:"column:ip;type:varchar(45);not null;comment:登录IP" json:"ip"`                                                                   // 登录IP
+	IP            string     `gorm:"column:ip;type:varchar(255);not null;comment:登录IP（加密存储）;serializer:encrypted" json:"ip"`                                       // 登录IP（加密存储）
+	IPIndex       *string    `gorm:"column:ip_index;type:char(64);comment:登录IP盲索引" json:"-"`                                                                       // 登录IP盲索引
 	Location      *string    `gorm:"column:location;type:varchar(64);comment:IP归属地" json:"location"`                                                               // IP归属地
-	UserAgent     *string    `gorm:"column:user_agent;type:text;comment:用户代理" json:"user_agent"`                                                                   // 用户代理
+	UserAgent     *string    `gorm:"column:user_agent;type:text;comment:用户代理（加密存储）;serializer:encrypted" json:"user_agent"`                                        // 用户代理（加密存储）
 	DeviceInfo    *string    `gorm:"column:device_info;type:varchar(64);comment:设备信息" json:"device_info"`                                                          // 设备信息
 	Browser       *string    `gorm:"column:browser;type:varchar(100);comment:浏览器" json:"browser"`                                                                  // 浏览器
 	Os            *string    `gorm:"column:os;type:varchar(100);comment:操作系统" json:"os"`                                                                           // 操作系统
@@ -40,6 +40,7 @@ type SysMenu struct {
 	ActivePath    *string        `gorm:"column:active_path;type:varchar(255);comment:激活菜单路径" json:"active_path"`                            // 激活菜单路径
 	CreateBy      *int64         `gorm:"column:create_by;type:bigint unsigned;comment:创建者" json:"create_by"`                                // 创建者
 	UpdateBy      *int64         `gorm:"column:update_by;type:bigint unsigned;comment:更新者" json:"update_by"`                                // 更新者
+	Version       int64          `gorm:"column:version;type:int unsigned;not null;default:1;comment:版本号，乐观锁" json:"version"`                // 版本号，乐观锁
 	CreatedAt     *time.Time     `gorm:"column:created_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"` // 创建时间
 	UpdatedAt     *time.Time     `gorm:"column:updated_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:更新时间" json:"updated_at"` // 更新时间
 	DeletedAt     gorm.DeletedAt `gorm:"column:deleted_at;type:datetime;comment:删除时间" json:"deleted_at"`                                    // 删除时间
@@ -15,6 +15,7 @@ const TableNameSysPost = "sw_sys_post"
 // SysPost 岗位表
 type SysPost struct {
 	ID        int64          `gorm:"column:id;type:bigint unsigned;primaryKey;autoIncrement:true;comment:岗位ID" json:"id"`               // 岗位ID
+	TenantID  int64          `gorm:"column:tenant_id;type:bigint unsigned;not null;default:1;comment:租户ID" json:"tenant_id"`            // 租户ID
 	DeptID    int64          `gorm:"column:dept_id;type:bigint unsigned;not null;comment:所属部门" json:"dept_id"`                          // 所属部门
 	Name      string         `gorm:"column:name;type:varchar(64);not null;comment:岗位名称" json:"name"`                                    // 岗位名称
 	Code      *string        `gorm:"column:code;type:varchar(64);comment:岗位编码" json:"code"`                                             // 岗位编码
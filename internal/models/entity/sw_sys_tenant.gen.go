@@ -0,0 +1,30 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const TableNameSysTenant = "sw_sys_tenant"
+
+// SysTenant 租户表
+type SysTenant struct {
+	ID        int64          `gorm:"column:id;type:bigint unsigned;primaryKey;autoIncrement:true;comment:租户ID" json:"id"`               // 租户ID
+	Name      string         `gorm:"column:name;type:varchar(64);not null;comment:租户名称" json:"name"`                                    // 租户名称
+	Code      string         `gorm:"column:code;type:varchar(64);not null;comment:租户编码，登录时使用" json:"code"`                              // 租户编码，登录时使用
+	Status    *int64         `gorm:"column:status;type:tinyint unsigned;not null;default:1;comment:状态：1=正常，2=禁用" json:"status"`         // 状态：1=正常，2=禁用
+	Remark    *string        `gorm:"column:remark;type:varchar(255);comment:备注" json:"remark"`                                          // 备注
+	CreatedAt *time.Time     `gorm:"column:created_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"` // 创建时间
+	UpdatedAt *time.Time     `gorm:"column:updated_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:更新时间" json:"updated_at"` // 更新时间
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;type:datetime;comment:删除时间" json:"deleted_at"`                                    // 删除时间
+}
+
+// TableName SysTenant's table name
+func (*SysTenant) TableName() string {
+	return TableNameSysTenant
+}
@@ -0,0 +1,32 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package entity
+
+import (
+	"time"
+)
+
+const TableNameSysUserOauth = "sw_sys_user_oauth"
+
+// SysUserOauth 第三方账号绑定表
+type SysUserOauth struct {
+	ID          int64      `gorm:"column:id;type:bigint unsigned;primaryKey;autoIncrement:true;comment:绑定ID" json:"id"`               // 绑定ID
+	TenantID    int64      `gorm:"column:tenant_id;type:bigint unsigned;not null;default:1;comment:租户ID" json:"tenant_id"`            // 租户ID
+	UserID      int64      `gorm:"column:user_id;type:bigint unsigned;not null;comment:用户ID" json:"user_id"`                          // 用户ID
+	Provider    string     `gorm:"column:provider;type:varchar(32);not null;comment:身份提供方名称" json:"provider"`                         // 身份提供方名称
+	Issuer      string     `gorm:"column:issuer;type:varchar(255);not null;comment:签发者" json:"issuer"`                                // 签发者
+	Subject     string     `gorm:"column:subject;type:varchar(255);not null;comment:外部用户标识(sub)" json:"subject"`                      // 外部用户标识(sub)
+	Email       *string    `gorm:"column:email;type:varchar(64);comment:外部邮箱" json:"email"`                                           // 外部邮箱
+	Nickname    *string    `gorm:"column:nickname;type:varchar(64);comment:外部昵称" json:"nickname"`                                     // 外部昵称
+	LastLoginAt *time.Time `gorm:"column:last_login_at;type:datetime;comment:最后登录时间" json:"last_login_at"`                            // 最后登录时间
+	CreatedAt   *time.Time `gorm:"column:created_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:创建时间" json:"created_at"` // 创建时间
+	UpdatedAt   *time.Time `gorm:"column:updated_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:更新时间" json:"updated_at"` // 更新时间
+	User        *SysUser   `gorm:"foreignKey:UserID;references:ID" json:"user"`
+}
+
+// TableName SysUserOauth's table name
+func (*SysUserOauth) TableName() string {
+	return TableNameSysUserOauth
+}
@@ -1,7 +1,7 @@
 package models
 
 type IDReq struct {
-	ID int64 `json:"id" form:"id" binding:"required,min=1"`
+	ID int64 `json:"id" form:"id" uri:"id" binding:"required,min=1"`
 }
 
 type IdsReq struct {
@@ -9,13 +9,20 @@ type IdsReq struct {
 }
 
 type PageReq struct {
-	Page int `json:"page" form:"page" binding:"min=1" default:"1"`
-	Size int `json:"size" form:"size" binding:"min=1,max=100" default:"10"`
+	Page int `json:"page" form:"page,default=1" binding:"omitempty,min=1"`
+	Size int `json:"size" form:"size,default=10" binding:"omitempty,min=1,max=100"`
 }
 
 type SortReq struct {
 	Field string `json:"field" form:"field"`
-	Order string `json:"order" form:"order" binding:"oneof=asc desc" default:"desc"`
+	Order string `json:"order" form:"order,default=desc" binding:"omitempty,oneof=asc desc"`
+}
+
+// CursorReq 游标分页参数，Cursor 为上一页响应的 next_cursor，首页为空
+type CursorReq struct {
+	Cursor    string `json:"cursor" form:"cursor"`
+	Size      int    `json:"size" form:"size,default=10" binding:"omitempty,min=1,max=100"`
+	WithTotal bool   `json:"with_total" form:"with_total"` // 首页是否返回近似总数
 }
 
 type TimeRangeReq struct {
@@ -34,6 +41,14 @@ type PageRes[T any] struct {
 	List  []*T  `json:"list"`
 }
 
+// CursorRes 游标分页响应，用于无限滚动；没有更多数据时 NextCursor 为空
+type CursorRes[T any] struct {
+	List       []*T   `json:"list"`
+	NextCursor string `json:"next_cursor"`
+	HasMore    bool   `json:"has_more"`
+	Total      *int64 `json:"total,omitempty"` // 近似总数，仅首页且请求 with_total 时返回
+}
+
 func NewResponse(code int, message string, data any) *Response {
 	return &Response{
 		Code:    code,
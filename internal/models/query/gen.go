@@ -19,6 +19,8 @@ var (
 	Q               = new(Query)
 	SysApi          *sysApi
 	SysApiGroup     *sysApiGroup
+	SysApiKey       *sysApiKey
+	SysApiKeyScope  *sysApiKeyScope
 	SysDept         *sysDept
 	SysFile         *sysFile
 	SysLoginLog     *sysLoginLog
@@ -28,13 +30,17 @@ var (
 	SysRole         *sysRole
 	SysRoleApi      *sysRoleApi
 	SysRoleMenu     *sysRoleMenu
+	SysTenant       *sysTenant
 	SysUser         *sysUser
+	SysUserOauth    *sysUserOauth
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
 	SysApi = &Q.SysApi
 	SysApiGroup = &Q.SysApiGroup
+	SysApiKey = &Q.SysApiKey
+	SysApiKeyScope = &Q.SysApiKeyScope
 	SysDept = &Q.SysDept
 	SysFile = &Q.SysFile
 	SysLoginLog = &Q.SysLoginLog
@@ -44,7 +50,9 @@ func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	SysRole = &Q.SysRole
 	SysRoleApi = &Q.SysRoleApi
 	SysRoleMenu = &Q.SysRoleMenu
+	SysTenant = &Q.SysTenant
 	SysUser = &Q.SysUser
+	SysUserOauth = &Q.SysUserOauth
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
@@ -52,6 +60,8 @@ func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 		db:              db,
 		SysApi:          newSysApi(db, opts...),
 		SysApiGroup:     newSysApiGroup(db, opts...),
+		SysApiKey:       newSysApiKey(db, opts...),
+		SysApiKeyScope:  newSysApiKeyScope(db, opts...),
 		SysDept:         newSysDept(db, opts...),
 		SysFile:         newSysFile(db, opts...),
 		SysLoginLog:     newSysLoginLog(db, opts...),
@@ -61,7 +71,9 @@ func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 		SysRole:         newSysRole(db, opts...),
 		SysRoleApi:      newSysRoleApi(db, opts...),
 		SysRoleMenu:     newSysRoleMenu(db, opts...),
+		SysTenant:       newSysTenant(db, opts...),
 		SysUser:         newSysUser(db, opts...),
+		SysUserOauth:    newSysUserOauth(db, opts...),
 	}
 }
 
@@ -70,6 +82,8 @@ type Query struct {
 
 	SysApi          sysApi
 	SysApiGroup     sysApiGroup
+	SysApiKey       sysApiKey
+	SysApiKeyScope  sysApiKeyScope
 	SysDept         sysDept
 	SysFile         sysFile
 	SysLoginLog     sysLoginLog
@@ -79,7 +93,9 @@ type Query struct {
 	SysRole         sysRole
 	SysRoleApi      sysRoleApi
 	SysRoleMenu     sysRoleMenu
+	SysTenant       sysTenant
 	SysUser         sysUser
+	SysUserOauth    sysUserOauth
 }
 
 func (q *Query) Available() bool { return q.db != nil }
@@ -89,6 +105,8 @@ func (q *Query) clone(db *gorm.DB) *Query {
 		db:              db,
 		SysApi:          q.SysApi.clone(db),
 		SysApiGroup:     q.SysApiGroup.clone(db),
+		SysApiKey:       q.SysApiKey.clone(db),
+		SysApiKeyScope:  q.SysApiKeyScope.clone(db),
 		SysDept:         q.SysDept.clone(db),
 		SysFile:         q.SysFile.clone(db),
 		SysLoginLog:     q.SysLoginLog.clone(db),
@@ -98,7 +116,9 @@ func (q *Query) clone(db *gorm.DB) *Query {
 		SysRole:         q.SysRole.clone(db),
 		SysRoleApi:      q.SysRoleApi.clone(db),
 		SysRoleMenu:     q.SysRoleMenu.clone(db),
+		SysTenant:       q.SysTenant.clone(db),
 		SysUser:         q.SysUser.clone(db),
+		SysUserOauth:    q.SysUserOauth.clone(db),
 	}
 }
 
@@ -115,6 +135,8 @@ func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 		db:              db,
 		SysApi:          q.SysApi.replaceDB(db),
 		SysApiGroup:     q.SysApiGroup.replaceDB(db),
+		SysApiKey:       q.SysApiKey.replaceDB(db),
+		SysApiKeyScope:  q.SysApiKeyScope.replaceDB(db),
 		SysDept:         q.SysDept.replaceDB(db),
 		SysFile:         q.SysFile.replaceDB(db),
 		SysLoginLog:     q.SysLoginLog.replaceDB(db),
@@ -124,13 +146,17 @@ func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 		SysRole:         q.SysRole.replaceDB(db),
 		SysRoleApi:      q.SysRoleApi.replaceDB(db),
 		SysRoleMenu:     q.SysRoleMenu.replaceDB(db),
+		SysTenant:       q.SysTenant.replaceDB(db),
 		SysUser:         q.SysUser.replaceDB(db),
+		SysUserOauth:    q.SysUserOauth.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
 	SysApi          ISysApiDo
 	SysApiGroup     ISysApiGroupDo
+	SysApiKey       ISysApiKeyDo
+	SysApiKeyScope  ISysApiKeyScopeDo
 	SysDept         ISysDeptDo
 	SysFile         ISysFileDo
 	SysLoginLog     ISysLoginLogDo
@@ -140,13 +166,17 @@ type queryCtx struct {
 	SysRole         ISysRoleDo
 	SysRoleApi      ISysRoleApiDo
 	SysRoleMenu     ISysRoleMenuDo
+	SysTenant       ISysTenantDo
 	SysUser         ISysUserDo
+	SysUserOauth    ISysUserOauthDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
 		SysApi:          q.SysApi.WithContext(ctx),
 		SysApiGroup:     q.SysApiGroup.WithContext(ctx),
+		SysApiKey:       q.SysApiKey.WithContext(ctx),
+		SysApiKeyScope:  q.SysApiKeyScope.WithContext(ctx),
 		SysDept:         q.SysDept.WithContext(ctx),
 		SysFile:         q.SysFile.WithContext(ctx),
 		SysLoginLog:     q.SysLoginLog.WithContext(ctx),
@@ -156,7 +186,9 @@ func (q *Query) WithContext(ctx context.Context) *queryCtx {
 		SysRole:         q.SysRole.WithContext(ctx),
 		SysRoleApi:      q.SysRoleApi.WithContext(ctx),
 		SysRoleMenu:     q.SysRoleMenu.WithContext(ctx),
+		SysTenant:       q.SysTenant.WithContext(ctx),
 		SysUser:         q.SysUser.WithContext(ctx),
+		SysUserOauth:    q.SysUserOauth.WithContext(ctx),
 	}
 }
 
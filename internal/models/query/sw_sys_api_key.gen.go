@@ -0,0 +1,601 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"sweet/internal/models/entity"
+)
+
+func newSysApiKey(db *gorm.DB, opts ...gen.DOOption) sysApiKey {
+	_sysApiKey := sysApiKey{}
+
+	_sysApiKey.sysApiKeyDo.UseDB(db, opts...)
+	_sysApiKey.sysApiKeyDo.UseModel(&entity.SysApiKey{})
+
+	tableName := _sysApiKey.sysApiKeyDo.TableName()
+	_sysApiKey.ALL = field.NewAsterisk(tableName)
+	_sysApiKey.ID = field.NewInt64(tableName, "id")
+	_sysApiKey.TenantID = field.NewInt64(tableName, "tenant_id")
+	_sysApiKey.Name = field.NewString(tableName, "name")
+	_sysApiKey.Prefix = field.NewString(tableName, "prefix")
+	_sysApiKey.SecretHash = field.NewString(tableName, "secret_hash")
+	_sysApiKey.OwnerType = field.NewInt64(tableName, "owner_type")
+	_sysApiKey.UserID = field.NewInt64(tableName, "user_id")
+	_sysApiKey.ServiceName = field.NewString(tableName, "service_name")
+	_sysApiKey.Status = field.NewInt64(tableName, "status")
+	_sysApiKey.ExpiresAt = field.NewTime(tableName, "expires_at")
+	_sysApiKey.LastUsedAt = field.NewTime(tableName, "last_used_at")
+	_sysApiKey.LastUsedIP = field.NewString(tableName, "last_used_ip")
+	_sysApiKey.RevokedAt = field.NewTime(tableName, "revoked_at")
+	_sysApiKey.Remark = field.NewString(tableName, "remark")
+	_sysApiKey.CreateBy = field.NewInt64(tableName, "create_by")
+	_sysApiKey.CreatedAt = field.NewTime(tableName, "created_at")
+	_sysApiKey.UpdatedAt = field.NewTime(tableName, "updated_at")
+	_sysApiKey.DeletedAt = field.NewField(tableName, "deleted_at")
+	_sysApiKey.User = sysApiKeyBelongsToUser{
+		db: db.Session(&gorm.Session{}),
+
+		RelationField: field.NewRelation("User", "entity.SysUser"),
+		Role: struct {
+			field.RelationField
+		}{
+			RelationField: field.NewRelation("User.Role", "entity.SysRole"),
+		},
+		Dept: struct {
+			field.RelationField
+			Parent struct {
+				field.RelationField
+			}
+			Children struct {
+				field.RelationField
+			}
+		}{
+			RelationField: field.NewRelation("User.Dept", "entity.SysDept"),
+			Parent: struct {
+				field.RelationField
+			}{
+				RelationField: field.NewRelation("User.Dept.Parent", "entity.SysDept"),
+			},
+			Children: struct {
+				field.RelationField
+			}{
+				RelationField: field.NewRelation("User.Dept.Children", "entity.SysDept"),
+			},
+		},
+		Post: struct {
+			field.RelationField
+			Dept struct {
+				field.RelationField
+			}
+		}{
+			RelationField: field.NewRelation("User.Post", "entity.SysPost"),
+			Dept: struct {
+				field.RelationField
+			}{
+				RelationField: field.NewRelation("User.Post.Dept", "entity.SysDept"),
+			},
+		},
+	}
+
+	_sysApiKey.fillFieldMap()
+
+	return _sysApiKey
+}
+
+// sysApiKey API密钥表
+type sysApiKey struct {
+	sysApiKeyDo
+
+	ALL         field.Asterisk
+	ID          field.Int64  // 密钥ID
+	TenantID    field.Int64  // 租户ID
+	Name        field.String // 密钥名称
+	Prefix      field.String // 密钥前缀（明文，用于查找）
+	SecretHash  field.String // 密钥哈希（SHA256）
+	OwnerType   field.Int64  // 归属类型：1=用户，2=服务
+	UserID      field.Int64  // 归属用户ID（归属类型为用户时）
+	ServiceName field.String // 归属服务名称（归属类型为服务时）
+	Status      field.Int64  // 状态：1=正常，2=已吊销
+	ExpiresAt   field.Time   // 过期时间（为空表示永不过期）
+	LastUsedAt  field.Time   // 最后使用时间
+	LastUsedIP  field.String // 最后使用IP
+	RevokedAt   field.Time   // 吊销时间
+	Remark      field.String // 备注
+	CreateBy    field.Int64  // 创建者
+	CreatedAt   field.Time   // 创建时间
+	UpdatedAt   field.Time   // 更新时间
+	DeletedAt   field.Field  // 删除时间
+	User        sysApiKeyBelongsToUser
+
+	fieldMap map[string]field.Expr
+}
+
+func (s sysApiKey) Table(newTableName string) *sysApiKey {
+	s.sysApiKeyDo.UseTable(newTableName)
+	return s.updateTableName(newTableName)
+}
+
+func (s sysApiKey) As(alias string) *sysApiKey {
+	s.sysApiKeyDo.DO = *(s.sysApiKeyDo.As(alias).(*gen.DO))
+	return s.updateTableName(alias)
+}
+
+func (s *sysApiKey) updateTableName(table string) *sysApiKey {
+	s.ALL = field.NewAsterisk(table)
+	s.ID = field.NewInt64(table, "id")
+	s.TenantID = field.NewInt64(table, "tenant_id")
+	s.Name = field.NewString(table, "name")
+	s.Prefix = field.NewString(table, "prefix")
+	s.SecretHash = field.NewString(table, "secret_hash")
+	s.OwnerType = field.NewInt64(table, "owner_type")
+	s.UserID = field.NewInt64(table, "user_id")
+	s.ServiceName = field.NewString(table, "service_name")
+	s.Status = field.NewInt64(table, "status")
+	s.ExpiresAt = field.NewTime(table, "expires_at")
+	s.LastUsedAt = field.NewTime(table, "last_used_at")
+	s.LastUsedIP = field.NewString(table, "last_used_ip")
+	s.RevokedAt = field.NewTime(table, "revoked_at")
+	s.Remark = field.NewString(table, "remark")
+	s.CreateBy = field.NewInt64(table, "create_by")
+	s.CreatedAt = field.NewTime(table, "created_at")
+	s.UpdatedAt = field.NewTime(table, "updated_at")
+	s.DeletedAt = field.NewField(table, "deleted_at")
+
+	s.fillFieldMap()
+
+	return s
+}
+
+func (s *sysApiKey) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := s.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (s *sysApiKey) fillFieldMap() {
+	s.fieldMap = make(map[string]field.Expr, 19)
+	s.fieldMap["id"] = s.ID
+	s.fieldMap["tenant_id"] = s.TenantID
+	s.fieldMap["name"] = s.Name
+	s.fieldMap["prefix"] = s.Prefix
+	s.fieldMap["secret_hash"] = s.SecretHash
+	s.fieldMap["owner_type"] = s.OwnerType
+	s.fieldMap["user_id"] = s.UserID
+	s.fieldMap["service_name"] = s.ServiceName
+	s.fieldMap["status"] = s.Status
+	s.fieldMap["expires_at"] = s.ExpiresAt
+	s.fieldMap["last_used_at"] = s.LastUsedAt
+	s.fieldMap["last_used_ip"] = s.LastUsedIP
+	s.fieldMap["revoked_at"] = s.RevokedAt
+	s.fieldMap["remark"] = s.Remark
+	s.fieldMap["create_by"] = s.CreateBy
+	s.fieldMap["created_at"] = s.CreatedAt
+	s.fieldMap["updated_at"] = s.UpdatedAt
+	s.fieldMap["deleted_at"] = s.DeletedAt
+
+}
+
+func (s sysApiKey) clone(db *gorm.DB) sysApiKey {
+	s.sysApiKeyDo.ReplaceConnPool(db.Statement.ConnPool)
+	s.User.db = db.Session(&gorm.Session{Initialized: true})
+	s.User.db.Statement.ConnPool = db.Statement.ConnPool
+	return s
+}
+
+func (s sysApiKey) replaceDB(db *gorm.DB) sysApiKey {
+	s.sysApiKeyDo.ReplaceDB(db)
+	s.User.db = db.Session(&gorm.Session{})
+	return s
+}
+
+type sysApiKeyBelongsToUser struct {
+	db *gorm.DB
+
+	field.RelationField
+
+	Role struct {
+		field.RelationField
+	}
+	Dept struct {
+		field.RelationField
+		Parent struct {
+			field.RelationField
+		}
+		Children struct {
+			field.RelationField
+		}
+	}
+	Post struct {
+		field.RelationField
+		Dept struct {
+			field.RelationField
+		}
+	}
+}
+
+func (a sysApiKeyBelongsToUser) Where(conds ...field.Expr) *sysApiKeyBelongsToUser {
+	if len(conds) == 0 {
+		return &a
+	}
+
+	exprs := make([]clause.Expression, 0, len(conds))
+	for _, cond := range conds {
+		exprs = append(exprs, cond.BeCond().(clause.Expression))
+	}
+	a.db = a.db.Clauses(clause.Where{Exprs: exprs})
+	return &a
+}
+
+func (a sysApiKeyBelongsToUser) WithContext(ctx context.Context) *sysApiKeyBelongsToUser {
+	a.db = a.db.WithContext(ctx)
+	return &a
+}
+
+func (a sysApiKeyBelongsToUser) Session(session *gorm.Session) *sysApiKeyBelongsToUser {
+	a.db = a.db.Session(session)
+	return &a
+}
+
+func (a sysApiKeyBelongsToUser) Model(m *entity.SysApiKey) *sysApiKeyBelongsToUserTx {
+	return &sysApiKeyBelongsToUserTx{a.db.Model(m).Association(a.Name())}
+}
+
+func (a sysApiKeyBelongsToUser) Unscoped() *sysApiKeyBelongsToUser {
+	a.db = a.db.Unscoped()
+	return &a
+}
+
+type sysApiKeyBelongsToUserTx struct{ tx *gorm.Association }
+
+func (a sysApiKeyBelongsToUserTx) Find() (result *entity.SysUser, err error) {
+	return result, a.tx.Find(&result)
+}
+
+func (a sysApiKeyBelongsToUserTx) Append(values ...*entity.SysUser) (err error) {
+	targetValues := make([]interface{}, len(values))
+	for i, v := range values {
+		targetValues[i] = v
+	}
+	return a.tx.Append(targetValues...)
+}
+
+func (a sysApiKeyBelongsToUserTx) Replace(values ...*entity.SysUser) (err error) {
+	targetValues := make([]interface{}, len(values))
+	for i, v := range values {
+		targetValues[i] = v
+	}
+	return a.tx.Replace(targetValues...)
+}
+
+func (a sysApiKeyBelongsToUserTx) Delete(values ...*entity.SysUser) (err error) {
+	targetValues := make([]interface{}, len(values))
+	for i, v := range values {
+		targetValues[i] = v
+	}
+	return a.tx.Delete(targetValues...)
+}
+
+func (a sysApiKeyBelongsToUserTx) Clear() error {
+	return a.tx.Clear()
+}
+
+func (a sysApiKeyBelongsToUserTx) Count() int64 {
+	return a.tx.Count()
+}
+
+func (a sysApiKeyBelongsToUserTx) Unscoped() *sysApiKeyBelongsToUserTx {
+	a.tx = a.tx.Unscoped()
+	return &a
+}
+
+type sysApiKeyDo struct{ gen.DO }
+
+type ISysApiKeyDo interface {
+	gen.SubQuery
+	Debug() ISysApiKeyDo
+	WithContext(ctx context.Context) ISysApiKeyDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ISysApiKeyDo
+	WriteDB() ISysApiKeyDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ISysApiKeyDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ISysApiKeyDo
+	Not(conds ...gen.Condition) ISysApiKeyDo
+	Or(conds ...gen.Condition) ISysApiKeyDo
+	Select(conds ...field.Expr) ISysApiKeyDo
+	Where(conds ...gen.Condition) ISysApiKeyDo
+	Order(conds ...field.Expr) ISysApiKeyDo
+	Distinct(cols ...field.Expr) ISysApiKeyDo
+	Omit(cols ...field.Expr) ISysApiKeyDo
+	Join(table schema.Tabler, on ...field.Expr) ISysApiKeyDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ISysApiKeyDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ISysApiKeyDo
+	Group(cols ...field.Expr) ISysApiKeyDo
+	Having(conds ...gen.Condition) ISysApiKeyDo
+	Limit(limit int) ISysApiKeyDo
+	Offset(offset int) ISysApiKeyDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ISysApiKeyDo
+	Unscoped() ISysApiKeyDo
+	Create(values ...*entity.SysApiKey) error
+	CreateInBatches(values []*entity.SysApiKey, batchSize int) error
+	Save(values ...*entity.SysApiKey) error
+	First() (*entity.SysApiKey, error)
+	Take() (*entity.SysApiKey, error)
+	Last() (*entity.SysApiKey, error)
+	Find() ([]*entity.SysApiKey, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*entity.SysApiKey, err error)
+	FindInBatches(result *[]*entity.SysApiKey, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*entity.SysApiKey) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ISysApiKeyDo
+	Assign(attrs ...field.AssignExpr) ISysApiKeyDo
+	Joins(fields ...field.RelationField) ISysApiKeyDo
+	Preload(fields ...field.RelationField) ISysApiKeyDo
+	FirstOrInit() (*entity.SysApiKey, error)
+	FirstOrCreate() (*entity.SysApiKey, error)
+	FindByPage(offset int, limit int) (result []*entity.SysApiKey, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ISysApiKeyDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (s sysApiKeyDo) Debug() ISysApiKeyDo {
+	return s.withDO(s.DO.Debug())
+}
+
+func (s sysApiKeyDo) WithContext(ctx context.Context) ISysApiKeyDo {
+	return s.withDO(s.DO.WithContext(ctx))
+}
+
+func (s sysApiKeyDo) ReadDB() ISysApiKeyDo {
+	return s.Clauses(dbresolver.Read)
+}
+
+func (s sysApiKeyDo) WriteDB() ISysApiKeyDo {
+	return s.Clauses(dbresolver.Write)
+}
+
+func (s sysApiKeyDo) Session(config *gorm.Session) ISysApiKeyDo {
+	return s.withDO(s.DO.Session(config))
+}
+
+func (s sysApiKeyDo) Clauses(conds ...clause.Expression) ISysApiKeyDo {
+	return s.withDO(s.DO.Clauses(conds...))
+}
+
+func (s sysApiKeyDo) Returning(value interface{}, columns ...string) ISysApiKeyDo {
+	return s.withDO(s.DO.Returning(value, columns...))
+}
+
+func (s sysApiKeyDo) Not(conds ...gen.Condition) ISysApiKeyDo {
+	return s.withDO(s.DO.Not(conds...))
+}
+
+func (s sysApiKeyDo) Or(conds ...gen.Condition) ISysApiKeyDo {
+	return s.withDO(s.DO.Or(conds...))
+}
+
+func (s sysApiKeyDo) Select(conds ...field.Expr) ISysApiKeyDo {
+	return s.withDO(s.DO.Select(conds...))
+}
+
+func (s sysApiKeyDo) Where(conds ...gen.Condition) ISysApiKeyDo {
+	return s.withDO(s.DO.Where(conds...))
+}
+
+func (s sysApiKeyDo) Order(conds ...field.Expr) ISysApiKeyDo {
+	return s.withDO(s.DO.Order(conds...))
+}
+
+func (s sysApiKeyDo) Distinct(cols ...field.Expr) ISysApiKeyDo {
+	return s.withDO(s.DO.Distinct(cols...))
+}
+
+func (s sysApiKeyDo) Omit(cols ...field.Expr) ISysApiKeyDo {
+	return s.withDO(s.DO.Omit(cols...))
+}
+
+func (s sysApiKeyDo) Join(table schema.Tabler, on ...field.Expr) ISysApiKeyDo {
+	return s.withDO(s.DO.Join(table, on...))
+}
+
+func (s sysApiKeyDo) LeftJoin(table schema.Tabler, on ...field.Expr) ISysApiKeyDo {
+	return s.withDO(s.DO.LeftJoin(table, on...))
+}
+
+func (s sysApiKeyDo) RightJoin(table schema.Tabler, on ...field.Expr) ISysApiKeyDo {
+	return s.withDO(s.DO.RightJoin(table, on...))
+}
+
+func (s sysApiKeyDo) Group(cols ...field.Expr) ISysApiKeyDo {
+	return s.withDO(s.DO.Group(cols...))
+}
+
+func (s sysApiKeyDo) Having(conds ...gen.Condition) ISysApiKeyDo {
+	return s.withDO(s.DO.Having(conds...))
+}
+
+func (s sysApiKeyDo) Limit(limit int) ISysApiKeyDo {
+	return s.withDO(s.DO.Limit(limit))
+}
+
+func (s sysApiKeyDo) Offset(offset int) ISysApiKeyDo {
+	return s.withDO(s.DO.Offset(offset))
+}
+
+func (s sysApiKeyDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ISysApiKeyDo {
+	return s.withDO(s.DO.Scopes(funcs...))
+}
+
+func (s sysApiKeyDo) Unscoped() ISysApiKeyDo {
+	return s.withDO(s.DO.Unscoped())
+}
+
+func (s sysApiKeyDo) Create(values ...*entity.SysApiKey) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Create(values)
+}
+
+func (s sysApiKeyDo) CreateInBatches(values []*entity.SysApiKey, batchSize int) error {
+	return s.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (s sysApiKeyDo) Save(values ...*entity.SysApiKey) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Save(values)
+}
+
+func (s sysApiKeyDo) First() (*entity.SysApiKey, error) {
+	if result, err := s.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysApiKey), nil
+	}
+}
+
+func (s sysApiKeyDo) Take() (*entity.SysApiKey, error) {
+	if result, err := s.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysApiKey), nil
+	}
+}
+
+func (s sysApiKeyDo) Last() (*entity.SysApiKey, error) {
+	if result, err := s.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysApiKey), nil
+	}
+}
+
+func (s sysApiKeyDo) Find() ([]*entity.SysApiKey, error) {
+	result, err := s.DO.Find()
+	return result.([]*entity.SysApiKey), err
+}
+
+func (s sysApiKeyDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*entity.SysApiKey, err error) {
+	buf := make([]*entity.SysApiKey, 0, batchSize)
+	err = s.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (s sysApiKeyDo) FindInBatches(result *[]*entity.SysApiKey, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return s.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (s sysApiKeyDo) Attrs(attrs ...field.AssignExpr) ISysApiKeyDo {
+	return s.withDO(s.DO.Attrs(attrs...))
+}
+
+func (s sysApiKeyDo) Assign(attrs ...field.AssignExpr) ISysApiKeyDo {
+	return s.withDO(s.DO.Assign(attrs...))
+}
+
+func (s sysApiKeyDo) Joins(fields ...field.RelationField) ISysApiKeyDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Joins(_f))
+	}
+	return &s
+}
+
+func (s sysApiKeyDo) Preload(fields ...field.RelationField) ISysApiKeyDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Preload(_f))
+	}
+	return &s
+}
+
+func (s sysApiKeyDo) FirstOrInit() (*entity.SysApiKey, error) {
+	if result, err := s.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysApiKey), nil
+	}
+}
+
+func (s sysApiKeyDo) FirstOrCreate() (*entity.SysApiKey, error) {
+	if result, err := s.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysApiKey), nil
+	}
+}
+
+func (s sysApiKeyDo) FindByPage(offset int, limit int) (result []*entity.SysApiKey, count int64, err error) {
+	result, err = s.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = s.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (s sysApiKeyDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = s.Count()
+	if err != nil {
+		return
+	}
+
+	err = s.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (s sysApiKeyDo) Scan(result interface{}) (err error) {
+	return s.DO.Scan(result)
+}
+
+func (s sysApiKeyDo) Delete(models ...*entity.SysApiKey) (result gen.ResultInfo, err error) {
+	return s.DO.Delete(models)
+}
+
+func (s *sysApiKeyDo) withDO(do gen.Dao) *sysApiKeyDo {
+	s.DO = *do.(*gen.DO)
+	return s
+}
@@ -0,0 +1,388 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"sweet/internal/models/entity"
+)
+
+func newSysApiKeyScope(db *gorm.DB, opts ...gen.DOOption) sysApiKeyScope {
+	_sysApiKeyScope := sysApiKeyScope{}
+
+	_sysApiKeyScope.sysApiKeyScopeDo.UseDB(db, opts...)
+	_sysApiKeyScope.sysApiKeyScopeDo.UseModel(&entity.SysApiKeyScope{})
+
+	tableName := _sysApiKeyScope.sysApiKeyScopeDo.TableName()
+	_sysApiKeyScope.ALL = field.NewAsterisk(tableName)
+	_sysApiKeyScope.KeyID = field.NewInt64(tableName, "key_id")
+	_sysApiKeyScope.APIID = field.NewInt64(tableName, "api_id")
+
+	_sysApiKeyScope.fillFieldMap()
+
+	return _sysApiKeyScope
+}
+
+// sysApiKeyScope API密钥授权范围表
+type sysApiKeyScope struct {
+	sysApiKeyScopeDo
+
+	ALL   field.Asterisk
+	KeyID field.Int64 // 密钥ID
+	APIID field.Int64 // API ID
+
+	fieldMap map[string]field.Expr
+}
+
+func (s sysApiKeyScope) Table(newTableName string) *sysApiKeyScope {
+	s.sysApiKeyScopeDo.UseTable(newTableName)
+	return s.updateTableName(newTableName)
+}
+
+func (s sysApiKeyScope) As(alias string) *sysApiKeyScope {
+	s.sysApiKeyScopeDo.DO = *(s.sysApiKeyScopeDo.As(alias).(*gen.DO))
+	return s.updateTableName(alias)
+}
+
+func (s *sysApiKeyScope) updateTableName(table string) *sysApiKeyScope {
+	s.ALL = field.NewAsterisk(table)
+	s.KeyID = field.NewInt64(table, "key_id")
+	s.APIID = field.NewInt64(table, "api_id")
+
+	s.fillFieldMap()
+
+	return s
+}
+
+func (s *sysApiKeyScope) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := s.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (s *sysApiKeyScope) fillFieldMap() {
+	s.fieldMap = make(map[string]field.Expr, 2)
+	s.fieldMap["key_id"] = s.KeyID
+	s.fieldMap["api_id"] = s.APIID
+}
+
+func (s sysApiKeyScope) clone(db *gorm.DB) sysApiKeyScope {
+	s.sysApiKeyScopeDo.ReplaceConnPool(db.Statement.ConnPool)
+	return s
+}
+
+func (s sysApiKeyScope) replaceDB(db *gorm.DB) sysApiKeyScope {
+	s.sysApiKeyScopeDo.ReplaceDB(db)
+	return s
+}
+
+type sysApiKeyScopeDo struct{ gen.DO }
+
+type ISysApiKeyScopeDo interface {
+	gen.SubQuery
+	Debug() ISysApiKeyScopeDo
+	WithContext(ctx context.Context) ISysApiKeyScopeDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ISysApiKeyScopeDo
+	WriteDB() ISysApiKeyScopeDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ISysApiKeyScopeDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ISysApiKeyScopeDo
+	Not(conds ...gen.Condition) ISysApiKeyScopeDo
+	Or(conds ...gen.Condition) ISysApiKeyScopeDo
+	Select(conds ...field.Expr) ISysApiKeyScopeDo
+	Where(conds ...gen.Condition) ISysApiKeyScopeDo
+	Order(conds ...field.Expr) ISysApiKeyScopeDo
+	Distinct(cols ...field.Expr) ISysApiKeyScopeDo
+	Omit(cols ...field.Expr) ISysApiKeyScopeDo
+	Join(table schema.Tabler, on ...field.Expr) ISysApiKeyScopeDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ISysApiKeyScopeDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ISysApiKeyScopeDo
+	Group(cols ...field.Expr) ISysApiKeyScopeDo
+	Having(conds ...gen.Condition) ISysApiKeyScopeDo
+	Limit(limit int) ISysApiKeyScopeDo
+	Offset(offset int) ISysApiKeyScopeDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ISysApiKeyScopeDo
+	Unscoped() ISysApiKeyScopeDo
+	Create(values ...*entity.SysApiKeyScope) error
+	CreateInBatches(values []*entity.SysApiKeyScope, batchSize int) error
+	Save(values ...*entity.SysApiKeyScope) error
+	First() (*entity.SysApiKeyScope, error)
+	Take() (*entity.SysApiKeyScope, error)
+	Last() (*entity.SysApiKeyScope, error)
+	Find() ([]*entity.SysApiKeyScope, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*entity.SysApiKeyScope, err error)
+	FindInBatches(result *[]*entity.SysApiKeyScope, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*entity.SysApiKeyScope) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ISysApiKeyScopeDo
+	Assign(attrs ...field.AssignExpr) ISysApiKeyScopeDo
+	Joins(fields ...field.RelationField) ISysApiKeyScopeDo
+	Preload(fields ...field.RelationField) ISysApiKeyScopeDo
+	FirstOrInit() (*entity.SysApiKeyScope, error)
+	FirstOrCreate() (*entity.SysApiKeyScope, error)
+	FindByPage(offset int, limit int) (result []*entity.SysApiKeyScope, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ISysApiKeyScopeDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (s sysApiKeyScopeDo) Debug() ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Debug())
+}
+
+func (s sysApiKeyScopeDo) WithContext(ctx context.Context) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.WithContext(ctx))
+}
+
+func (s sysApiKeyScopeDo) ReadDB() ISysApiKeyScopeDo {
+	return s.Clauses(dbresolver.Read)
+}
+
+func (s sysApiKeyScopeDo) WriteDB() ISysApiKeyScopeDo {
+	return s.Clauses(dbresolver.Write)
+}
+
+func (s sysApiKeyScopeDo) Session(config *gorm.Session) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Session(config))
+}
+
+func (s sysApiKeyScopeDo) Clauses(conds ...clause.Expression) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Clauses(conds...))
+}
+
+func (s sysApiKeyScopeDo) Returning(value interface{}, columns ...string) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Returning(value, columns...))
+}
+
+func (s sysApiKeyScopeDo) Not(conds ...gen.Condition) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Not(conds...))
+}
+
+func (s sysApiKeyScopeDo) Or(conds ...gen.Condition) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Or(conds...))
+}
+
+func (s sysApiKeyScopeDo) Select(conds ...field.Expr) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Select(conds...))
+}
+
+func (s sysApiKeyScopeDo) Where(conds ...gen.Condition) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Where(conds...))
+}
+
+func (s sysApiKeyScopeDo) Order(conds ...field.Expr) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Order(conds...))
+}
+
+func (s sysApiKeyScopeDo) Distinct(cols ...field.Expr) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Distinct(cols...))
+}
+
+func (s sysApiKeyScopeDo) Omit(cols ...field.Expr) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Omit(cols...))
+}
+
+func (s sysApiKeyScopeDo) Join(table schema.Tabler, on ...field.Expr) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Join(table, on...))
+}
+
+func (s sysApiKeyScopeDo) LeftJoin(table schema.Tabler, on ...field.Expr) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.LeftJoin(table, on...))
+}
+
+func (s sysApiKeyScopeDo) RightJoin(table schema.Tabler, on ...field.Expr) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.RightJoin(table, on...))
+}
+
+func (s sysApiKeyScopeDo) Group(cols ...field.Expr) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Group(cols...))
+}
+
+func (s sysApiKeyScopeDo) Having(conds ...gen.Condition) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Having(conds...))
+}
+
+func (s sysApiKeyScopeDo) Limit(limit int) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Limit(limit))
+}
+
+func (s sysApiKeyScopeDo) Offset(offset int) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Offset(offset))
+}
+
+func (s sysApiKeyScopeDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Scopes(funcs...))
+}
+
+func (s sysApiKeyScopeDo) Unscoped() ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Unscoped())
+}
+
+func (s sysApiKeyScopeDo) Create(values ...*entity.SysApiKeyScope) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Create(values)
+}
+
+func (s sysApiKeyScopeDo) CreateInBatches(values []*entity.SysApiKeyScope, batchSize int) error {
+	return s.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (s sysApiKeyScopeDo) Save(values ...*entity.SysApiKeyScope) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Save(values)
+}
+
+func (s sysApiKeyScopeDo) First() (*entity.SysApiKeyScope, error) {
+	if result, err := s.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysApiKeyScope), nil
+	}
+}
+
+func (s sysApiKeyScopeDo) Take() (*entity.SysApiKeyScope, error) {
+	if result, err := s.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysApiKeyScope), nil
+	}
+}
+
+func (s sysApiKeyScopeDo) Last() (*entity.SysApiKeyScope, error) {
+	if result, err := s.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysApiKeyScope), nil
+	}
+}
+
+func (s sysApiKeyScopeDo) Find() ([]*entity.SysApiKeyScope, error) {
+	result, err := s.DO.Find()
+	return result.([]*entity.SysApiKeyScope), err
+}
+
+func (s sysApiKeyScopeDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*entity.SysApiKeyScope, err error) {
+	buf := make([]*entity.SysApiKeyScope, 0, batchSize)
+	err = s.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (s sysApiKeyScopeDo) FindInBatches(result *[]*entity.SysApiKeyScope, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return s.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (s sysApiKeyScopeDo) Attrs(attrs ...field.AssignExpr) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Attrs(attrs...))
+}
+
+func (s sysApiKeyScopeDo) Assign(attrs ...field.AssignExpr) ISysApiKeyScopeDo {
+	return s.withDO(s.DO.Assign(attrs...))
+}
+
+func (s sysApiKeyScopeDo) Joins(fields ...field.RelationField) ISysApiKeyScopeDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Joins(_f))
+	}
+	return &s
+}
+
+func (s sysApiKeyScopeDo) Preload(fields ...field.RelationField) ISysApiKeyScopeDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Preload(_f))
+	}
+	return &s
+}
+
+func (s sysApiKeyScopeDo) FirstOrInit() (*entity.SysApiKeyScope, error) {
+	if result, err := s.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysApiKeyScope), nil
+	}
+}
+
+func (s sysApiKeyScopeDo) FirstOrCreate() (*entity.SysApiKeyScope, error) {
+	if result, err := s.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysApiKeyScope), nil
+	}
+}
+
+func (s sysApiKeyScopeDo) FindByPage(offset int, limit int) (result []*entity.SysApiKeyScope, count int64, err error) {
+	result, err = s.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = s.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (s sysApiKeyScopeDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = s.Count()
+	if err != nil {
+		return
+	}
+
+	err = s.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (s sysApiKeyScopeDo) Scan(result interface{}) (err error) {
+	return s.DO.Scan(result)
+}
+
+func (s sysApiKeyScopeDo) Delete(models ...*entity.SysApiKeyScope) (result gen.ResultInfo, err error) {
+	return s.DO.Delete(models)
+}
+
+func (s *sysApiKeyScopeDo) withDO(do gen.Dao) *sysApiKeyScopeDo {
+	s.DO = *do.(*gen.DO)
+	return s
+}
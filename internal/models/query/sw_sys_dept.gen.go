@@ -29,6 +29,7 @@ func newSysDept(db *gorm.DB, opts ...gen.DOOption) sysDept {
 	tableName := _sysDept.sysDeptDo.TableName()
 	_sysDept.ALL = field.NewAsterisk(tableName)
 	_sysDept.ID = field.NewInt64(tableName, "id")
+	_sysDept.TenantID = field.NewInt64(tableName, "tenant_id")
 	_sysDept.ParentID = field.NewInt64(tableName, "parent_id")
 	_sysDept.Name = field.NewString(tableName, "name")
 	_sysDept.Code = field.NewString(tableName, "code")
@@ -60,6 +61,7 @@ type sysDept struct {
 
 	ALL       field.Asterisk
 	ID        field.Int64  // 部门ID
+	TenantID  field.Int64  // 租户ID
 	ParentID  field.Int64  // 父部门ID
 	Name      field.String // 部门名称
 	Code      field.String // 部门编码
@@ -88,6 +90,7 @@ func (s sysDept) As(alias string) *sysDept {
 func (s *sysDept) updateTableName(table string) *sysDept {
 	s.ALL = field.NewAsterisk(table)
 	s.ID = field.NewInt64(table, "id")
+	s.TenantID = field.NewInt64(table, "tenant_id")
 	s.ParentID = field.NewInt64(table, "parent_id")
 	s.Name = field.NewString(table, "name")
 	s.Code = field.NewString(table, "code")
@@ -112,8 +115,9 @@ func (s *sysDept) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (s *sysDept) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 11)
+	s.fieldMap = make(map[string]field.Expr, 12)
 	s.fieldMap["id"] = s.ID
+	s.fieldMap["tenant_id"] = s.TenantID
 	s.fieldMap["parent_id"] = s.ParentID
 	s.fieldMap["name"] = s.Name
 	s.fieldMap["code"] = s.Code
@@ -29,6 +29,7 @@ func newSysFile(db *gorm.DB, opts ...gen.DOOption) sysFile {
 	tableName := _sysFile.sysFileDo.TableName()
 	_sysFile.ALL = field.NewAsterisk(tableName)
 	_sysFile.ID = field.NewInt64(tableName, "id")
+	_sysFile.TenantID = field.NewInt64(tableName, "tenant_id")
 	_sysFile.Name = field.NewString(tableName, "name")
 	_sysFile.OriginalName = field.NewString(tableName, "original_name")
 	_sysFile.FilePath = field.NewString(tableName, "file_path")
@@ -60,6 +61,7 @@ type sysFile struct {
 
 	ALL          field.Asterisk
 	ID           field.Int64  // 文件ID
+	TenantID     field.Int64  // 租户ID
 	Name         field.String // 文件名称
 	OriginalName field.String // 原始文件名
 	FilePath     field.String // 文件路径
@@ -92,6 +94,7 @@ func (s sysFile) As(alias string) *sysFile {
 func (s *sysFile) updateTableName(table string) *sysFile {
 	s.ALL = field.NewAsterisk(table)
 	s.ID = field.NewInt64(table, "id")
+	s.TenantID = field.NewInt64(table, "tenant_id")
 	s.Name = field.NewString(table, "name")
 	s.OriginalName = field.NewString(table, "original_name")
 	s.FilePath = field.NewString(table, "file_path")
@@ -122,8 +125,9 @@ func (s *sysFile) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (s *sysFile) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 16)
+	s.fieldMap = make(map[string]field.Expr, 17)
 	s.fieldMap["id"] = s.ID
+	s.fieldMap["tenant_id"] = s.TenantID
 	s.fieldMap["name"] = s.Name
 	s.fieldMap["original_name"] = s.OriginalName
 	s.fieldMap["file_path"] = s.FilePath
@@ -29,11 +29,13 @@ func newSysLoginLog(db *gorm.DB, opts ...gen.DOOption) sysLoginLog {
 	tableName := _sysLoginLog.sysLoginLogDo.TableName()
 	_sysLoginLog.ALL = field.NewAsterisk(tableName)
 	_sysLoginLog.ID = field.NewInt64(tableName, "id")
+	_sysLoginLog.TenantID = field.NewInt64(tableName, "tenant_id")
 	_sysLoginLog.UserID = field.NewInt64(tableName, "user_id")
 	_sysLoginLog.Username = field.NewString(tableName, "username")
 	_sysLoginLog.LoginType = field.NewInt64(tableName, "login_type")
 	_sysLoginLog.ClientType = field.NewInt64(tableName, "client_type")
 	_sysLoginLog.IP = field.NewString(tableName, "ip")
+	_sysLoginLog.IPIndex = field.NewString(tableName, "ip_index")
 	_sysLoginLog.Location = field.NewString(tableName, "location")
 	_sysLoginLog.UserAgent = field.NewString(tableName, "user_agent")
 	_sysLoginLog.DeviceInfo = field.NewString(tableName, "device_info")
@@ -65,13 +67,15 @@ type sysLoginLog struct {
 
 	ALL           field.Asterisk
 	ID            field.Int64  // 日志ID
+	TenantID      field.Int64  // 租户ID
 	UserID        field.Int64  // 用户ID
 	Username      field.String // 登录用户名
 	LoginType     field.Int64  // 登录类型（1账号密码 2手机验证码 3邮箱验证码 4第三方登录 5微信 6QQ 7支付宝）
 	ClientType    field.Int64  // 客户端类型（1Web 2移动端 3小程序 4API 5管理后台）
-	IP            field.String // 登录IP
+	IP            field.String // 登录IP（加密存储）
+	IPIndex       field.String // 登录IP盲索引
 	Location      field.String // IP归属地
-	UserAgent     field.String // 用户代理
+	UserAgent     field.String // 用户代理（加密存储）
 	DeviceInfo    field.String // 设备信息
 	Browser       field.String // 浏览器
 	Os            field.String // 操作系统
@@ -102,11 +106,13 @@ func (s sysLoginLog) As(alias string) *sysLoginLog {
 func (s *sysLoginLog) updateTableName(table string) *sysLoginLog {
 	s.ALL = field.NewAsterisk(table)
 	s.ID = field.NewInt64(table, "id")
+	s.TenantID = field.NewInt64(table, "tenant_id")
 	s.UserID = field.NewInt64(table, "user_id")
 	s.Username = field.NewString(table, "username")
 	s.LoginType = field.NewInt64(table, "login_type")
 	s.ClientType = field.NewInt64(table, "client_type")
 	s.IP = field.NewString(table, "ip")
+	s.IPIndex = field.NewString(table, "ip_index")
 	s.Location = field.NewString(table, "location")
 	s.UserAgent = field.NewString(table, "user_agent")
 	s.DeviceInfo = field.NewString(table, "device_info")
@@ -137,13 +143,15 @@ func (s *sysLoginLog) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (s *sysLoginLog) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 21)
+	s.fieldMap = make(map[string]field.Expr, 23)
 	s.fieldMap["id"] = s.ID
+	s.fieldMap["tenant_id"] = s.TenantID
 	s.fieldMap["user_id"] = s.UserID
 	s.fieldMap["username"] = s.Username
 	s.fieldMap["login_type"] = s.LoginType
 	s.fieldMap["client_type"] = s.ClientType
 	s.fieldMap["ip"] = s.IP
+	s.fieldMap["ip_index"] = s.IPIndex
 	s.fieldMap["location"] = s.Location
 	s.fieldMap["user_agent"] = s.UserAgent
 	s.fieldMap["device_info"] = s.DeviceInfo
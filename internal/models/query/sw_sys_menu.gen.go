@@ -54,6 +54,7 @@ func newSysMenu(db *gorm.DB, opts ...gen.DOOption) sysMenu {
 	_sysMenu.ActivePath = field.NewString(tableName, "active_path")
 	_sysMenu.CreateBy = field.NewInt64(tableName, "create_by")
 	_sysMenu.UpdateBy = field.NewInt64(tableName, "update_by")
+	_sysMenu.Version = field.NewInt64(tableName, "version")
 	_sysMenu.CreatedAt = field.NewTime(tableName, "created_at")
 	_sysMenu.UpdatedAt = field.NewTime(tableName, "updated_at")
 	_sysMenu.DeletedAt = field.NewField(tableName, "deleted_at")
@@ -105,6 +106,7 @@ type sysMenu struct {
 	ActivePath    field.String // 激活菜单路径
 	CreateBy      field.Int64  // 创建者
 	UpdateBy      field.Int64  // 更新者
+	Version       field.Int64  // 版本号，乐观锁
 	CreatedAt     field.Time   // 创建时间
 	UpdatedAt     field.Time   // 更新时间
 	DeletedAt     field.Field  // 删除时间
@@ -153,6 +155,7 @@ func (s *sysMenu) updateTableName(table string) *sysMenu {
 	s.ActivePath = field.NewString(table, "active_path")
 	s.CreateBy = field.NewInt64(table, "create_by")
 	s.UpdateBy = field.NewInt64(table, "update_by")
+	s.Version = field.NewInt64(table, "version")
 	s.CreatedAt = field.NewTime(table, "created_at")
 	s.UpdatedAt = field.NewTime(table, "updated_at")
 	s.DeletedAt = field.NewField(table, "deleted_at")
@@ -172,7 +175,7 @@ func (s *sysMenu) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (s *sysMenu) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 31)
+	s.fieldMap = make(map[string]field.Expr, 32)
 	s.fieldMap["id"] = s.ID
 	s.fieldMap["parent_id"] = s.ParentID
 	s.fieldMap["name"] = s.Name
@@ -199,6 +202,7 @@ func (s *sysMenu) fillFieldMap() {
 	s.fieldMap["active_path"] = s.ActivePath
 	s.fieldMap["create_by"] = s.CreateBy
 	s.fieldMap["update_by"] = s.UpdateBy
+	s.fieldMap["version"] = s.Version
 	s.fieldMap["created_at"] = s.CreatedAt
 	s.fieldMap["updated_at"] = s.UpdatedAt
 	s.fieldMap["deleted_at"] = s.DeletedAt
@@ -29,13 +29,16 @@ func newSysOperationLog(db *gorm.DB, opts ...gen.DOOption) sysOperationLog {
 	tableName := _sysOperationLog.sysOperationLogDo.TableName()
 	_sysOperationLog.ALL = field.NewAsterisk(tableName)
 	_sysOperationLog.ID = field.NewInt64(tableName, "id")
+	_sysOperationLog.TenantID = field.NewInt64(tableName, "tenant_id")
 	_sysOperationLog.UserID = field.NewInt64(tableName, "user_id")
 	_sysOperationLog.Username = field.NewString(tableName, "username")
+	_sysOperationLog.ImpersonatorID = field.NewInt64(tableName, "impersonator_id")
 	_sysOperationLog.Module = field.NewString(tableName, "module")
 	_sysOperationLog.Operation = field.NewString(tableName, "operation")
 	_sysOperationLog.Method = field.NewString(tableName, "method")
 	_sysOperationLog.URL = field.NewString(tableName, "url")
 	_sysOperationLog.IP = field.NewString(tableName, "ip")
+	_sysOperationLog.IPIndex = field.NewString(tableName, "ip_index")
 	_sysOperationLog.Location = field.NewString(tableName, "location")
 	_sysOperationLog.UserAgent = field.NewString(tableName, "user_agent")
 	_sysOperationLog.RequestParams = field.NewString(tableName, "request_params")
@@ -59,24 +62,27 @@ func newSysOperationLog(db *gorm.DB, opts ...gen.DOOption) sysOperationLog {
 type sysOperationLog struct {
 	sysOperationLogDo
 
-	ALL           field.Asterisk
-	ID            field.Int64  // 日志ID
-	UserID        field.Int64  // 操作用户ID
-	Username      field.String // 操作用户名
-	Module        field.String // 操作模块
-	Operation     field.String // 操作类型
-	Method        field.String // HTTP方法
-	URL           field.String // 请求URL
-	IP            field.String // 操作IP
-	Location      field.String // IP归属地
-	UserAgent     field.String // 用户代理
-	RequestParams field.String // 请求参数
-	ResponseData  field.String // 响应数据
-	Status        field.Int64  // 操作状态（1成功 2失败）
-	ErrorMsg      field.String // 错误信息
-	CostTime      field.Int64  // 耗时（毫秒）
-	CreatedAt     field.Time   // 操作时间
-	User          sysOperationLogBelongsToUser
+	ALL            field.Asterisk
+	ID             field.Int64  // 日志ID
+	TenantID       field.Int64  // 租户ID
+	UserID         field.Int64  // 操作用户ID
+	Username       field.String // 操作用户名
+	ImpersonatorID field.Int64  // 模拟登录的管理员ID（非模拟登录为空）
+	Module         field.String // 操作模块
+	Operation      field.String // 操作类型
+	Method         field.String // HTTP方法
+	URL            field.String // 请求URL
+	IP             field.String // 操作IP（加密存储）
+	IPIndex        field.String // 操作IP盲索引
+	Location       field.String // IP归属地
+	UserAgent      field.String // 用户代理（加密存储）
+	RequestParams  field.String // 请求参数
+	ResponseData   field.String // 响应数据
+	Status         field.Int64  // 操作状态（1成功 2失败）
+	ErrorMsg       field.String // 错误信息
+	CostTime       field.Int64  // 耗时（毫秒）
+	CreatedAt      field.Time   // 操作时间
+	User           sysOperationLogBelongsToUser
 
 	fieldMap map[string]field.Expr
 }
@@ -94,13 +100,16 @@ func (s sysOperationLog) As(alias string) *sysOperationLog {
 func (s *sysOperationLog) updateTableName(table string) *sysOperationLog {
 	s.ALL = field.NewAsterisk(table)
 	s.ID = field.NewInt64(table, "id")
+	s.TenantID = field.NewInt64(table, "tenant_id")
 	s.UserID = field.NewInt64(table, "user_id")
 	s.Username = field.NewString(table, "username")
+	s.ImpersonatorID = field.NewInt64(table, "impersonator_id")
 	s.Module = field.NewString(table, "module")
 	s.Operation = field.NewString(table, "operation")
 	s.Method = field.NewString(table, "method")
 	s.URL = field.NewString(table, "url")
 	s.IP = field.NewString(table, "ip")
+	s.IPIndex = field.NewString(table, "ip_index")
 	s.Location = field.NewString(table, "location")
 	s.UserAgent = field.NewString(table, "user_agent")
 	s.RequestParams = field.NewString(table, "request_params")
@@ -125,15 +134,18 @@ func (s *sysOperationLog) GetFieldByName(fieldName string) (field.OrderExpr, boo
 }
 
 func (s *sysOperationLog) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 17)
+	s.fieldMap = make(map[string]field.Expr, 20)
 	s.fieldMap["id"] = s.ID
+	s.fieldMap["tenant_id"] = s.TenantID
 	s.fieldMap["user_id"] = s.UserID
 	s.fieldMap["username"] = s.Username
+	s.fieldMap["impersonator_id"] = s.ImpersonatorID
 	s.fieldMap["module"] = s.Module
 	s.fieldMap["operation"] = s.Operation
 	s.fieldMap["method"] = s.Method
 	s.fieldMap["url"] = s.URL
 	s.fieldMap["ip"] = s.IP
+	s.fieldMap["ip_index"] = s.IPIndex
 	s.fieldMap["location"] = s.Location
 	s.fieldMap["user_agent"] = s.UserAgent
 	s.fieldMap["request_params"] = s.RequestParams
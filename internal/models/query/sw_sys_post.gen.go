@@ -29,6 +29,7 @@ func newSysPost(db *gorm.DB, opts ...gen.DOOption) sysPost {
 	tableName := _sysPost.sysPostDo.TableName()
 	_sysPost.ALL = field.NewAsterisk(tableName)
 	_sysPost.ID = field.NewInt64(tableName, "id")
+	_sysPost.TenantID = field.NewInt64(tableName, "tenant_id")
 	_sysPost.DeptID = field.NewInt64(tableName, "dept_id")
 	_sysPost.Name = field.NewString(tableName, "name")
 	_sysPost.Code = field.NewString(tableName, "code")
@@ -54,6 +55,7 @@ type sysPost struct {
 
 	ALL       field.Asterisk
 	ID        field.Int64  // 岗位ID
+	TenantID  field.Int64  // 租户ID
 	DeptID    field.Int64  // 所属部门
 	Name      field.String // 岗位名称
 	Code      field.String // 岗位编码
@@ -80,6 +82,7 @@ func (s sysPost) As(alias string) *sysPost {
 func (s *sysPost) updateTableName(table string) *sysPost {
 	s.ALL = field.NewAsterisk(table)
 	s.ID = field.NewInt64(table, "id")
+	s.TenantID = field.NewInt64(table, "tenant_id")
 	s.DeptID = field.NewInt64(table, "dept_id")
 	s.Name = field.NewString(table, "name")
 	s.Code = field.NewString(table, "code")
@@ -104,8 +107,9 @@ func (s *sysPost) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (s *sysPost) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 10)
+	s.fieldMap = make(map[string]field.Expr, 11)
 	s.fieldMap["id"] = s.ID
+	s.fieldMap["tenant_id"] = s.TenantID
 	s.fieldMap["dept_id"] = s.DeptID
 	s.fieldMap["name"] = s.Name
 	s.fieldMap["code"] = s.Code
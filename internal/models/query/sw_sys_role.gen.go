@@ -29,6 +29,7 @@ func newSysRole(db *gorm.DB, opts ...gen.DOOption) sysRole {
 	tableName := _sysRole.sysRoleDo.TableName()
 	_sysRole.ALL = field.NewAsterisk(tableName)
 	_sysRole.ID = field.NewInt64(tableName, "id")
+	_sysRole.TenantID = field.NewInt64(tableName, "tenant_id")
 	_sysRole.Name = field.NewString(tableName, "name")
 	_sysRole.Code = field.NewString(tableName, "code")
 	_sysRole.Sort = field.NewInt64(tableName, "sort")
@@ -36,6 +37,7 @@ func newSysRole(db *gorm.DB, opts ...gen.DOOption) sysRole {
 	_sysRole.IsSuper = field.NewInt64(tableName, "is_super")
 	_sysRole.Status = field.NewInt64(tableName, "status")
 	_sysRole.Remark = field.NewString(tableName, "remark")
+	_sysRole.Version = field.NewInt64(tableName, "version")
 	_sysRole.CreatedAt = field.NewTime(tableName, "created_at")
 	_sysRole.UpdatedAt = field.NewTime(tableName, "updated_at")
 	_sysRole.DeletedAt = field.NewField(tableName, "deleted_at")
@@ -51,6 +53,7 @@ type sysRole struct {
 
 	ALL       field.Asterisk
 	ID        field.Int64  // 角色ID
+	TenantID  field.Int64  // 租户ID
 	Name      field.String // 角色名称
 	Code      field.String // 角色标识
 	Sort      field.Int64  // 排序
@@ -58,6 +61,7 @@ type sysRole struct {
 	IsSuper   field.Int64  // 是否超级管理员：1=是，2否
 	Status    field.Int64  // 状态：1=正常，2=禁用
 	Remark    field.String // 备注
+	Version   field.Int64  // 版本号，乐观锁
 	CreatedAt field.Time   // 创建时间
 	UpdatedAt field.Time   // 更新时间
 	DeletedAt field.Field  // 删除时间
@@ -78,6 +82,7 @@ func (s sysRole) As(alias string) *sysRole {
 func (s *sysRole) updateTableName(table string) *sysRole {
 	s.ALL = field.NewAsterisk(table)
 	s.ID = field.NewInt64(table, "id")
+	s.TenantID = field.NewInt64(table, "tenant_id")
 	s.Name = field.NewString(table, "name")
 	s.Code = field.NewString(table, "code")
 	s.Sort = field.NewInt64(table, "sort")
@@ -85,6 +90,7 @@ func (s *sysRole) updateTableName(table string) *sysRole {
 	s.IsSuper = field.NewInt64(table, "is_super")
 	s.Status = field.NewInt64(table, "status")
 	s.Remark = field.NewString(table, "remark")
+	s.Version = field.NewInt64(table, "version")
 	s.CreatedAt = field.NewTime(table, "created_at")
 	s.UpdatedAt = field.NewTime(table, "updated_at")
 	s.DeletedAt = field.NewField(table, "deleted_at")
@@ -104,8 +110,9 @@ func (s *sysRole) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (s *sysRole) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 11)
+	s.fieldMap = make(map[string]field.Expr, 13)
 	s.fieldMap["id"] = s.ID
+	s.fieldMap["tenant_id"] = s.TenantID
 	s.fieldMap["name"] = s.Name
 	s.fieldMap["code"] = s.Code
 	s.fieldMap["sort"] = s.Sort
@@ -113,6 +120,7 @@ func (s *sysRole) fillFieldMap() {
 	s.fieldMap["is_super"] = s.IsSuper
 	s.fieldMap["status"] = s.Status
 	s.fieldMap["remark"] = s.Remark
+	s.fieldMap["version"] = s.Version
 	s.fieldMap["created_at"] = s.CreatedAt
 	s.fieldMap["updated_at"] = s.UpdatedAt
 	s.fieldMap["deleted_at"] = s.DeletedAt
@@ -29,6 +29,7 @@ func newSysUser(db *gorm.DB, opts ...gen.DOOption) sysUser {
 	tableName := _sysUser.sysUserDo.TableName()
 	_sysUser.ALL = field.NewAsterisk(tableName)
 	_sysUser.ID = field.NewInt64(tableName, "id")
+	_sysUser.TenantID = field.NewInt64(tableName, "tenant_id")
 	_sysUser.Username = field.NewString(tableName, "username")
 	_sysUser.Password = field.NewString(tableName, "password")
 	_sysUser.Salt = field.NewString(tableName, "salt")
@@ -37,11 +38,15 @@ func newSysUser(db *gorm.DB, opts ...gen.DOOption) sysUser {
 	_sysUser.Avatar = field.NewString(tableName, "avatar")
 	_sysUser.Email = field.NewString(tableName, "email")
 	_sysUser.Phone = field.NewString(tableName, "phone")
+	_sysUser.EmailIndex = field.NewString(tableName, "email_index")
+	_sysUser.PhoneIndex = field.NewString(tableName, "phone_index")
 	_sysUser.Status = field.NewInt64(tableName, "status")
+	_sysUser.PasswordReset = field.NewInt64(tableName, "password_reset")
 	_sysUser.RoleID = field.NewInt64(tableName, "role_id")
 	_sysUser.DeptID = field.NewInt64(tableName, "dept_id")
 	_sysUser.PostID = field.NewInt64(tableName, "post_id")
 	_sysUser.Remark = field.NewString(tableName, "remark")
+	_sysUser.Version = field.NewInt64(tableName, "version")
 	_sysUser.CreatedAt = field.NewTime(tableName, "created_at")
 	_sysUser.UpdatedAt = field.NewTime(tableName, "updated_at")
 	_sysUser.DeletedAt = field.NewField(tableName, "deleted_at")
@@ -72,25 +77,30 @@ func newSysUser(db *gorm.DB, opts ...gen.DOOption) sysUser {
 type sysUser struct {
 	sysUserDo
 
-	ALL       field.Asterisk
-	ID        field.Int64  // 管理员ID
-	Username  field.String // 登录用户名
-	Password  field.String // 登录密码
-	Salt      field.String // 密码盐
-	Realname  field.String // 真实姓名
-	Nickname  field.String // 昵称
-	Avatar    field.String // 头像
-	Email     field.String // 邮箱
-	Phone     field.String // 手机号
-	Status    field.Int64  // 状态：1=正常，2=禁用
-	RoleID    field.Int64  // 角色ID
-	DeptID    field.Int64  // 部门ID
-	PostID    field.Int64  // 岗位ID
-	Remark    field.String // 备注
-	CreatedAt field.Time   // 创建时间
-	UpdatedAt field.Time   // 更新时间
-	DeletedAt field.Field  // 删除时间
-	Role      sysUserBelongsToRole
+	ALL           field.Asterisk
+	ID            field.Int64  // 管理员ID
+	TenantID      field.Int64  // 租户ID
+	Username      field.String // 登录用户名
+	Password      field.String // 登录密码
+	Salt          field.String // 密码盐
+	Realname      field.String // 真实姓名
+	Nickname      field.String // 昵称
+	Avatar        field.String // 头像
+	Email         field.String // 邮箱（加密存储）
+	Phone         field.String // 手机号（加密存储）
+	EmailIndex    field.String // 邮箱盲索引
+	PhoneIndex    field.String // 手机号盲索引
+	Status        field.Int64  // 状态：1=正常，2=禁用
+	PasswordReset field.Int64  // 是否需要修改密码：1=是，2=否
+	RoleID        field.Int64  // 角色ID
+	DeptID        field.Int64  // 部门ID
+	PostID        field.Int64  // 岗位ID
+	Remark        field.String // 备注
+	Version       field.Int64  // 版本号，乐观锁
+	CreatedAt     field.Time   // 创建时间
+	UpdatedAt     field.Time   // 更新时间
+	DeletedAt     field.Field  // 删除时间
+	Role          sysUserBelongsToRole
 
 	Dept sysUserBelongsToDept
 
@@ -112,6 +122,7 @@ func (s sysUser) As(alias string) *sysUser {
 func (s *sysUser) updateTableName(table string) *sysUser {
 	s.ALL = field.NewAsterisk(table)
 	s.ID = field.NewInt64(table, "id")
+	s.TenantID = field.NewInt64(table, "tenant_id")
 	s.Username = field.NewString(table, "username")
 	s.Password = field.NewString(table, "password")
 	s.Salt = field.NewString(table, "salt")
@@ -120,11 +131,15 @@ func (s *sysUser) updateTableName(table string) *sysUser {
 	s.Avatar = field.NewString(table, "avatar")
 	s.Email = field.NewString(table, "email")
 	s.Phone = field.NewString(table, "phone")
+	s.EmailIndex = field.NewString(table, "email_index")
+	s.PhoneIndex = field.NewString(table, "phone_index")
 	s.Status = field.NewInt64(table, "status")
+	s.PasswordReset = field.NewInt64(table, "password_reset")
 	s.RoleID = field.NewInt64(table, "role_id")
 	s.DeptID = field.NewInt64(table, "dept_id")
 	s.PostID = field.NewInt64(table, "post_id")
 	s.Remark = field.NewString(table, "remark")
+	s.Version = field.NewInt64(table, "version")
 	s.CreatedAt = field.NewTime(table, "created_at")
 	s.UpdatedAt = field.NewTime(table, "updated_at")
 	s.DeletedAt = field.NewField(table, "deleted_at")
@@ -144,8 +159,9 @@ func (s *sysUser) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (s *sysUser) fillFieldMap() {
-	s.fieldMap = make(map[string]field.Expr, 20)
+	s.fieldMap = make(map[string]field.Expr, 25)
 	s.fieldMap["id"] = s.ID
+	s.fieldMap["tenant_id"] = s.TenantID
 	s.fieldMap["username"] = s.Username
 	s.fieldMap["password"] = s.Password
 	s.fieldMap["salt"] = s.Salt
@@ -154,11 +170,15 @@ func (s *sysUser) fillFieldMap() {
 	s.fieldMap["avatar"] = s.Avatar
 	s.fieldMap["email"] = s.Email
 	s.fieldMap["phone"] = s.Phone
+	s.fieldMap["email_index"] = s.EmailIndex
+	s.fieldMap["phone_index"] = s.PhoneIndex
 	s.fieldMap["status"] = s.Status
+	s.fieldMap["password_reset"] = s.PasswordReset
 	s.fieldMap["role_id"] = s.RoleID
 	s.fieldMap["dept_id"] = s.DeptID
 	s.fieldMap["post_id"] = s.PostID
 	s.fieldMap["remark"] = s.Remark
+	s.fieldMap["version"] = s.Version
 	s.fieldMap["created_at"] = s.CreatedAt
 	s.fieldMap["updated_at"] = s.UpdatedAt
 	s.fieldMap["deleted_at"] = s.DeletedAt
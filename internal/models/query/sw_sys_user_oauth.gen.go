@@ -0,0 +1,573 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"sweet/internal/models/entity"
+)
+
+func newSysUserOauth(db *gorm.DB, opts ...gen.DOOption) sysUserOauth {
+	_sysUserOauth := sysUserOauth{}
+
+	_sysUserOauth.sysUserOauthDo.UseDB(db, opts...)
+	_sysUserOauth.sysUserOauthDo.UseModel(&entity.SysUserOauth{})
+
+	tableName := _sysUserOauth.sysUserOauthDo.TableName()
+	_sysUserOauth.ALL = field.NewAsterisk(tableName)
+	_sysUserOauth.ID = field.NewInt64(tableName, "id")
+	_sysUserOauth.TenantID = field.NewInt64(tableName, "tenant_id")
+	_sysUserOauth.UserID = field.NewInt64(tableName, "user_id")
+	_sysUserOauth.Provider = field.NewString(tableName, "provider")
+	_sysUserOauth.Issuer = field.NewString(tableName, "issuer")
+	_sysUserOauth.Subject = field.NewString(tableName, "subject")
+	_sysUserOauth.Email = field.NewString(tableName, "email")
+	_sysUserOauth.Nickname = field.NewString(tableName, "nickname")
+	_sysUserOauth.LastLoginAt = field.NewTime(tableName, "last_login_at")
+	_sysUserOauth.CreatedAt = field.NewTime(tableName, "created_at")
+	_sysUserOauth.UpdatedAt = field.NewTime(tableName, "updated_at")
+	_sysUserOauth.User = sysUserOauthBelongsToUser{
+		db: db.Session(&gorm.Session{}),
+
+		RelationField: field.NewRelation("User", "entity.SysUser"),
+		Role: struct {
+			field.RelationField
+		}{
+			RelationField: field.NewRelation("User.Role", "entity.SysRole"),
+		},
+		Dept: struct {
+			field.RelationField
+			Parent struct {
+				field.RelationField
+			}
+			Children struct {
+				field.RelationField
+			}
+		}{
+			RelationField: field.NewRelation("User.Dept", "entity.SysDept"),
+			Parent: struct {
+				field.RelationField
+			}{
+				RelationField: field.NewRelation("User.Dept.Parent", "entity.SysDept"),
+			},
+			Children: struct {
+				field.RelationField
+			}{
+				RelationField: field.NewRelation("User.Dept.Children", "entity.SysDept"),
+			},
+		},
+		Post: struct {
+			field.RelationField
+			Dept struct {
+				field.RelationField
+			}
+		}{
+			RelationField: field.NewRelation("User.Post", "entity.SysPost"),
+			Dept: struct {
+				field.RelationField
+			}{
+				RelationField: field.NewRelation("User.Post.Dept", "entity.SysDept"),
+			},
+		},
+	}
+
+	_sysUserOauth.fillFieldMap()
+
+	return _sysUserOauth
+}
+
+// sysUserOauth 第三方账号绑定表
+type sysUserOauth struct {
+	sysUserOauthDo
+
+	ALL         field.Asterisk
+	ID          field.Int64  // 绑定ID
+	TenantID    field.Int64  // 租户ID
+	UserID      field.Int64  // 用户ID
+	Provider    field.String // 身份提供方名称
+	Issuer      field.String // 签发者
+	Subject     field.String // 外部用户标识(sub)
+	Email       field.String // 外部邮箱
+	Nickname    field.String // 外部昵称
+	LastLoginAt field.Time   // 最后登录时间
+	CreatedAt   field.Time   // 创建时间
+	UpdatedAt   field.Time   // 更新时间
+	User        sysUserOauthBelongsToUser
+
+	fieldMap map[string]field.Expr
+}
+
+func (s sysUserOauth) Table(newTableName string) *sysUserOauth {
+	s.sysUserOauthDo.UseTable(newTableName)
+	return s.updateTableName(newTableName)
+}
+
+func (s sysUserOauth) As(alias string) *sysUserOauth {
+	s.sysUserOauthDo.DO = *(s.sysUserOauthDo.As(alias).(*gen.DO))
+	return s.updateTableName(alias)
+}
+
+func (s *sysUserOauth) updateTableName(table string) *sysUserOauth {
+	s.ALL = field.NewAsterisk(table)
+	s.ID = field.NewInt64(table, "id")
+	s.TenantID = field.NewInt64(table, "tenant_id")
+	s.UserID = field.NewInt64(table, "user_id")
+	s.Provider = field.NewString(table, "provider")
+	s.Issuer = field.NewString(table, "issuer")
+	s.Subject = field.NewString(table, "subject")
+	s.Email = field.NewString(table, "email")
+	s.Nickname = field.NewString(table, "nickname")
+	s.LastLoginAt = field.NewTime(table, "last_login_at")
+	s.CreatedAt = field.NewTime(table, "created_at")
+	s.UpdatedAt = field.NewTime(table, "updated_at")
+
+	s.fillFieldMap()
+
+	return s
+}
+
+func (s *sysUserOauth) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := s.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (s *sysUserOauth) fillFieldMap() {
+	s.fieldMap = make(map[string]field.Expr, 12)
+	s.fieldMap["id"] = s.ID
+	s.fieldMap["tenant_id"] = s.TenantID
+	s.fieldMap["user_id"] = s.UserID
+	s.fieldMap["provider"] = s.Provider
+	s.fieldMap["issuer"] = s.Issuer
+	s.fieldMap["subject"] = s.Subject
+	s.fieldMap["email"] = s.Email
+	s.fieldMap["nickname"] = s.Nickname
+	s.fieldMap["last_login_at"] = s.LastLoginAt
+	s.fieldMap["created_at"] = s.CreatedAt
+	s.fieldMap["updated_at"] = s.UpdatedAt
+
+}
+
+func (s sysUserOauth) clone(db *gorm.DB) sysUserOauth {
+	s.sysUserOauthDo.ReplaceConnPool(db.Statement.ConnPool)
+	s.User.db = db.Session(&gorm.Session{Initialized: true})
+	s.User.db.Statement.ConnPool = db.Statement.ConnPool
+	return s
+}
+
+func (s sysUserOauth) replaceDB(db *gorm.DB) sysUserOauth {
+	s.sysUserOauthDo.ReplaceDB(db)
+	s.User.db = db.Session(&gorm.Session{})
+	return s
+}
+
+type sysUserOauthBelongsToUser struct {
+	db *gorm.DB
+
+	field.RelationField
+
+	Role struct {
+		field.RelationField
+	}
+	Dept struct {
+		field.RelationField
+		Parent struct {
+			field.RelationField
+		}
+		Children struct {
+			field.RelationField
+		}
+	}
+	Post struct {
+		field.RelationField
+		Dept struct {
+			field.RelationField
+		}
+	}
+}
+
+func (a sysUserOauthBelongsToUser) Where(conds ...field.Expr) *sysUserOauthBelongsToUser {
+	if len(conds) == 0 {
+		return &a
+	}
+
+	exprs := make([]clause.Expression, 0, len(conds))
+	for _, cond := range conds {
+		exprs = append(exprs, cond.BeCond().(clause.Expression))
+	}
+	a.db = a.db.Clauses(clause.Where{Exprs: exprs})
+	return &a
+}
+
+func (a sysUserOauthBelongsToUser) WithContext(ctx context.Context) *sysUserOauthBelongsToUser {
+	a.db = a.db.WithContext(ctx)
+	return &a
+}
+
+func (a sysUserOauthBelongsToUser) Session(session *gorm.Session) *sysUserOauthBelongsToUser {
+	a.db = a.db.Session(session)
+	return &a
+}
+
+func (a sysUserOauthBelongsToUser) Model(m *entity.SysUserOauth) *sysUserOauthBelongsToUserTx {
+	return &sysUserOauthBelongsToUserTx{a.db.Model(m).Association(a.Name())}
+}
+
+func (a sysUserOauthBelongsToUser) Unscoped() *sysUserOauthBelongsToUser {
+	a.db = a.db.Unscoped()
+	return &a
+}
+
+type sysUserOauthBelongsToUserTx struct{ tx *gorm.Association }
+
+func (a sysUserOauthBelongsToUserTx) Find() (result *entity.SysUser, err error) {
+	return result, a.tx.Find(&result)
+}
+
+func (a sysUserOauthBelongsToUserTx) Append(values ...*entity.SysUser) (err error) {
+	targetValues := make([]interface{}, len(values))
+	for i, v := range values {
+		targetValues[i] = v
+	}
+	return a.tx.Append(targetValues...)
+}
+
+func (a sysUserOauthBelongsToUserTx) Replace(values ...*entity.SysUser) (err error) {
+	targetValues := make([]interface{}, len(values))
+	for i, v := range values {
+		targetValues[i] = v
+	}
+	return a.tx.Replace(targetValues...)
+}
+
+func (a sysUserOauthBelongsToUserTx) Delete(values ...*entity.SysUser) (err error) {
+	targetValues := make([]interface{}, len(values))
+	for i, v := range values {
+		targetValues[i] = v
+	}
+	return a.tx.Delete(targetValues...)
+}
+
+func (a sysUserOauthBelongsToUserTx) Clear() error {
+	return a.tx.Clear()
+}
+
+func (a sysUserOauthBelongsToUserTx) Count() int64 {
+	return a.tx.Count()
+}
+
+func (a sysUserOauthBelongsToUserTx) Unscoped() *sysUserOauthBelongsToUserTx {
+	a.tx = a.tx.Unscoped()
+	return &a
+}
+
+type sysUserOauthDo struct{ gen.DO }
+
+type ISysUserOauthDo interface {
+	gen.SubQuery
+	Debug() ISysUserOauthDo
+	WithContext(ctx context.Context) ISysUserOauthDo
+	WithResult(fc func(tx gen.Dao)) gen.ResultInfo
+	ReplaceDB(db *gorm.DB)
+	ReadDB() ISysUserOauthDo
+	WriteDB() ISysUserOauthDo
+	As(alias string) gen.Dao
+	Session(config *gorm.Session) ISysUserOauthDo
+	Columns(cols ...field.Expr) gen.Columns
+	Clauses(conds ...clause.Expression) ISysUserOauthDo
+	Not(conds ...gen.Condition) ISysUserOauthDo
+	Or(conds ...gen.Condition) ISysUserOauthDo
+	Select(conds ...field.Expr) ISysUserOauthDo
+	Where(conds ...gen.Condition) ISysUserOauthDo
+	Order(conds ...field.Expr) ISysUserOauthDo
+	Distinct(cols ...field.Expr) ISysUserOauthDo
+	Omit(cols ...field.Expr) ISysUserOauthDo
+	Join(table schema.Tabler, on ...field.Expr) ISysUserOauthDo
+	LeftJoin(table schema.Tabler, on ...field.Expr) ISysUserOauthDo
+	RightJoin(table schema.Tabler, on ...field.Expr) ISysUserOauthDo
+	Group(cols ...field.Expr) ISysUserOauthDo
+	Having(conds ...gen.Condition) ISysUserOauthDo
+	Limit(limit int) ISysUserOauthDo
+	Offset(offset int) ISysUserOauthDo
+	Count() (count int64, err error)
+	Scopes(funcs ...func(gen.Dao) gen.Dao) ISysUserOauthDo
+	Unscoped() ISysUserOauthDo
+	Create(values ...*entity.SysUserOauth) error
+	CreateInBatches(values []*entity.SysUserOauth, batchSize int) error
+	Save(values ...*entity.SysUserOauth) error
+	First() (*entity.SysUserOauth, error)
+	Take() (*entity.SysUserOauth, error)
+	Last() (*entity.SysUserOauth, error)
+	Find() ([]*entity.SysUserOauth, error)
+	FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*entity.SysUserOauth, err error)
+	FindInBatches(result *[]*entity.SysUserOauth, batchSize int, fc func(tx gen.Dao, batch int) error) error
+	Pluck(column field.Expr, dest interface{}) error
+	Delete(...*entity.SysUserOauth) (info gen.ResultInfo, err error)
+	Update(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	Updates(value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumn(column field.Expr, value interface{}) (info gen.ResultInfo, err error)
+	UpdateColumnSimple(columns ...field.AssignExpr) (info gen.ResultInfo, err error)
+	UpdateColumns(value interface{}) (info gen.ResultInfo, err error)
+	UpdateFrom(q gen.SubQuery) gen.Dao
+	Attrs(attrs ...field.AssignExpr) ISysUserOauthDo
+	Assign(attrs ...field.AssignExpr) ISysUserOauthDo
+	Joins(fields ...field.RelationField) ISysUserOauthDo
+	Preload(fields ...field.RelationField) ISysUserOauthDo
+	FirstOrInit() (*entity.SysUserOauth, error)
+	FirstOrCreate() (*entity.SysUserOauth, error)
+	FindByPage(offset int, limit int) (result []*entity.SysUserOauth, count int64, err error)
+	ScanByPage(result interface{}, offset int, limit int) (count int64, err error)
+	Rows() (*sql.Rows, error)
+	Row() *sql.Row
+	Scan(result interface{}) (err error)
+	Returning(value interface{}, columns ...string) ISysUserOauthDo
+	UnderlyingDB() *gorm.DB
+	schema.Tabler
+}
+
+func (s sysUserOauthDo) Debug() ISysUserOauthDo {
+	return s.withDO(s.DO.Debug())
+}
+
+func (s sysUserOauthDo) WithContext(ctx context.Context) ISysUserOauthDo {
+	return s.withDO(s.DO.WithContext(ctx))
+}
+
+func (s sysUserOauthDo) ReadDB() ISysUserOauthDo {
+	return s.Clauses(dbresolver.Read)
+}
+
+func (s sysUserOauthDo) WriteDB() ISysUserOauthDo {
+	return s.Clauses(dbresolver.Write)
+}
+
+func (s sysUserOauthDo) Session(config *gorm.Session) ISysUserOauthDo {
+	return s.withDO(s.DO.Session(config))
+}
+
+func (s sysUserOauthDo) Clauses(conds ...clause.Expression) ISysUserOauthDo {
+	return s.withDO(s.DO.Clauses(conds...))
+}
+
+func (s sysUserOauthDo) Returning(value interface{}, columns ...string) ISysUserOauthDo {
+	return s.withDO(s.DO.Returning(value, columns...))
+}
+
+func (s sysUserOauthDo) Not(conds ...gen.Condition) ISysUserOauthDo {
+	return s.withDO(s.DO.Not(conds...))
+}
+
+func (s sysUserOauthDo) Or(conds ...gen.Condition) ISysUserOauthDo {
+	return s.withDO(s.DO.Or(conds...))
+}
+
+func (s sysUserOauthDo) Select(conds ...field.Expr) ISysUserOauthDo {
+	return s.withDO(s.DO.Select(conds...))
+}
+
+func (s sysUserOauthDo) Where(conds ...gen.Condition) ISysUserOauthDo {
+	return s.withDO(s.DO.Where(conds...))
+}
+
+func (s sysUserOauthDo) Order(conds ...field.Expr) ISysUserOauthDo {
+	return s.withDO(s.DO.Order(conds...))
+}
+
+func (s sysUserOauthDo) Distinct(cols ...field.Expr) ISysUserOauthDo {
+	return s.withDO(s.DO.Distinct(cols...))
+}
+
+func (s sysUserOauthDo) Omit(cols ...field.Expr) ISysUserOauthDo {
+	return s.withDO(s.DO.Omit(cols...))
+}
+
+func (s sysUserOauthDo) Join(table schema.Tabler, on ...field.Expr) ISysUserOauthDo {
+	return s.withDO(s.DO.Join(table, on...))
+}
+
+func (s sysUserOauthDo) LeftJoin(table schema.Tabler, on ...field.Expr) ISysUserOauthDo {
+	return s.withDO(s.DO.LeftJoin(table, on...))
+}
+
+func (s sysUserOauthDo) RightJoin(table schema.Tabler, on ...field.Expr) ISysUserOauthDo {
+	return s.withDO(s.DO.RightJoin(table, on...))
+}
+
+func (s sysUserOauthDo) Group(cols ...field.Expr) ISysUserOauthDo {
+	return s.withDO(s.DO.Group(cols...))
+}
+
+func (s sysUserOauthDo) Having(conds ...gen.Condition) ISysUserOauthDo {
+	return s.withDO(s.DO.Having(conds...))
+}
+
+func (s sysUserOauthDo) Limit(limit int) ISysUserOauthDo {
+	return s.withDO(s.DO.Limit(limit))
+}
+
+func (s sysUserOauthDo) Offset(offset int) ISysUserOauthDo {
+	return s.withDO(s.DO.Offset(offset))
+}
+
+func (s sysUserOauthDo) Scopes(funcs ...func(gen.Dao) gen.Dao) ISysUserOauthDo {
+	return s.withDO(s.DO.Scopes(funcs...))
+}
+
+func (s sysUserOauthDo) Unscoped() ISysUserOauthDo {
+	return s.withDO(s.DO.Unscoped())
+}
+
+func (s sysUserOauthDo) Create(values ...*entity.SysUserOauth) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Create(values)
+}
+
+func (s sysUserOauthDo) CreateInBatches(values []*entity.SysUserOauth, batchSize int) error {
+	return s.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (s sysUserOauthDo) Save(values ...*entity.SysUserOauth) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return s.DO.Save(values)
+}
+
+func (s sysUserOauthDo) First() (*entity.SysUserOauth, error) {
+	if result, err := s.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysUserOauth), nil
+	}
+}
+
+func (s sysUserOauthDo) Take() (*entity.SysUserOauth, error) {
+	if result, err := s.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysUserOauth), nil
+	}
+}
+
+func (s sysUserOauthDo) Last() (*entity.SysUserOauth, error) {
+	if result, err := s.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysUserOauth), nil
+	}
+}
+
+func (s sysUserOauthDo) Find() ([]*entity.SysUserOauth, error) {
+	result, err := s.DO.Find()
+	return result.([]*entity.SysUserOauth), err
+}
+
+func (s sysUserOauthDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*entity.SysUserOauth, err error) {
+	buf := make([]*entity.SysUserOauth, 0, batchSize)
+	err = s.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (s sysUserOauthDo) FindInBatches(result *[]*entity.SysUserOauth, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return s.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (s sysUserOauthDo) Attrs(attrs ...field.AssignExpr) ISysUserOauthDo {
+	return s.withDO(s.DO.Attrs(attrs...))
+}
+
+func (s sysUserOauthDo) Assign(attrs ...field.AssignExpr) ISysUserOauthDo {
+	return s.withDO(s.DO.Assign(attrs...))
+}
+
+func (s sysUserOauthDo) Joins(fields ...field.RelationField) ISysUserOauthDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Joins(_f))
+	}
+	return &s
+}
+
+func (s sysUserOauthDo) Preload(fields ...field.RelationField) ISysUserOauthDo {
+	for _, _f := range fields {
+		s = *s.withDO(s.DO.Preload(_f))
+	}
+	return &s
+}
+
+func (s sysUserOauthDo) FirstOrInit() (*entity.SysUserOauth, error) {
+	if result, err := s.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysUserOauth), nil
+	}
+}
+
+func (s sysUserOauthDo) FirstOrCreate() (*entity.SysUserOauth, error) {
+	if result, err := s.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.SysUserOauth), nil
+	}
+}
+
+func (s sysUserOauthDo) FindByPage(offset int, limit int) (result []*entity.SysUserOauth, count int64, err error) {
+	result, err = s.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = s.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (s sysUserOauthDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = s.Count()
+	if err != nil {
+		return
+	}
+
+	err = s.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (s sysUserOauthDo) Scan(result interface{}) (err error) {
+	return s.DO.Scan(result)
+}
+
+func (s sysUserOauthDo) Delete(models ...*entity.SysUserOauth) (result gen.ResultInfo, err error) {
+	return s.DO.Delete(models)
+}
+
+func (s *sysUserOauthDo) withDO(do gen.Dao) *sysUserOauthDo {
+	s.DO = *do.(*gen.DO)
+	return s
+}
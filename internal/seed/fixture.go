@@ -0,0 +1,201 @@
+package seed
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture 初始化数据
+type Fixture struct {
+	Roles     []*RoleFixture     `json:"roles" yaml:"roles"`           // 角色
+	Admin     *AdminFixture      `json:"admin" yaml:"admin"`           // 初始管理员
+	ApiGroups []*ApiGroupFixture `json:"api_groups" yaml:"api_groups"` // API分组及接口
+	Menus     []*MenuFixture     `json:"menus" yaml:"menus"`           // 菜单树
+}
+
+// RoleFixture 角色
+type RoleFixture struct {
+	Code     string `json:"code" yaml:"code"`           // 角色标识，用于判断是否已存在
+	Name     string `json:"name" yaml:"name"`           // 角色名称
+	Sort     int64  `json:"sort" yaml:"sort"`           // 排序
+	IsSystem bool   `json:"is_system" yaml:"is_system"` // 是否系统内置
+	IsSuper  bool   `json:"is_super" yaml:"is_super"`   // 是否超级管理员
+	Remark   string `json:"remark" yaml:"remark"`       // 备注
+}
+
+// AdminFixture 初始管理员，首次登录后必须修改密码
+type AdminFixture struct {
+	Username string `json:"username" yaml:"username"` // 登录用户名，用于判断是否已存在
+	Password string `json:"password" yaml:"password"` // 初始密码，为空时随机生成
+	Realname string `json:"realname" yaml:"realname"` // 真实姓名
+	Nickname string `json:"nickname" yaml:"nickname"` // 昵称
+	Role     string `json:"role" yaml:"role"`         // 角色标识
+}
+
+// ApiGroupFixture API分组
+type ApiGroupFixture struct {
+	Code        string        `json:"code" yaml:"code"`               // 分组编码，用于判断是否已存在
+	Name        string        `json:"name" yaml:"name"`               // 分组名称
+	Description string        `json:"description" yaml:"description"` // 分组描述
+	Sort        int64         `json:"sort" yaml:"sort"`               // 显示顺序
+	Apis        []*ApiFixture `json:"apis" yaml:"apis"`               // 分组下的接口
+}
+
+// ApiFixture API接口
+type ApiFixture struct {
+	Name        string `json:"name" yaml:"name"`               // API名称
+	Method      string `json:"method" yaml:"method"`           // HTTP方法
+	Path        string `json:"path" yaml:"path"`               // 路由路径，与 gin FullPath 一致，如 /api/v1/users/:id
+	Description string `json:"description" yaml:"description"` // API描述
+	Public      bool   `json:"public" yaml:"public"`           // 是否无需认证
+}
+
+// MenuFixture 菜单，按 name 判断是否已存在
+type MenuFixture struct {
+	Name      string         `json:"name" yaml:"name"`             // 路由名称
+	Title     string         `json:"title" yaml:"title"`           // 菜单名称
+	Path      string         `json:"path" yaml:"path"`             // 路由地址
+	Component string         `json:"component" yaml:"component"`   // 组件地址
+	MenuType  int64          `json:"menu_type" yaml:"menu_type"`   // 菜单类型（1 目录 2 菜单 3 按钮）
+	Perms     string         `json:"perms" yaml:"perms"`           // 权限标识
+	Icon      string         `json:"icon" yaml:"icon"`             // 菜单图标
+	Order     int64          `json:"order" yaml:"order"`           // 显示顺序 从大到小
+	IsHide    bool           `json:"is_hide" yaml:"is_hide"`       // 是否在菜单中隐藏
+	KeepAlive bool           `json:"keep_alive" yaml:"keep_alive"` // 是否缓存页面
+	Children  []*MenuFixture `json:"children" yaml:"children"`     // 子菜单与按钮
+}
+
+// Parse 解析初始化数据，format 为 yaml、yml 或 json
+func Parse(data []byte, format string) (*Fixture, error) {
+	var fixture Fixture
+	switch strings.ToLower(strings.TrimPrefix(format, ".")) {
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("解析YAML失败: %w", err)
+		}
+	case "json":
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("解析JSON失败: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("不支持的初始化数据格式: %s", format)
+	}
+
+	if err := fixture.Validate(); err != nil {
+		return nil, err
+	}
+	return &fixture, nil
+}
+
+// Load 从文件系统读取初始化数据，格式由扩展名决定
+func Load(fsys fs.FS, name string) (*Fixture, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("读取初始化数据失败: %w", err)
+	}
+	fixture, err := Parse(data, path.Ext(name))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return fixture, nil
+}
+
+// Validate 校验必填字段与唯一性
+func (f *Fixture) Validate() error {
+	roles := make(map[string]struct{}, len(f.Roles))
+	for _, r := range f.Roles {
+		if r.Code == "" || r.Name == "" {
+			return fmt.Errorf("角色的 code 和 name 不能为空")
+		}
+		if _, ok := roles[r.Code]; ok {
+			return fmt.Errorf("角色 %s 重复", r.Code)
+		}
+		roles[r.Code] = struct{}{}
+	}
+
+	if f.Admin != nil {
+		if f.Admin.Username == "" {
+			return fmt.Errorf("管理员的 username 不能为空")
+		}
+		if n := len(f.Admin.Password); n != 0 && (n < 6 || n > 15) {
+			return fmt.Errorf("管理员初始密码长度需为6-15位")
+		}
+		if _, ok := roles[f.Admin.Role]; !ok {
+			return fmt.Errorf("管理员角色 %q 未在 roles 中定义", f.Admin.Role)
+		}
+	}
+
+	groups := make(map[string]struct{}, len(f.ApiGroups))
+	apis := make(map[string]struct{})
+	for _, g := range f.ApiGroups {
+		if g.Code == "" || g.Name == "" {
+			return fmt.Errorf("API分组的 code 和 name 不能为空")
+		}
+		if _, ok := groups[g.Code]; ok {
+			return fmt.Errorf("API分组 %s 重复", g.Code)
+		}
+		groups[g.Code] = struct{}{}
+
+		for _, a := range g.Apis {
+			a.Method = strings.ToUpper(a.Method)
+			if !validMethod(a.Method) || !strings.HasPrefix(a.Path, "/") || a.Name == "" {
+				return fmt.Errorf("API %s %s 定义不完整", a.Method, a.Path)
+			}
+			key := a.Method + " " + a.Path
+			if _, ok := apis[key]; ok {
+				return fmt.Errorf("API %s 重复", key)
+			}
+			apis[key] = struct{}{}
+		}
+	}
+
+	return validateMenus(f.Menus, make(map[string]struct{}))
+}
+
+// validateMenus 递归校验菜单树，name 全局唯一
+func validateMenus(menus []*MenuFixture, names map[string]struct{}) error {
+	for _, m := range menus {
+		if m.Name == "" || m.Title == "" {
+			return fmt.Errorf("菜单的 name 和 title 不能为空")
+		}
+		if _, ok := names[m.Name]; ok {
+			return fmt.Errorf("菜单 %s 重复", m.Name)
+		}
+		names[m.Name] = struct{}{}
+
+		switch m.MenuType {
+		case 0:
+			m.MenuType = 1
+		case 1, 2:
+		case 3:
+			if m.Perms == "" {
+				return fmt.Errorf("按钮 %s 缺少权限标识", m.Name)
+			}
+			if len(m.Children) > 0 {
+				return fmt.Errorf("按钮 %s 不能包含子菜单", m.Name)
+			}
+		default:
+			return fmt.Errorf("菜单 %s 的类型不合法: %d", m.Name, m.MenuType)
+		}
+
+		if err := validateMenus(m.Children, names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validMethod 是否为支持的HTTP方法
+func validMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
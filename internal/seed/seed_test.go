@@ -0,0 +1,164 @@
+package seed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"sweet/internal/models/entity"
+	"sweet/pkg/crypto"
+	"sweet/pkg/migrate"
+	"sweet/pkg/utils"
+	"sweet/resource"
+)
+
+const testFixture = `
+roles:
+  - { code: super_admin, name: 超级管理员, is_system: true, is_super: true }
+  - { code: auditor, name: 审计员 }
+admin: { username: admin, password: "123456", role: super_admin }
+api_groups:
+  - code: user
+    name: 用户管理
+    apis:
+      - { name: 用户列表, method: get, path: /api/v1/users }
+      - { name: 登录, method: POST, path: /api/v1/auth/login, public: true }
+menus:
+  - name: System
+    title: 系统管理
+    children:
+      - name: SystemUser
+        title: 用户管理
+        menu_type: 2
+        children:
+          - { name: SystemUserCreate, title: 新增, menu_type: 3, perms: "system:user:create" }
+`
+
+// newTestDB 创建内存数据库并执行 SQLite 迁移脚本建表
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	// 内存数据库每个连接相互独立
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	fsys, err := resource.MigrationsFor("sqlite")
+	require.NoError(t, err)
+	migrations, err := migrate.Load(fsys)
+	require.NoError(t, err)
+	_, err = migrate.New(db, migrations, migrate.DefaultConfig()).Up(context.Background(), 0)
+	require.NoError(t, err)
+	return db
+}
+
+func TestParse(t *testing.T) {
+	fixture, err := Parse([]byte(testFixture), "yaml")
+	require.NoError(t, err)
+	assert.Len(t, fixture.Roles, 2)
+	assert.True(t, fixture.Roles[0].IsSuper)
+	assert.Equal(t, "GET", fixture.ApiGroups[0].Apis[0].Method)
+	assert.Equal(t, int64(1), fixture.Menus[0].MenuType, "未指定类型的菜单默认为目录")
+
+	fixture, err = Parse([]byte(`{"roles":[{"code":"r","name":"角色"}],"menus":[{"name":"M","title":"菜单"}]}`), ".json")
+	require.NoError(t, err)
+	assert.Equal(t, "r", fixture.Roles[0].Code)
+
+	_, err = Parse([]byte("roles: []"), "toml")
+	assert.Error(t, err)
+}
+
+func TestFixture_Validate(t *testing.T) {
+	cases := map[string]string{
+		"角色重复":     `roles: [{code: a, name: A}, {code: a, name: B}]`,
+		"管理员角色未定义": `admin: {username: admin, role: missing}`,
+		"密码过短":     `{roles: [{code: a, name: A}], admin: {username: admin, password: "123", role: a}}`,
+		"API重复":    `api_groups: [{code: g, name: G, apis: [{name: a, method: GET, path: /a}, {name: b, method: get, path: /a}]}]`,
+		"API方法错误":  `api_groups: [{code: g, name: G, apis: [{name: a, method: FETCH, path: /a}]}]`,
+		"菜单重复":     `menus: [{name: A, title: A, children: [{name: A, title: B}]}]`,
+		"按钮缺少权限":   `menus: [{name: A, title: A, menu_type: 3}]`,
+		"菜单类型错误":   `menus: [{name: A, title: A, menu_type: 9}]`,
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(data), "yaml")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoad_Default(t *testing.T) {
+	fixture, err := Load(resource.Seeds(), "default.yaml")
+	require.NoError(t, err)
+	require.NotNil(t, fixture.Admin)
+	assert.Empty(t, fixture.Admin.Password, "默认管理员密码应随机生成")
+
+	var super *RoleFixture
+	for _, r := range fixture.Roles {
+		if r.Code == fixture.Admin.Role {
+			super = r
+		}
+	}
+	require.NotNil(t, super)
+	assert.True(t, super.IsSystem)
+	assert.True(t, super.IsSuper)
+}
+
+func TestSeeder_Run(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	fixture, err := Parse([]byte(testFixture), "yaml")
+	require.NoError(t, err)
+
+	result, err := New(db).Run(ctx, fixture)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Roles: 2, ApiGroups: 1, Apis: 2, Menus: 3, Admin: true}, result)
+
+	var role entity.SysRole
+	require.NoError(t, db.Where("code = ?", "super_admin").First(&role).Error)
+	assert.Equal(t, int64(1), utils.Deref(role.IsSystem))
+	assert.Equal(t, int64(1), utils.Deref(role.IsSuper))
+
+	var admin entity.SysUser
+	require.NoError(t, db.Where("username = ?", "admin").First(&admin).Error)
+	assert.Equal(t, role.ID, utils.Deref(admin.RoleID))
+	assert.Equal(t, int64(1), utils.Deref(admin.PasswordReset), "初始管理员必须修改密码")
+	assert.Equal(t, crypto.MD5("123456"+admin.Salt), admin.Password)
+
+	var button entity.SysMenu
+	require.NoError(t, db.Where("name = ?", "SystemUserCreate").First(&button).Error)
+	var parent entity.SysMenu
+	require.NoError(t, db.Where("id = ?", utils.Deref(button.ParentID)).First(&parent).Error)
+	assert.Equal(t, "SystemUser", parent.Name)
+
+	var login entity.SysApi
+	require.NoError(t, db.Where("path = ?", "/api/v1/auth/login").First(&login).Error)
+	assert.Equal(t, int64(2), utils.Deref(login.IsAuth))
+
+	// 重复执行不会新建或修改数据
+	require.NoError(t, db.Model(&role).Update("name", "自定义名称").Error)
+	result, err = New(db).Run(ctx, fixture)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{}, result)
+	require.NoError(t, db.First(&role, role.ID).Error)
+	assert.Equal(t, "自定义名称", role.Name)
+}
+
+func TestSeeder_GeneratedPassword(t *testing.T) {
+	db := newTestDB(t)
+	fixture, err := Load(resource.Seeds(), "default.yaml")
+	require.NoError(t, err)
+
+	result, err := New(db).Run(context.Background(), fixture)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.AdminPassword)
+
+	var admin entity.SysUser
+	require.NoError(t, db.Where("username = ?", fixture.Admin.Username).First(&admin).Error)
+	assert.Equal(t, crypto.MD5(result.AdminPassword+admin.Salt), admin.Password)
+}
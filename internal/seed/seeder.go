@@ -0,0 +1,238 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"sweet/internal/models/entity"
+	"sweet/internal/models/query"
+	"sweet/pkg/auth"
+	"sweet/pkg/crypto"
+	"sweet/pkg/database"
+	"sweet/pkg/utils"
+)
+
+// Result 执行结果
+type Result struct {
+	Roles         int    // 新建角色数
+	ApiGroups     int    // 新建API分组数
+	Apis          int    // 新建API数
+	Menus         int    // 新建菜单数
+	Admin         bool   // 是否新建了管理员
+	AdminPassword string // 随机生成的管理员初始密码，仅在新建且未指定密码时返回
+}
+
+// Seeder 初始化数据写入器
+// 所有数据按唯一标识判断是否已存在（包括已软删除的记录），已存在的数据不会被修改，可重复执行
+type Seeder struct {
+	q *query.Query
+}
+
+// New 创建初始化数据写入器
+func New(db *gorm.DB) *Seeder {
+	return &Seeder{q: query.Use(db)}
+}
+
+// Run 在一个事务中写入初始化数据
+func (s *Seeder) Run(ctx context.Context, fixture *Fixture) (*Result, error) {
+	if err := fixture.Validate(); err != nil {
+		return nil, err
+	}
+	// 初始化数据归属超级租户，查询与写入均限定在该租户内
+	ctx = database.WithTenant(ctx, auth.SuperTenantID)
+
+	result := &Result{}
+	err := s.q.Transaction(func(tx *query.Query) error {
+		roleIDs, err := s.seedRoles(ctx, tx, fixture.Roles, result)
+		if err != nil {
+			return err
+		}
+		if err := s.seedApis(ctx, tx, fixture.ApiGroups, result); err != nil {
+			return err
+		}
+		if err := s.seedMenus(ctx, tx, fixture.Menus, nil, result); err != nil {
+			return err
+		}
+		if fixture.Admin != nil {
+			return s.seedAdmin(ctx, tx, fixture.Admin, roleIDs[fixture.Admin.Role], result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// seedRoles 写入角色，返回角色标识到ID的映射
+func (s *Seeder) seedRoles(ctx context.Context, tx *query.Query, roles []*RoleFixture, result *Result) (map[string]int64, error) {
+	dao := tx.SysRole
+	ids := make(map[string]int64, len(roles))
+	for _, r := range roles {
+		exist, err := dao.WithContext(ctx).Unscoped().Where(dao.Code.Eq(r.Code)).First()
+		if err == nil {
+			ids[r.Code] = exist.ID
+			continue
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("查询角色 %s 失败: %w", r.Code, err)
+		}
+
+		role := &entity.SysRole{
+			Name:     r.Name,
+			Code:     r.Code,
+			Sort:     r.Sort,
+			IsSystem: utils.Ptr(flag(r.IsSystem)),
+			IsSuper:  utils.Ptr(flag(r.IsSuper)),
+			Status:   utils.Ptr[int64](1),
+			Remark:   optional(r.Remark),
+		}
+		if err := dao.WithContext(ctx).Create(role); err != nil {
+			return nil, fmt.Errorf("创建角色 %s 失败: %w", r.Code, err)
+		}
+		ids[r.Code] = role.ID
+		result.Roles++
+	}
+	return ids, nil
+}
+
+// seedApis 写入API分组及接口
+func (s *Seeder) seedApis(ctx context.Context, tx *query.Query, groups []*ApiGroupFixture, result *Result) error {
+	groupDao, apiDao := tx.SysApiGroup, tx.SysApi
+	for _, g := range groups {
+		if _, err := groupDao.WithContext(ctx).Unscoped().Where(groupDao.Code.Eq(g.Code)).First(); errors.Is(err, gorm.ErrRecordNotFound) {
+			group := &entity.SysApiGroup{
+				Name:        g.Name,
+				Code:        g.Code,
+				Description: optional(g.Description),
+				Sort:        g.Sort,
+				Status:      utils.Ptr[int64](1),
+			}
+			if err := groupDao.WithContext(ctx).Create(group); err != nil {
+				return fmt.Errorf("创建API分组 %s 失败: %w", g.Code, err)
+			}
+			result.ApiGroups++
+		} else if err != nil {
+			return fmt.Errorf("查询API分组 %s 失败: %w", g.Code, err)
+		}
+
+		for _, a := range g.Apis {
+			_, err := apiDao.WithContext(ctx).Unscoped().Where(apiDao.Path.Eq(a.Path), apiDao.Method.Eq(a.Method)).First()
+			if err == nil {
+				continue
+			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("查询API %s %s 失败: %w", a.Method, a.Path, err)
+			}
+
+			api := &entity.SysApi{
+				Name:        a.Name,
+				Path:        a.Path,
+				Method:      a.Method,
+				Group_:      utils.Ptr(g.Code),
+				Description: optional(a.Description),
+				Status:      utils.Ptr[int64](1),
+				IsAuth:      utils.Ptr(flag(!a.Public)),
+			}
+			if err := apiDao.WithContext(ctx).Create(api); err != nil {
+				return fmt.Errorf("创建API %s %s 失败: %w", a.Method, a.Path, err)
+			}
+			result.Apis++
+		}
+	}
+	return nil
+}
+
+// seedMenus 递归写入菜单树
+func (s *Seeder) seedMenus(ctx context.Context, tx *query.Query, menus []*MenuFixture, parentID *int64, result *Result) error {
+	dao := tx.SysMenu
+	for _, m := range menus {
+		exist, err := dao.WithContext(ctx).Unscoped().Where(dao.Name.Eq(m.Name)).First()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			exist = &entity.SysMenu{
+				ParentID:     parentID,
+				Name:         m.Name,
+				Title:        m.Title,
+				Path:         optional(m.Path),
+				Component:    optional(m.Component),
+				MenuType:     utils.Ptr(m.MenuType),
+				Status:       utils.Ptr[int64](1),
+				Perms:        optional(m.Perms),
+				Icon:         optional(m.Icon),
+				Order_:       utils.Ptr(m.Order),
+				IsHide:       utils.Ptr(flag(m.IsHide)),
+				KeepAlive:    utils.Ptr(flag(m.KeepAlive)),
+				IsFirstLevel: utils.Ptr(flag(parentID == nil)),
+			}
+			if err := dao.WithContext(ctx).Create(exist); err != nil {
+				return fmt.Errorf("创建菜单 %s 失败: %w", m.Name, err)
+			}
+			result.Menus++
+		} else if err != nil {
+			return fmt.Errorf("查询菜单 %s 失败: %w", m.Name, err)
+		}
+
+		if err := s.seedMenus(ctx, tx, m.Children, &exist.ID, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedAdmin 写入初始管理员，首次登录后必须修改密码
+func (s *Seeder) seedAdmin(ctx context.Context, tx *query.Query, admin *AdminFixture, roleID int64, result *Result) error {
+	dao := tx.SysUser
+	if _, err := dao.WithContext(ctx).Unscoped().Where(dao.Username.Eq(admin.Username)).First(); err == nil {
+		return nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("查询管理员 %s 失败: %w", admin.Username, err)
+	}
+
+	password := admin.Password
+	if password == "" {
+		generated, err := crypto.RandomHex(6)
+		if err != nil {
+			return err
+		}
+		password = generated
+		result.AdminPassword = generated
+	}
+
+	nickname := admin.Nickname
+	if nickname == "" {
+		nickname = admin.Username
+	}
+	salt := crypto.Salt()
+	user := &entity.SysUser{
+		Username:      admin.Username,
+		Password:      crypto.MD5(password + salt),
+		Salt:          salt,
+		Realname:      admin.Realname,
+		Nickname:      nickname,
+		Status:        utils.Ptr[int64](1),
+		PasswordReset: utils.Ptr[int64](1),
+		RoleID:        &roleID,
+	}
+	if err := dao.WithContext(ctx).Create(user); err != nil {
+		return fmt.Errorf("创建管理员 %s 失败: %w", admin.Username, err)
+	}
+	result.Admin = true
+	return nil
+}
+
+// flag 布尔值转换为 1=是，2=否
+func flag(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 2
+}
+
+// optional 空字符串转换为 nil
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,61 @@
+package basic
+
+import (
+	"context"
+	"encoding/json"
+	"sweet/internal/global"
+	"sweet/internal/listing"
+	"sweet/internal/models"
+	basicDto "sweet/internal/models/dto/basic"
+	"sweet/pkg/database"
+	"sweet/pkg/errs"
+
+	"go.uber.org/zap"
+)
+
+// AuditLogService 数据变更审计服务实现
+type AuditLogService struct{}
+
+// ListAuditLog 按实体与主键查询变更记录，记录由 database.AuditPlugin 写入
+func (s *AuditLogService) ListAuditLog(ctx context.Context, req *basicDto.ListAuditLogReq) (*basicDto.ListAuditLogRes, error) {
+	spec := &listing.Spec{Page: req.PageReq}
+	offset, limit := spec.Limit(listing.MaxSize)
+
+	logs, total, err := database.FindAuditLogs(ctx, global.DBClient.DB(), req.Entity, req.EntityID, offset, limit)
+	if err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"查询变更记录失败",
+			zap.String("entity", req.Entity),
+			zap.String("entity_id", req.EntityID),
+			zap.Error(err),
+		)
+		return nil, errs.ErrServer
+	}
+
+	list := make([]*basicDto.AuditLogItem, 0, len(logs))
+	for _, log := range logs {
+		list = append(list, &basicDto.AuditLogItem{
+			ID:        log.ID,
+			Action:    log.Action,
+			Before:    rawJSON(log.Before),
+			After:     rawJSON(log.After),
+			Changes:   rawJSON(log.Changes),
+			UserID:    log.UserID,
+			CreatedAt: log.CreatedAt,
+		})
+	}
+	return (*basicDto.ListAuditLogRes)(models.NewPageRes(total, list)), nil
+}
+
+// rawJSON 将存储的 JSON 字符串原样输出，空值输出 null
+func rawJSON(s *string) json.RawMessage {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return json.RawMessage(*s)
+}
+
+// NewAuditLogService 创建数据变更审计服务
+func NewAuditLogService() IAuditLogService {
+	return &AuditLogService{}
+}
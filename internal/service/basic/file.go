@@ -14,9 +14,11 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	"gorm.io/gen/field"
 	"gorm.io/gorm"
 
 	"sweet/internal/global"
+	"sweet/internal/listing"
 	basicDto "sweet/internal/models/dto/basic"
 	"sweet/internal/models/entity"
 )
@@ -38,7 +40,7 @@ func (s *FileService) UploadFile(ctx context.Context, req *basicDto.UploadFileRe
 	// 打开上传的文件
 	src, err := req.File.Open()
 	if err != nil {
-		global.Logger.Error("打开上传文件失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("打开上传文件失败", zap.Error(err))
 		return nil, fmt.Errorf("打开文件失败: %v", err)
 	}
 	defer src.Close()
@@ -46,14 +48,14 @@ func (s *FileService) UploadFile(ctx context.Context, req *basicDto.UploadFileRe
 	// 计算文件MD5
 	md5Hash, err := s.calculateFileMD5(src)
 	if err != nil {
-		global.Logger.Error("计算文件MD5失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("计算文件MD5失败", zap.Error(err))
 		return nil, fmt.Errorf("计算文件MD5失败: %v", err)
 	}
 
 	// 检查文件是否已存在（去重）
 	existingFile, err := s.GetFileByMD5(ctx, md5Hash)
 	if err != nil {
-		global.Logger.Error("检查文件MD5失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("检查文件MD5失败", zap.Error(err))
 		return nil, fmt.Errorf("检查文件是否存在失败: %v", err)
 	}
 	if existingFile != nil {
@@ -101,7 +103,7 @@ func (s *FileService) UploadFile(ctx context.Context, req *basicDto.UploadFileRe
 	}
 
 	if err != nil {
-		global.Logger.Error("保存文件失败", zap.Int64("storage_type", storageType), zap.Error(err))
+		global.Logger.WithContext(ctx).Error("保存文件失败", zap.Int64("storage_type", storageType), zap.Error(err))
 		return nil, fmt.Errorf("保存文件失败: %v", err)
 	}
 
@@ -129,11 +131,11 @@ func (s *FileService) UploadFile(ctx context.Context, req *basicDto.UploadFileRe
 	}
 
 	if err := global.Query.SysFile.WithContext(ctx).Create(fileEntity); err != nil {
-		global.Logger.Error("保存文件信息到数据库失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("保存文件信息到数据库失败", zap.Error(err))
 		return nil, fmt.Errorf("保存文件信息失败: %v", err)
 	}
 
-	global.Logger.Info("文件上传成功", zap.Int64("file_id", fileEntity.ID), zap.String("file_name", fileName))
+	global.Logger.WithContext(ctx).Info("文件上传成功", zap.Int64("file_id", fileEntity.ID), zap.String("file_name", fileName))
 
 	return &basicDto.UploadFileRes{
 		ID:           fileEntity.ID,
@@ -156,7 +158,7 @@ func (s *FileService) DownloadFile(ctx context.Context, req *basicDto.DownloadFi
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return "", "", errors.New("文件不存在")
 		}
-		global.Logger.Error("查询文件信息失败", zap.Int64("file_id", req.ID), zap.Error(err))
+		global.Logger.WithContext(ctx).Error("查询文件信息失败", zap.Int64("file_id", req.ID), zap.Error(err))
 		return "", "", fmt.Errorf("查询文件信息失败: %v", err)
 	}
 
@@ -185,17 +187,17 @@ func (s *FileService) DeleteFile(ctx context.Context, req *basicDto.DeleteFileRe
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("文件不存在")
 		}
-		global.Logger.Error("查询文件信息失败", zap.Int64("file_id", req.ID), zap.Error(err))
+		global.Logger.WithContext(ctx).Error("查询文件信息失败", zap.Int64("file_id", req.ID), zap.Error(err))
 		return fmt.Errorf("查询文件信息失败: %v", err)
 	}
 
 	// 软删除文件记录
 	if _, err := global.Query.SysFile.WithContext(ctx).Where(global.Query.SysFile.ID.Eq(req.ID)).Delete(); err != nil {
-		global.Logger.Error("删除文件记录失败", zap.Int64("file_id", req.ID), zap.Error(err))
+		global.Logger.WithContext(ctx).Error("删除文件记录失败", zap.Int64("file_id", req.ID), zap.Error(err))
 		return fmt.Errorf("删除文件失败: %v", err)
 	}
 
-	global.Logger.Info("文件删除成功", zap.Int64("file_id", req.ID))
+	global.Logger.WithContext(ctx).Info("文件删除成功", zap.Int64("file_id", req.ID))
 	return nil
 }
 
@@ -208,11 +210,11 @@ func (s *FileService) BatchDeleteFile(ctx context.Context, req *basicDto.BatchDe
 	// 批量软删除
 	result, err := global.Query.SysFile.WithContext(ctx).Where(global.Query.SysFile.ID.In(req.IDs...)).Delete()
 	if err != nil {
-		global.Logger.Error("批量删除文件失败", zap.Any("file_ids", req.IDs), zap.Error(err))
+		global.Logger.WithContext(ctx).Error("批量删除文件失败", zap.Any("file_ids", req.IDs), zap.Error(err))
 		return fmt.Errorf("批量删除文件失败: %v", err)
 	}
 
-	global.Logger.Info("批量删除文件成功", zap.Any("file_ids", req.IDs), zap.Int64("affected_rows", result.RowsAffected))
+	global.Logger.WithContext(ctx).Info("批量删除文件成功", zap.Any("file_ids", req.IDs), zap.Int64("affected_rows", result.RowsAffected))
 	return nil
 }
 
@@ -223,7 +225,7 @@ func (s *FileService) GetFile(ctx context.Context, req *basicDto.GetFileReq) (*b
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("文件不存在")
 		}
-		global.Logger.Error("查询文件详情失败", zap.Int64("file_id", req.ID), zap.Error(err))
+		global.Logger.WithContext(ctx).Error("查询文件详情失败", zap.Int64("file_id", req.ID), zap.Error(err))
 		return nil, fmt.Errorf("查询文件详情失败: %v", err)
 	}
 
@@ -245,137 +247,42 @@ func (s *FileService) GetFile(ctx context.Context, req *basicDto.GetFileReq) (*b
 	}, nil
 }
 
-// ListFile 获取文件列表
-func (s *FileService) ListFile(ctx context.Context, req *basicDto.ListFileReq) (*basicDto.ListFileRes, error) {
-	// 设置默认分页参数
-	if req.Page <= 0 {
-		req.Page = 1
-	}
-	if req.PageSize <= 0 {
-		req.PageSize = 20
-	}
-	if req.PageSize > 100 {
-		req.PageSize = 100
-	}
-
-	// 构建查询条件
-	query := global.Query.SysFile.WithContext(ctx)
-
-	// 文件名模糊查询
-	if req.Name != "" {
-		query = query.Where(global.Query.SysFile.Name.Like("%" + req.Name + "%")).Or(global.Query.SysFile.OriginalName.Like("%" + req.Name + "%"))
-	}
-
-	// 文件类型筛选
-	if req.FileType != "" {
-		query = query.Where(global.Query.SysFile.FileType.Eq(req.FileType))
-	}
-
-	// 文件扩展名筛选
-	if req.FileExt != "" {
-		query = query.Where(global.Query.SysFile.FileExt.Eq(req.FileExt))
-	}
-
-	// 存储类型筛选
-	if req.StorageType != nil {
-		query = query.Where(global.Query.SysFile.StorageType.Eq(*req.StorageType))
-	}
-
-	// 上传用户筛选
-	if req.UserID != nil {
-		query = query.Where(global.Query.SysFile.UploadUserID.Eq(*req.UserID))
-	}
-
-	// 状态筛选
-	if req.Status != nil {
-		query = query.Where(global.Query.SysFile.Status.Eq(*req.Status))
-	}
-
-	// 时间范围筛选
-	if req.StartTime != "" {
-		startTime, parseErr := time.Parse("2006-01-02", req.StartTime)
-		if parseErr == nil {
-			query = query.Where(global.Query.SysFile.CreatedAt.Gte(startTime))
-		}
-	}
-	if req.EndTime != "" {
-		endTime, parseErr := time.Parse("2006-01-02", req.EndTime)
-		if parseErr == nil {
-			query = query.Where(global.Query.SysFile.CreatedAt.Lte(endTime.Add(24 * time.Hour)))
-		}
+// fileListOptions 文件列表的排序白名单与默认排序
+func fileListOptions() listing.Options {
+	dao := global.Query.SysFile
+	return listing.Options{
+		Sorts:       []string{"created_at", "updated_at", "file_size"},
+		DefaultSort: []field.Expr{dao.CreatedAt.Desc()},
 	}
+}
 
-	// 统计总数
-	total, err := query.Count()
-	if err != nil {
-		global.Logger.Error("统计文件总数失败", zap.Error(err))
-		return nil, fmt.Errorf("统计文件总数失败: %v", err)
-	}
-
-	// 排序
-	orderBy := "created_at"
-	orderType := "desc"
-	if req.OrderBy != "" {
-		orderBy = req.OrderBy
-	}
-	if req.OrderType != "" && (req.OrderType == "asc" || req.OrderType == "desc") {
-		orderType = req.OrderType
-	}
-	if orderType == "desc" {
-		switch orderBy {
-		case "created_at":
-			query = query.Order(global.Query.SysFile.CreatedAt.Desc())
-		case "updated_at":
-			query = query.Order(global.Query.SysFile.UpdatedAt.Desc())
-		case "file_size":
-			query = query.Order(global.Query.SysFile.FileSize.Desc())
-		default:
-			query = query.Order(global.Query.SysFile.CreatedAt.Desc())
-		}
-	} else {
-		switch orderBy {
-		case "created_at":
-			query = query.Order(global.Query.SysFile.CreatedAt)
-		case "updated_at":
-			query = query.Order(global.Query.SysFile.UpdatedAt)
-		case "file_size":
-			query = query.Order(global.Query.SysFile.FileSize)
-		default:
-			query = query.Order(global.Query.SysFile.CreatedAt)
-		}
+// toListFileItem 转换为文件列表项
+func toListFileItem(file *entity.SysFile) *basicDto.ListFileItem {
+	return &basicDto.ListFileItem{
+		ID:           file.ID,
+		Name:         file.Name,
+		OriginalName: file.OriginalName,
+		FileURL:      file.FileURL,
+		FileSize:     file.FileSize,
+		FileType:     file.FileType,
+		FileExt:      file.FileExt,
+		StorageType:  file.StorageType,
+		UploadUserID: file.UploadUserID,
+		Status:       file.Status,
+		CreatedAt:    file.CreatedAt,
+		UpdatedAt:    file.UpdatedAt,
 	}
+}
 
-	// 分页查询
-	offset := (req.Page - 1) * req.PageSize
-	files, err := query.Offset(offset).Limit(req.PageSize).Find()
+// ListFile 获取文件列表
+func (s *FileService) ListFile(ctx context.Context, req *basicDto.ListFileReq) (*basicDto.ListFileRes, error) {
+	dao := global.Query.SysFile
+	res, err := listing.Find(dao.WithContext(ctx), &dao, req, fileListOptions(), toListFileItem)
 	if err != nil {
-		global.Logger.Error("查询文件列表失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("查询文件列表失败", zap.Any("req", req), zap.Error(err))
 		return nil, fmt.Errorf("查询文件列表失败: %v", err)
 	}
-
-	// 转换为响应格式
-	list := make([]basicDto.ListFileItem, len(files))
-	for i, file := range files {
-		list[i] = basicDto.ListFileItem{
-			ID:           file.ID,
-			Name:         file.Name,
-			OriginalName: file.OriginalName,
-			FileURL:      file.FileURL,
-			FileSize:     file.FileSize,
-			FileType:     file.FileType,
-			FileExt:      file.FileExt,
-			StorageType:  file.StorageType,
-			UploadUserID: file.UploadUserID,
-			Status:       file.Status,
-			CreatedAt:    file.CreatedAt,
-			UpdatedAt:    file.UpdatedAt,
-		}
-	}
-
-	return &basicDto.ListFileRes{
-		List:  list,
-		Total: total,
-	}, nil
+	return (*basicDto.ListFileRes)(res), nil
 }
 
 // UpdateFile 更新文件信息
@@ -400,7 +307,7 @@ func (s *FileService) UpdateFile(ctx context.Context, req *basicDto.UpdateFileRe
 	// 执行更新
 	result, err := global.Query.SysFile.WithContext(ctx).Where(global.Query.SysFile.ID.Eq(req.ID)).Updates(updateData)
 	if err != nil {
-		global.Logger.Error("更新文件信息失败", zap.Int64("file_id", req.ID), zap.Error(err))
+		global.Logger.WithContext(ctx).Error("更新文件信息失败", zap.Int64("file_id", req.ID), zap.Error(err))
 		return fmt.Errorf("更新文件信息失败: %v", err)
 	}
 
@@ -408,7 +315,7 @@ func (s *FileService) UpdateFile(ctx context.Context, req *basicDto.UpdateFileRe
 		return errors.New("文件不存在")
 	}
 
-	global.Logger.Info("更新文件信息成功", zap.Int64("file_id", req.ID))
+	global.Logger.WithContext(ctx).Info("更新文件信息成功", zap.Int64("file_id", req.ID))
 	return nil
 }
 
@@ -420,14 +327,14 @@ func (s *FileService) GetFileStatistics(ctx context.Context) (*basicDto.FileStat
 	// 总文件数
 	stats.TotalFiles, err = global.Query.SysFile.WithContext(ctx).Count()
 	if err != nil {
-		global.Logger.Error("统计总文件数失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("统计总文件数失败", zap.Error(err))
 		return nil, fmt.Errorf("统计总文件数失败: %v", err)
 	}
 
 	// 总文件大小
 	var totalSize sql.NullInt64
 	if err := global.DBClient.WithContext(ctx).Model(&entity.SysFile{}).Select("SUM(file_size)").Scan(&totalSize).Error; err != nil {
-		global.Logger.Error("统计总文件大小失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("统计总文件大小失败", zap.Error(err))
 		return nil, fmt.Errorf("统计总文件大小失败: %v", err)
 	}
 	if totalSize.Valid {
@@ -437,28 +344,28 @@ func (s *FileService) GetFileStatistics(ctx context.Context) (*basicDto.FileStat
 	// 本地存储文件数
 	stats.LocalFiles, err = global.Query.SysFile.WithContext(ctx).Where(global.Query.SysFile.StorageType.Eq(1)).Count()
 	if err != nil {
-		global.Logger.Error("统计本地文件数失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("统计本地文件数失败", zap.Error(err))
 		return nil, fmt.Errorf("统计本地文件数失败: %v", err)
 	}
 
 	// 云存储文件数
 	stats.CloudFiles, err = global.Query.SysFile.WithContext(ctx).Where(global.Query.SysFile.StorageType.Gt(1)).Count()
 	if err != nil {
-		global.Logger.Error("统计云存储文件数失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("统计云存储文件数失败", zap.Error(err))
 		return nil, fmt.Errorf("统计云存储文件数失败: %v", err)
 	}
 
 	// 正常状态文件数
 	stats.ActiveFiles, err = global.Query.SysFile.WithContext(ctx).Where(global.Query.SysFile.Status.Eq(1)).Count()
 	if err != nil {
-		global.Logger.Error("统计正常文件数失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("统计正常文件数失败", zap.Error(err))
 		return nil, fmt.Errorf("统计正常文件数失败: %v", err)
 	}
 
 	// 禁用状态文件数
 	stats.DisabledFiles, err = global.Query.SysFile.WithContext(ctx).Where(global.Query.SysFile.Status.Eq(2)).Count()
 	if err != nil {
-		global.Logger.Error("统计禁用文件数失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("统计禁用文件数失败", zap.Error(err))
 		return nil, fmt.Errorf("统计禁用文件数失败: %v", err)
 	}
 
@@ -633,7 +540,7 @@ func (s *FileService) MoveFile(ctx context.Context, fileID int64, targetStorageT
 		return fmt.Errorf("更新文件信息失败: %v", updateErr)
 	}
 
-	global.Logger.Info("文件移动成功", zap.Int64("file_id", fileID), zap.Int64("from_storage", *fileEntity.StorageType), zap.Int64("to_storage", targetStorageType))
+	global.Logger.WithContext(ctx).Info("文件移动成功", zap.Int64("file_id", fileID), zap.Int64("from_storage", *fileEntity.StorageType), zap.Int64("to_storage", targetStorageType))
 	return nil
 }
 
@@ -664,20 +571,20 @@ func (s *FileService) CleanupExpiredFiles(ctx context.Context, expireDays int) (
 	for _, file := range reallyExpiredFiles {
 		// 删除物理文件
 		if err := s.deletePhysicalFile(file.FilePath, *file.StorageType); err != nil {
-			global.Logger.Error("删除物理文件失败", zap.Int64("file_id", file.ID), zap.String("file_path", file.FilePath), zap.Error(err))
+			global.Logger.WithContext(ctx).Error("删除物理文件失败", zap.Int64("file_id", file.ID), zap.String("file_path", file.FilePath), zap.Error(err))
 			continue
 		}
 
 		// 物理删除数据库记录
 		if _, err := global.Query.SysFile.WithContext(ctx).Unscoped().Where(global.Query.SysFile.ID.Eq(file.ID)).Delete(); err != nil {
-			global.Logger.Error("物理删除文件记录失败", zap.Int64("file_id", file.ID), zap.Error(err))
+			global.Logger.WithContext(ctx).Error("物理删除文件记录失败", zap.Int64("file_id", file.ID), zap.Error(err))
 			continue
 		}
 
 		cleanedCount++
 	}
 
-	global.Logger.Info("清理过期文件完成", zap.Int("expire_days", expireDays), zap.Int64("cleaned_count", cleanedCount))
+	global.Logger.WithContext(ctx).Info("清理过期文件完成", zap.Int("expire_days", expireDays), zap.Int64("cleaned_count", cleanedCount))
 	return cleanedCount, nil
 }
 
@@ -720,131 +627,23 @@ func (s *FileService) UpdateFileStatus(ctx context.Context, fileID int64, status
 		return errors.New("文件不存在")
 	}
 
-	global.Logger.Info("更新文件状态成功", zap.Int64("file_id", fileID), zap.Int64("status", status))
+	global.Logger.WithContext(ctx).Info("更新文件状态成功", zap.Int64("file_id", fileID), zap.Int64("status", status))
 	return nil
 }
 
 // GetUserFiles 获取用户上传的文件列表
 func (s *FileService) GetUserFiles(ctx context.Context, userID int64, req *basicDto.ListFileReq) (*basicDto.ListFileRes, error) {
-	// 设置默认分页参数
-	if req.Page <= 0 {
-		req.Page = 1
-	}
-	if req.PageSize <= 0 {
-		req.PageSize = 20
-	}
-	if req.PageSize > 100 {
-		req.PageSize = 100
-	}
-
-	// 构建查询条件
-	query := global.Query.SysFile.WithContext(ctx).Where(global.Query.SysFile.UploadUserID.Eq(userID))
-
-	// 文件名模糊查询
-	if req.Name != "" {
-		query = query.Where(global.Query.SysFile.Name.Like("%" + req.Name + "%")).Or(global.Query.SysFile.OriginalName.Like("%" + req.Name + "%"))
-	}
+	// 只能查询自己的文件，忽略请求中的用户筛选
+	req.UserID = nil
 
-	// 文件类型筛选
-	if req.FileType != "" {
-		query = query.Where(global.Query.SysFile.FileType.Eq(req.FileType))
-	}
-
-	// 文件扩展名筛选
-	if req.FileExt != "" {
-		query = query.Where(global.Query.SysFile.FileExt.Eq(req.FileExt))
-	}
-
-	// 状态筛选
-	if req.Status != nil {
-		query = query.Where(global.Query.SysFile.Status.Eq(*req.Status))
-	}
-
-	// 时间范围筛选
-	if req.StartTime != "" {
-		startTime, parseStartErr := time.Parse("2006-01-02", req.StartTime)
-		if parseStartErr == nil {
-			query = query.Where(global.Query.SysFile.CreatedAt.Gte(startTime))
-		}
-	}
-	if req.EndTime != "" {
-		endTime, parseEndErr := time.Parse("2006-01-02", req.EndTime)
-		if parseEndErr == nil {
-			query = query.Where(global.Query.SysFile.CreatedAt.Lte(endTime.Add(24 * time.Hour)))
-		}
-	}
-
-	// 统计总数
-	total, err := query.Count()
+	dao := global.Query.SysFile
+	query := dao.WithContext(ctx).Where(dao.UploadUserID.Eq(userID))
+	res, err := listing.Find(query, &dao, req, fileListOptions(), toListFileItem)
 	if err != nil {
-		global.Logger.Error("统计用户文件总数失败", zap.Int64("user_id", userID), zap.Error(err))
-		return nil, fmt.Errorf("统计用户文件总数失败: %v", err)
-	}
-
-	// 排序
-	orderBy := "created_at"
-	orderType := "desc"
-	if req.OrderBy != "" {
-		orderBy = req.OrderBy
-	}
-	if req.OrderType != "" && (req.OrderType == "asc" || req.OrderType == "desc") {
-		orderType = req.OrderType
-	}
-	if orderType == "desc" {
-		switch orderBy {
-		case "created_at":
-			query = query.Order(global.Query.SysFile.CreatedAt.Desc())
-		case "updated_at":
-			query = query.Order(global.Query.SysFile.UpdatedAt.Desc())
-		case "file_size":
-			query = query.Order(global.Query.SysFile.FileSize.Desc())
-		default:
-			query = query.Order(global.Query.SysFile.CreatedAt.Desc())
-		}
-	} else {
-		switch orderBy {
-		case "created_at":
-			query = query.Order(global.Query.SysFile.CreatedAt)
-		case "updated_at":
-			query = query.Order(global.Query.SysFile.UpdatedAt)
-		case "file_size":
-			query = query.Order(global.Query.SysFile.FileSize)
-		default:
-			query = query.Order(global.Query.SysFile.CreatedAt)
-		}
-	}
-
-	// 分页查询
-	offset := (req.Page - 1) * req.PageSize
-	files, err := query.Offset(offset).Limit(req.PageSize).Find()
-	if err != nil {
-		global.Logger.Error("查询用户文件列表失败", zap.Int64("user_id", userID), zap.Error(err))
+		global.Logger.WithContext(ctx).Error("查询用户文件列表失败", zap.Int64("user_id", userID), zap.Error(err))
 		return nil, fmt.Errorf("查询用户文件列表失败: %v", err)
 	}
-
-	// 转换为响应格式
-	list := make([]basicDto.ListFileItem, len(files))
-	for i, file := range files {
-		list[i] = basicDto.ListFileItem{
-			ID:           file.ID,
-			Name:         file.Name,
-			OriginalName: file.OriginalName,
-			FileURL:      file.FileURL,
-			FileSize:     file.FileSize,
-			FileType:     file.FileType,
-			FileExt:      file.FileExt,
-			StorageType:  file.StorageType,
-			UploadUserID: file.UploadUserID,
-			Status:       file.Status,
-			CreatedAt:    file.CreatedAt,
-			UpdatedAt:    file.UpdatedAt,
-		}
-	}
-
-	return &basicDto.ListFileRes{
-		List:  list,
-		Total: total,
-	}, nil
+	return (*basicDto.ListFileRes)(res), nil
 }
 
 // 私有方法：计算文件MD5
@@ -14,6 +14,10 @@ type IBasicService interface {
 	OperationLog() IOperationLogService
 	// File 获取文件服务
 	File() IFileService
+	// AuditLog 获取数据变更审计服务
+	AuditLog() IAuditLogService
+	// SlowQuery 获取慢查询统计服务
+	SlowQuery() ISlowQueryService
 }
 
 // ILoginLogService 登录日志服务接口
@@ -28,6 +32,8 @@ type ILoginLogService interface {
 	ClearLoginLog(ctx context.Context, uid int64) error
 	// ListLoginLog 获取登录日志列表
 	ListLoginLog(ctx context.Context, req *basicDto.ListLoginLogReq) (*basicDto.ListLoginLogRes, error)
+	// CursorLoginLog 游标分页获取登录日志列表，不统计精确总数
+	CursorLoginLog(ctx context.Context, req *basicDto.CursorLoginLogReq) (*basicDto.CursorLoginLogRes, error)
 	// GetLoginLog 获取登录日志详情
 	GetLoginLog(ctx context.Context, req *models.IDReq) (*basicDto.LoginLogDetailRes, error)
 }
@@ -44,10 +50,26 @@ type IOperationLogService interface {
 	ClearOperationLog(ctx context.Context, uid int64) error
 	// ListOperationLog 获取操作日志列表
 	ListOperationLog(ctx context.Context, req *basicDto.ListOperationLogReq) (*basicDto.ListOperationLogRes, error)
+	// CursorOperationLog 游标分页获取操作日志列表，不统计精确总数
+	CursorOperationLog(ctx context.Context, req *basicDto.CursorOperationLogReq) (*basicDto.CursorOperationLogRes, error)
 	// GetOperationLog 获取操作日志详情
 	GetOperationLog(ctx context.Context, req *models.IDReq) (*basicDto.OperationLogDetailRes, error)
 }
 
+// IAuditLogService 数据变更审计服务接口
+type IAuditLogService interface {
+	// ListAuditLog 按实体与主键获取变更记录
+	ListAuditLog(ctx context.Context, req *basicDto.ListAuditLogReq) (*basicDto.ListAuditLogRes, error)
+}
+
+// ISlowQueryService 慢查询统计服务接口，统计为实例级别，多实例部署时各实例分别统计
+type ISlowQueryService interface {
+	// ListSlowQuery 按累计耗时等排序获取慢查询最多的SQL指纹
+	ListSlowQuery(ctx context.Context, req *basicDto.ListSlowQueryReq) (*basicDto.ListSlowQueryRes, error)
+	// ResetSlowQuery 清空慢查询统计
+	ResetSlowQuery(ctx context.Context) error
+}
+
 // IFileService 文件服务接口
 type IFileService interface {
 	// UploadFile 上传文件
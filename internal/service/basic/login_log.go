@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"sweet/internal/global"
+	"sweet/internal/listing"
 	"sweet/internal/models"
 	basicDto "sweet/internal/models/dto/basic"
 	"sweet/internal/models/entity"
+	"sweet/pkg/crypto"
 	"sweet/pkg/errs"
 	"sweet/pkg/utils"
 
@@ -17,12 +19,17 @@ import (
 type LoginLogService struct{}
 
 func (s *LoginLogService) CreateLoginLog(ctx context.Context, req *basicDto.CreateLoginLogReq) error {
+	ipIndex, err := ipBlindIndex(ctx, req.IP)
+	if err != nil {
+		return err
+	}
 	if err := global.Query.SysLoginLog.WithContext(ctx).Create(&entity.SysLoginLog{
 		UserID:     req.UserID,
 		Username:   req.Username,
 		LoginType:  req.LoginType,
 		ClientType: req.ClientType,
 		IP:         req.IP,
+		IPIndex:    ipIndex,
 		Location:   req.Location,
 		UserAgent:  req.UserAgent,
 		DeviceInfo: req.DeviceInfo,
@@ -31,7 +38,7 @@ func (s *LoginLogService) CreateLoginLog(ctx context.Context, req *basicDto.Crea
 		Status:     req.Status,
 		FailReason: req.FailReason,
 	}); err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"创建登录日志失败",
 			zap.Error(err),
 		)
@@ -48,7 +55,7 @@ func (s *LoginLogService) DeleteLoginLog(ctx context.Context, req *basicDto.Dele
 	}
 
 	if _, err := do.Where(dao.ID.In(req.Ids...)).Delete(); err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"删除登录日志失败",
 			zap.Error(err),
 		)
@@ -59,7 +66,7 @@ func (s *LoginLogService) DeleteLoginLog(ctx context.Context, req *basicDto.Dele
 
 func (s *LoginLogService) ClearLoginLog(ctx context.Context, uid int64) error {
 	if _, err := global.Query.SysLoginLog.WithContext(ctx).Where(global.Query.SysLoginLog.UserID.Eq(uid)).Delete(); err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"清空登录日志失败",
 			zap.Error(err),
 		)
@@ -70,7 +77,7 @@ func (s *LoginLogService) ClearLoginLog(ctx context.Context, uid int64) error {
 
 func (s *LoginLogService) ClearAllLoginLog(ctx context.Context) error {
 	if _, err := global.Query.SysLoginLog.WithContext(ctx).Delete(); err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"清空所有登录日志失败",
 			zap.Error(err),
 		)
@@ -103,6 +110,15 @@ func (s *LoginLogService) ListLoginLog(ctx context.Context, req *basicDto.ListLo
 		do = do.Where(dao.Status.Eq(req.Status))
 	}
 
+	// 登录IP条件，IP加密存储，使用盲索引匹配
+	index, err := ipBlindIndex(ctx, req.IP)
+	if err != nil {
+		return nil, err
+	}
+	if index != nil {
+		do = do.Where(dao.IPIndex.Eq(*index))
+	}
+
 	// 时间范围条件
 	if req.StartTime != 0 {
 		startTime := utils.UnixToTime(req.StartTime)
@@ -173,25 +189,14 @@ func (s *LoginLogService) ListLoginLog(ctx context.Context, req *basicDto.ListLo
 	offset := (req.Page - 1) * req.Size
 	loginLogs, total, err := do.FindByPage(offset, req.Size)
 	if err != nil {
-		global.Logger.Error("查询登录日志列表失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("查询登录日志列表失败", zap.Error(err))
 		return nil, errs.NewError(1000, "查询登录日志列表失败")
 	}
 
 	// 转换为响应格式
 	items := make([]*basicDto.ListLoginLogItem, 0, len(loginLogs))
 	for _, log := range loginLogs {
-		item := &basicDto.ListLoginLogItem{
-			ID:         log.ID,
-			UserID:     log.UserID,
-			Username:   log.Username,
-			LoginType:  log.LoginType,
-			ClientType: log.ClientType,
-			Browser:    log.Browser,
-			Os:         log.Os,
-			Status:     log.Status,
-			CreatedAt:  log.CreatedAt,
-		}
-		items = append(items, item)
+		items = append(items, toListLoginLogItem(log))
 	}
 
 	// 构建响应
@@ -203,6 +208,46 @@ func (s *LoginLogService) ListLoginLog(ctx context.Context, req *basicDto.ListLo
 	return res, nil
 }
 
+// CursorLoginLog 按 (created_at, id) 游标分页，避免大表的 OFFSET 与 COUNT
+func (s *LoginLogService) CursorLoginLog(ctx context.Context, req *basicDto.CursorLoginLogReq) (*basicDto.CursorLoginLogRes, error) {
+	dao := global.Query.SysLoginLog
+	do := dao.WithContext(ctx)
+
+	// 登录IP条件，IP加密存储，使用盲索引匹配
+	index, err := ipBlindIndex(ctx, req.IP)
+	if err != nil {
+		return nil, err
+	}
+	if index != nil {
+		do = do.Where(dao.IPIndex.Eq(*index))
+	}
+
+	res, err := listing.FindByCursor(do, &dao, "login_log", req, toListLoginLogItem)
+	if err != nil {
+		if errors.Is(err, errs.ErrCursorInvalid) {
+			return nil, err
+		}
+		global.Logger.WithContext(ctx).Error("游标查询登录日志列表失败", zap.Error(err))
+		return nil, errs.NewError(1000, "查询登录日志列表失败")
+	}
+	return (*basicDto.CursorLoginLogRes)(res), nil
+}
+
+// toListLoginLogItem 转换为登录日志列表项
+func toListLoginLogItem(log *entity.SysLoginLog) *basicDto.ListLoginLogItem {
+	return &basicDto.ListLoginLogItem{
+		ID:         log.ID,
+		UserID:     log.UserID,
+		Username:   log.Username,
+		LoginType:  log.LoginType,
+		ClientType: log.ClientType,
+		Browser:    log.Browser,
+		Os:         log.Os,
+		Status:     log.Status,
+		CreatedAt:  log.CreatedAt,
+	}
+}
+
 func (s *LoginLogService) GetLoginLog(ctx context.Context, req *models.IDReq) (*basicDto.LoginLogDetailRes, error) {
 	// 查询登录日志并预加载用户信息
 	dao := global.Query.SysLoginLog
@@ -212,10 +257,10 @@ func (s *LoginLogService) GetLoginLog(ctx context.Context, req *models.IDReq) (*
 	loginLog, err := do.Preload(dao.User).Where(dao.ID.Eq(req.ID)).First()
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			global.Logger.Error("查询登录日志失败, id: %d", zap.Int64("id", req.ID))
+			global.Logger.WithContext(ctx).Error("查询登录日志失败, id: %d", zap.Int64("id", req.ID))
 			return nil, errs.ErrNotFound
 		}
-		global.Logger.Error("查询登录日志失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("查询登录日志失败", zap.Error(err))
 		return nil, errs.ErrServer
 	}
 
@@ -252,3 +297,13 @@ func (s *LoginLogService) GetLoginLog(ctx context.Context, req *models.IDReq) (*
 func NewLoginLogService() ILoginLogService {
 	return &LoginLogService{}
 }
+
+// ipBlindIndex 计算IP的盲索引，字段加密未初始化时不能跳过IP条件
+func ipBlindIndex(ctx context.Context, ip string) (*string, error) {
+	index, err := crypto.BlindIndex(&ip)
+	if err != nil {
+		global.Logger.WithContext(ctx).Error("计算IP盲索引失败", zap.Error(err))
+		return nil, errs.ErrServer
+	}
+	return index, nil
+}
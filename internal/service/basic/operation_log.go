@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sweet/internal/global"
+	"sweet/internal/listing"
 	"sweet/internal/models"
 	basicDto "sweet/internal/models/dto/basic"
 	"sweet/internal/models/entity"
@@ -19,6 +20,10 @@ type OperationService struct{}
 
 // CreateOperationLog 创建操作日志
 func (s *OperationService) CreateOperationLog(ctx context.Context, req *basicDto.CreateOperationLogReq) error {
+	ipIndex, err := ipBlindIndex(ctx, req.IP)
+	if err != nil {
+		return err
+	}
 	if err := global.Query.SysOperationLog.WithContext(ctx).Create(&entity.SysOperationLog{
 		UserID:        req.UserID,
 		Username:      req.Username,
@@ -27,6 +32,7 @@ func (s *OperationService) CreateOperationLog(ctx context.Context, req *basicDto
 		Method:        req.Method,
 		URL:           req.URL,
 		IP:            req.IP,
+		IPIndex:       ipIndex,
 		Location:      req.Location,
 		UserAgent:     req.UserAgent,
 		RequestParams: req.Params,
@@ -35,7 +41,7 @@ func (s *OperationService) CreateOperationLog(ctx context.Context, req *basicDto
 		ErrorMsg:      req.ErrorMsg,
 		CostTime:      req.Duration,
 	}); err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"创建操作日志失败",
 			zap.Error(err),
 		)
@@ -53,7 +59,7 @@ func (s *OperationService) DeleteOperationLog(ctx context.Context, req *basicDto
 	}
 
 	if _, err := do.Where(dao.ID.In(req.Ids...)).Delete(); err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"删除操作日志失败",
 			zap.Error(err),
 		)
@@ -65,7 +71,7 @@ func (s *OperationService) DeleteOperationLog(ctx context.Context, req *basicDto
 // ClearAllOperationLog 清空所有操作日志
 func (s *OperationService) ClearAllOperationLog(ctx context.Context) error {
 	if _, err := global.Query.SysOperationLog.WithContext(ctx).Delete(); err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"清空所有操作日志失败",
 			zap.Error(err),
 		)
@@ -77,7 +83,7 @@ func (s *OperationService) ClearAllOperationLog(ctx context.Context) error {
 // ClearOperationLog 清空指定用户的操作日志
 func (s *OperationService) ClearOperationLog(ctx context.Context, uid int64) error {
 	if _, err := global.Query.SysOperationLog.WithContext(ctx).Where(global.Query.SysOperationLog.UserID.Eq(uid)).Delete(); err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"清空操作日志失败",
 			zap.Error(err),
 		)
@@ -116,6 +122,15 @@ func (s *OperationService) ListOperationLog(ctx context.Context, req *basicDto.L
 		do = do.Where(dao.Status.Eq(req.Status))
 	}
 
+	// 操作IP条件，IP加密存储，使用盲索引匹配
+	index, err := ipBlindIndex(ctx, req.IP)
+	if err != nil {
+		return nil, err
+	}
+	if index != nil {
+		do = do.Where(dao.IPIndex.Eq(*index))
+	}
+
 	// 时间范围条件
 	if req.StartTime != 0 {
 		startTime := utils.UnixToTime(req.StartTime)
@@ -192,27 +207,14 @@ func (s *OperationService) ListOperationLog(ctx context.Context, req *basicDto.L
 	offset := (req.Page - 1) * req.Size
 	operationLogs, total, err := do.FindByPage(offset, req.Size)
 	if err != nil {
-		global.Logger.Error("查询操作日志列表失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("查询操作日志列表失败", zap.Error(err))
 		return nil, errs.NewError(1000, "查询操作日志列表失败")
 	}
 
 	// 转换为响应格式
 	items := make([]*basicDto.ListOperationLogItem, 0, len(operationLogs))
 	for _, log := range operationLogs {
-		item := &basicDto.ListOperationLogItem{
-			ID:        log.ID,
-			UserID:    log.UserID,
-			Username:  log.Username,
-			Module:    log.Module,
-			Operation: log.Operation,
-			Method:    log.Method,
-			URL:       log.URL,
-			IP:        log.IP,
-			Status:    log.Status,
-			Duration:  log.CostTime,
-			CreatedAt: log.CreatedAt,
-		}
-		items = append(items, item)
+		items = append(items, toListOperationLogItem(log))
 	}
 
 	// 构建响应
@@ -224,6 +226,48 @@ func (s *OperationService) ListOperationLog(ctx context.Context, req *basicDto.L
 	return res, nil
 }
 
+// CursorOperationLog 按 (created_at, id) 游标分页，避免大表的 OFFSET 与 COUNT
+func (s *OperationService) CursorOperationLog(ctx context.Context, req *basicDto.CursorOperationLogReq) (*basicDto.CursorOperationLogRes, error) {
+	dao := global.Query.SysOperationLog
+	do := dao.WithContext(ctx)
+
+	// 操作IP条件，IP加密存储，使用盲索引匹配
+	index, err := ipBlindIndex(ctx, req.IP)
+	if err != nil {
+		return nil, err
+	}
+	if index != nil {
+		do = do.Where(dao.IPIndex.Eq(*index))
+	}
+
+	res, err := listing.FindByCursor(do, &dao, "operation_log", req, toListOperationLogItem)
+	if err != nil {
+		if errors.Is(err, errs.ErrCursorInvalid) {
+			return nil, err
+		}
+		global.Logger.WithContext(ctx).Error("游标查询操作日志列表失败", zap.Error(err))
+		return nil, errs.NewError(1000, "查询操作日志列表失败")
+	}
+	return (*basicDto.CursorOperationLogRes)(res), nil
+}
+
+// toListOperationLogItem 转换为操作日志列表项
+func toListOperationLogItem(log *entity.SysOperationLog) *basicDto.ListOperationLogItem {
+	return &basicDto.ListOperationLogItem{
+		ID:        log.ID,
+		UserID:    log.UserID,
+		Username:  log.Username,
+		Module:    log.Module,
+		Operation: log.Operation,
+		Method:    log.Method,
+		URL:       log.URL,
+		IP:        log.IP,
+		Status:    log.Status,
+		Duration:  log.CostTime,
+		CreatedAt: log.CreatedAt,
+	}
+}
+
 // GetOperationLog 获取操作日志详情
 func (s *OperationService) GetOperationLog(ctx context.Context, req *models.IDReq) (*basicDto.OperationLogDetailRes, error) {
 	// 查询操作日志并预加载用户信息
@@ -234,10 +278,10 @@ func (s *OperationService) GetOperationLog(ctx context.Context, req *models.IDRe
 	operationLog, err := do.Preload(dao.User).Where(dao.ID.Eq(req.ID)).First()
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			global.Logger.Error("查询操作日志失败, id: %d", zap.Int64("id", req.ID))
+			global.Logger.WithContext(ctx).Error("查询操作日志失败, id: %d", zap.Int64("id", req.ID))
 			return nil, errs.ErrNotFound
 		}
-		global.Logger.Error("查询操作日志失败", zap.Error(err))
+		global.Logger.WithContext(ctx).Error("查询操作日志失败", zap.Error(err))
 		return nil, errs.ErrServer
 	}
 
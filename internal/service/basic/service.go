@@ -10,6 +10,10 @@ type Service struct {
 	operation IOperationLogService
 	// file 文件服务
 	file IFileService
+	// auditLog 数据变更审计服务
+	auditLog IAuditLogService
+	// slowQuery 慢查询统计服务
+	slowQuery ISlowQueryService
 }
 
 var (
@@ -23,6 +27,8 @@ func NewService() IBasicService {
 		s.loginLog = NewLoginLogService()
 		s.operation = NewOperationLogService()
 		s.file = NewFileService()
+		s.auditLog = NewAuditLogService()
+		s.slowQuery = NewSlowQueryService()
 	})
 	return s
 }
@@ -41,3 +47,13 @@ func (s *Service) OperationLog() IOperationLogService {
 func (s *Service) File() IFileService {
 	return s.file
 }
+
+// AuditLog 获取数据变更审计服务
+func (s *Service) AuditLog() IAuditLogService {
+	return s.auditLog
+}
+
+// SlowQuery 获取慢查询统计服务
+func (s *Service) SlowQuery() ISlowQueryService {
+	return s.slowQuery
+}
@@ -0,0 +1,69 @@
+package basic
+
+import (
+	"context"
+	"sweet/internal/global"
+	basicDto "sweet/internal/models/dto/basic"
+	"sweet/pkg/auth"
+	"sweet/pkg/errs"
+)
+
+// defaultSlowQueryLimit 默认返回的SQL指纹数
+const defaultSlowQueryLimit = 20
+
+// SlowQueryService 慢查询统计服务实现
+type SlowQueryService struct{}
+
+// ListSlowQuery 获取本实例的慢查询统计，由 database.CustomLogger 按SQL指纹聚合
+// 统计不区分租户，示例SQL含绑定的参数值（密码哈希、盲索引及其他租户的数据等），仅超级租户可查看
+func (s *SlowQueryService) ListSlowQuery(ctx context.Context, req *basicDto.ListSlowQueryReq) (*basicDto.ListSlowQueryRes, error) {
+	if err := requireSuperTenant(ctx); err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSlowQueryLimit
+	}
+
+	stats := global.DBClient.SlowQueryStats(limit, req.Sort)
+	list := make([]*basicDto.SlowQueryItem, 0, len(stats))
+	for _, stat := range stats {
+		list = append(list, &basicDto.SlowQueryItem{
+			Fingerprint: stat.Fingerprint,
+			Example:     stat.Example,
+			Count:       stat.Count,
+			Errors:      stat.Errors,
+			Rows:        stat.Rows,
+			TotalMs:     stat.TotalMs,
+			AvgMs:       stat.AvgMs,
+			P50Ms:       stat.P50Ms,
+			P99Ms:       stat.P99Ms,
+			MaxMs:       stat.MaxMs,
+			FirstSeen:   stat.FirstSeen,
+			LastSeen:    stat.LastSeen,
+		})
+	}
+	return &basicDto.ListSlowQueryRes{List: list}, nil
+}
+
+// ResetSlowQuery 清空本实例的慢查询统计
+func (s *SlowQueryService) ResetSlowQuery(ctx context.Context) error {
+	if err := requireSuperTenant(ctx); err != nil {
+		return err
+	}
+	global.DBClient.ResetSlowQueryStats()
+	return nil
+}
+
+// requireSuperTenant 校验调用方为超级租户
+func requireSuperTenant(ctx context.Context) error {
+	if p, ok := auth.PrincipalFrom(ctx); ok && p.IsSuperTenant() {
+		return nil
+	}
+	return errs.ErrTenantForbidden
+}
+
+// NewSlowQueryService 创建慢查询统计服务
+func NewSlowQueryService() ISlowQueryService {
+	return &SlowQueryService{}
+}
@@ -0,0 +1,469 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"sweet/internal/global"
+	systemDTO "sweet/internal/models/dto/system"
+	"sweet/internal/models/entity"
+	"sweet/internal/models/query"
+	"sweet/pkg/auth"
+	"sweet/pkg/errs"
+	"sweet/pkg/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// ApiKeyOwnerUser 归属用户
+	ApiKeyOwnerUser int64 = 1
+	// ApiKeyOwnerService 归属服务
+	ApiKeyOwnerService int64 = 2
+
+	// apiKeyUsageInterval 最后使用时间的最小刷新间隔，避免每次请求都写库
+	apiKeyUsageInterval = time.Minute
+)
+
+type ApiKeyService struct{}
+
+func NewApiKeyService() IApiKeyService {
+	return &ApiKeyService{}
+}
+
+func (s *ApiKeyService) CreateApiKey(ctx context.Context, req *systemDTO.CreateApiKeyReq) (*systemDTO.CreateApiKeyRes, error) {
+	apiKey := &entity.SysApiKey{
+		Name:      req.Name,
+		OwnerType: utils.Ptr(req.OwnerType),
+		Status:    utils.Ptr(int64(1)),
+		Remark:    req.Remark,
+	}
+	// 创建者由数据库操作人插件根据上下文中的认证主体填充
+	operatorID, _ := auth.UidFrom(ctx)
+	owner, err := s.resolveOwner(ctx, operatorID, req.OwnerType, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.OwnerType {
+	case ApiKeyOwnerUser:
+		apiKey.UserID = utils.Ptr(owner.ID)
+	case ApiKeyOwnerService:
+		if req.ServiceName == nil || *req.ServiceName == "" {
+			return nil, errs.ErrParams
+		}
+		apiKey.ServiceName = req.ServiceName
+	}
+
+	if req.ExpiresAt != nil {
+		expiresAt := time.Unix(*req.ExpiresAt, 0)
+		if !expiresAt.After(time.Now()) {
+			return nil, errs.ErrParams
+		}
+		apiKey.ExpiresAt = &expiresAt
+	}
+
+	key := auth.GenerateAPIKey()
+	apiKey.Prefix = key.Prefix
+	apiKey.SecretHash = key.SecretHash
+
+	err = global.Query.Transaction(func(tx *query.Query) error {
+		if err := s.checkApiIds(ctx, tx, req.ApiIds); err != nil {
+			return err
+		}
+		if err := s.checkGrant(ctx, tx, owner, req.ApiIds); err != nil {
+			return err
+		}
+
+		if err := tx.SysApiKey.WithContext(ctx).Create(apiKey); err != nil {
+			global.Logger.WithContext(ctx).Error(
+				"创建API密钥失败",
+				zap.String("name", req.Name),
+				zap.Error(err),
+			)
+			return errs.ErrServer
+		}
+
+		return s.saveScopes(ctx, tx, apiKey.ID, req.ApiIds)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	global.Logger.WithContext(ctx).Info(
+		"创建API密钥成功",
+		zap.Int64("id", apiKey.ID),
+		zap.String("prefix", apiKey.Prefix),
+		zap.Int64("create_by", operatorID),
+	)
+
+	return &systemDTO.CreateApiKeyRes{
+		ID:     apiKey.ID,
+		Key:    key.Key,
+		Prefix: key.Prefix,
+	}, nil
+}
+
+func (s *ApiKeyService) RevokeApiKey(ctx context.Context, req *systemDTO.RevokeApiKeyReq) error {
+	dao := global.Query.SysApiKey
+
+	_, err := dao.WithContext(ctx).
+		Where(dao.ID.In(req.Ids...), dao.Status.Eq(1)).
+		UpdateSimple(dao.Status.Value(2), dao.RevokedAt.Value(time.Now()))
+	if err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"吊销API密钥失败",
+			zap.Int64s("ids", req.Ids),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+
+	global.Logger.WithContext(ctx).Info("吊销API密钥成功", zap.Int64s("ids", req.Ids))
+	return nil
+}
+
+func (s *ApiKeyService) AssignApiKeyApiIds(ctx context.Context, req *systemDTO.AssignApiKeyApiIdsReq) error {
+	return global.Query.Transaction(func(tx *query.Query) error {
+		dao := tx.SysApiKey
+		apiKey, err := dao.WithContext(ctx).Where(dao.ID.Eq(req.ID)).First()
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errs.ErrApiKeyNotFound
+			}
+			global.Logger.WithContext(ctx).Error(
+				"查询API密钥失败",
+				zap.Int64("id", req.ID),
+				zap.Error(err),
+			)
+			return errs.ErrServer
+		}
+
+		operatorID, _ := auth.UidFrom(ctx)
+		owner, err := s.resolveOwner(ctx, operatorID, utils.Deref(apiKey.OwnerType), apiKey.UserID)
+		if err != nil {
+			return err
+		}
+		if err := s.checkApiIds(ctx, tx, req.ApiIds); err != nil {
+			return err
+		}
+		if err := s.checkGrant(ctx, tx, owner, req.ApiIds); err != nil {
+			return err
+		}
+
+		scope := tx.SysApiKeyScope
+		if _, err := scope.WithContext(ctx).Where(scope.KeyID.Eq(req.ID)).Delete(); err != nil {
+			global.Logger.WithContext(ctx).Error(
+				"清除API密钥授权范围失败",
+				zap.Int64("id", req.ID),
+				zap.Error(err),
+			)
+			return errs.ErrServer
+		}
+
+		return s.saveScopes(ctx, tx, req.ID, req.ApiIds)
+	})
+}
+
+func (s *ApiKeyService) ListApiKey(ctx context.Context, req *systemDTO.ApiKeyListReq) (*systemDTO.ApiKeyListRes, error) {
+	dao := global.Query.SysApiKey
+	query := dao.WithContext(ctx)
+
+	// 条件查询
+	if req.Name != "" {
+		query = query.Where(dao.Name.Like("%" + req.Name + "%"))
+	}
+	if req.Prefix != "" {
+		query = query.Where(dao.Prefix.Eq(req.Prefix))
+	}
+	if req.OwnerType != nil {
+		query = query.Where(dao.OwnerType.Eq(*req.OwnerType))
+	}
+	if req.UserID != nil {
+		query = query.Where(dao.UserID.Eq(*req.UserID))
+	}
+	if req.Status != nil {
+		query = query.Where(dao.Status.Eq(*req.Status))
+	}
+	// 时间范围查询
+	if req.StartTime > 0 {
+		query = query.Where(dao.CreatedAt.Gte(time.Unix(req.StartTime, 0)))
+	}
+	if req.EndTime > 0 {
+		query = query.Where(dao.CreatedAt.Lte(time.Unix(req.EndTime, 0)))
+	}
+	query = query.Order(dao.CreatedAt.Desc())
+
+	// 分页参数验证
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Size <= 0 {
+		req.Size = 10
+	}
+	if req.Size > 100 {
+		req.Size = 100
+	}
+
+	offset := (req.Page - 1) * req.Size
+	keys, total, err := query.FindByPage(offset, req.Size)
+	if err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"查询API密钥列表失败",
+			zap.Any("req", req),
+			zap.Error(err),
+		)
+		return nil, errs.ErrServer
+	}
+
+	// 批量查询授权范围
+	ids := make([]int64, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, key.ID)
+	}
+	apiIds := make(map[int64][]int64, len(keys))
+	if len(ids) > 0 {
+		scope := global.Query.SysApiKeyScope
+		scopes, err := scope.WithContext(ctx).Where(scope.KeyID.In(ids...)).Find()
+		if err != nil {
+			global.Logger.WithContext(ctx).Error(
+				"查询API密钥授权范围失败",
+				zap.Int64s("ids", ids),
+				zap.Error(err),
+			)
+			return nil, errs.ErrServer
+		}
+		for _, item := range scopes {
+			apiIds[item.KeyID] = append(apiIds[item.KeyID], item.APIID)
+		}
+	}
+
+	// 转换为DTO，不返回密钥哈希
+	list := make([]*systemDTO.ApiKeyListItem, 0, len(keys))
+	for _, key := range keys {
+		list = append(list, &systemDTO.ApiKeyListItem{
+			ID:          key.ID,
+			Name:        key.Name,
+			Prefix:      key.Prefix,
+			OwnerType:   key.OwnerType,
+			UserID:      key.UserID,
+			ServiceName: key.ServiceName,
+			Status:      key.Status,
+			ApiIds:      apiIds[key.ID],
+			ExpiresAt:   key.ExpiresAt,
+			LastUsedAt:  key.LastUsedAt,
+			LastUsedIP:  key.LastUsedIP,
+			RevokedAt:   key.RevokedAt,
+			Remark:      key.Remark,
+			CreatedAt:   key.CreatedAt,
+		})
+	}
+
+	return &systemDTO.ApiKeyListRes{
+		List:  list,
+		Total: total,
+	}, nil
+}
+
+func (s *ApiKeyService) Authenticate(ctx context.Context, req *systemDTO.ApiKeyAuthReq) (*systemDTO.ApiKeyPrincipal, error) {
+	prefix, secret, err := auth.ParseAPIKey(req.Key)
+	if err != nil {
+		return nil, errs.ErrApiKeyInvalid
+	}
+
+	dao := global.Query.SysApiKey
+	apiKey, err := dao.WithContext(ctx).Where(dao.Prefix.Eq(prefix)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrApiKeyInvalid
+		}
+		global.Logger.WithContext(ctx).Error(
+			"查询API密钥失败",
+			zap.String("prefix", prefix),
+			zap.Error(err),
+		)
+		return nil, errs.ErrServer
+	}
+
+	if !auth.VerifyAPIKeySecret(secret, apiKey.SecretHash) {
+		return nil, errs.ErrApiKeyInvalid
+	}
+	if utils.Deref(apiKey.Status) != 1 {
+		return nil, errs.ErrApiKeyRevoked
+	}
+	if apiKey.ExpiresAt != nil && apiKey.ExpiresAt.Before(time.Now()) {
+		return nil, errs.ErrApiKeyExpired
+	}
+
+	// 校验授权范围
+	api := global.Query.SysApi
+	scope := global.Query.SysApiKeyScope
+	target, err := api.WithContext(ctx).
+		Join(scope, scope.APIID.EqCol(api.ID)).
+		Where(
+			scope.KeyID.Eq(apiKey.ID),
+			api.Method.Eq(req.Method),
+			api.Path.Eq(req.Path),
+			api.Status.Eq(1),
+		).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrApiKeyScope
+		}
+		global.Logger.WithContext(ctx).Error(
+			"查询API密钥授权范围失败",
+			zap.Int64("id", apiKey.ID),
+			zap.Error(err),
+		)
+		return nil, errs.ErrServer
+	}
+
+	principal := &systemDTO.ApiKeyPrincipal{
+		KeyID:       apiKey.ID,
+		TenantID:    apiKey.TenantID,
+		OwnerType:   utils.Deref(apiKey.OwnerType),
+		ServiceName: utils.Deref(apiKey.ServiceName),
+		Username:    utils.Deref(apiKey.ServiceName),
+	}
+	if principal.OwnerType == ApiKeyOwnerUser {
+		user, err := loadUserWithRole(ctx, utils.Deref(apiKey.UserID))
+		if err != nil {
+			if errors.Is(err, errs.ErrUserNotFound) {
+				return nil, errs.ErrApiKeyInvalid
+			}
+			return nil, err
+		}
+		// 归属用户被禁用时密钥随之失效
+		if user.Status != nil && *user.Status != 1 {
+			return nil, errs.ErrUserDisabled
+		}
+		// 归属用户的角色权限被收回后，密钥同样不能访问该接口
+		if err := s.checkGrant(ctx, global.Query, user, []int64{target.ID}); err != nil {
+			if errors.Is(err, errs.ErrApiKeyGrant) {
+				return nil, errs.ErrApiKeyScope
+			}
+			return nil, err
+		}
+		principal.Uid = user.ID
+		principal.Username = user.Username
+		principal.RoleID = utils.Deref(user.RoleID)
+	}
+
+	s.touch(ctx, apiKey, req.IP)
+	return principal, nil
+}
+
+// touch 记录密钥最后使用信息，失败不影响本次请求
+func (s *ApiKeyService) touch(ctx context.Context, apiKey *entity.SysApiKey, ip string) {
+	now := time.Now()
+	if apiKey.LastUsedAt != nil && now.Sub(*apiKey.LastUsedAt) < apiKeyUsageInterval && utils.Deref(apiKey.LastUsedIP) == ip {
+		return
+	}
+
+	dao := global.Query.SysApiKey
+	if _, err := dao.WithContext(ctx).Where(dao.ID.Eq(apiKey.ID)).
+		UpdateSimple(dao.LastUsedAt.Value(now), dao.LastUsedIP.Value(ip)); err != nil {
+		global.Logger.WithContext(ctx).Warn(
+			"更新API密钥使用记录失败",
+			zap.Int64("id", apiKey.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// checkApiIds 校验ApiID均存在
+func (s *ApiKeyService) checkApiIds(ctx context.Context, tx *query.Query, apiIds []int64) error {
+	api := tx.SysApi
+	count, err := api.WithContext(ctx).Where(api.ID.In(apiIds...)).Count()
+	if err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"查询API列表失败",
+			zap.Int64s("api_ids", apiIds),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+	if int(count) != len(uniqueIds(apiIds)) {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// resolveOwner 校验操作人可以管理该归属的密钥，返回归属用户，服务密钥返回nil
+// 普通用户只能管理自己的密钥，为其他用户或服务管理密钥需要超级管理员
+func (s *ApiKeyService) resolveOwner(ctx context.Context, operatorID, ownerType int64, userID *int64) (*entity.SysUser, error) {
+	operator, err := loadUserWithRole(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+	if ownerType == ApiKeyOwnerUser && (userID == nil || *userID == operator.ID) {
+		return operator, nil
+	}
+	if !isSuperUser(operator) {
+		global.Logger.WithContext(ctx).Warn(
+			"非超级管理员尝试管理其他归属的API密钥",
+			zap.Int64("operator_id", operator.ID),
+			zap.Int64("owner_type", ownerType),
+			zap.Int64("user_id", utils.Deref(userID)),
+		)
+		return nil, errs.ErrApiKeyOwner
+	}
+	if ownerType == ApiKeyOwnerService {
+		return nil, nil
+	}
+	return loadUserWithRole(ctx, *userID)
+}
+
+// checkGrant 校验授权范围不超出归属用户的角色权限，服务密钥与超级管理员不限制
+func (s *ApiKeyService) checkGrant(ctx context.Context, tx *query.Query, owner *entity.SysUser, apiIds []int64) error {
+	if owner == nil || isSuperUser(owner) {
+		return nil
+	}
+	if owner.Role == nil || utils.Deref(owner.Role.Status) != 1 {
+		return errs.ErrApiKeyGrant
+	}
+
+	apiIds = uniqueIds(apiIds)
+	dao := tx.SysRoleApi
+	count, err := dao.WithContext(ctx).Where(dao.RoleID.Eq(owner.Role.ID), dao.APIID.In(apiIds...)).Count()
+	if err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"查询角色API关联失败",
+			zap.Int64("role_id", owner.Role.ID),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+	if int(count) != len(apiIds) {
+		return errs.ErrApiKeyGrant
+	}
+	return nil
+}
+
+// saveScopes 写入密钥授权范围
+func (s *ApiKeyService) saveScopes(ctx context.Context, tx *query.Query, keyID int64, apiIds []int64) error {
+	apiIds = uniqueIds(apiIds)
+	scopes := make([]*entity.SysApiKeyScope, 0, len(apiIds))
+	for _, apiID := range apiIds {
+		scopes = append(scopes, &entity.SysApiKeyScope{KeyID: keyID, APIID: apiID})
+	}
+	if err := tx.SysApiKeyScope.WithContext(ctx).CreateInBatches(scopes, 100); err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"写入API密钥授权范围失败",
+			zap.Int64("id", keyID),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+	return nil
+}
+
+// uniqueIds 去重ID列表
+func uniqueIds(ids []int64) []int64 {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
@@ -0,0 +1,664 @@
+package system
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sweet/internal/global"
+	systemDTO "sweet/internal/models/dto/system"
+	"sweet/internal/models/entity"
+	"sweet/internal/models/query"
+	"sweet/pkg/auth"
+	"sweet/pkg/crypto"
+	"sweet/pkg/database"
+	"sweet/pkg/errs"
+	"sweet/pkg/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type AuthService struct{}
+
+func NewAuthService() IAuthService {
+	return &AuthService{}
+}
+
+func (s *AuthService) OIDCAuthURL(ctx context.Context, req *systemDTO.OIDCAuthURLReq) (*systemDTO.OIDCAuthURLRes, error) {
+	provider, err := auth.GetOIDCProvider(req.Provider)
+	if err != nil {
+		return nil, errs.ErrOAuthProvider
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx)
+	if err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"生成第三方授权地址失败",
+			zap.String("provider", req.Provider),
+			zap.Error(err),
+		)
+		return nil, errs.ErrServer
+	}
+
+	return &systemDTO.OIDCAuthURLRes{URL: authURL}, nil
+}
+
+func (s *AuthService) OIDCLogin(ctx context.Context, req *systemDTO.OIDCLoginReq) (*systemDTO.LoginRes, error) {
+	// 保留的设备类型在兑换授权码前拒绝，避免消耗一次性的 state
+	if auth.IsReservedDevice(req.DeviceType) {
+		return nil, errs.ErrParams
+	}
+	provider, identity, err := s.exchange(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *entity.SysUser
+	err = global.Query.Transaction(func(tx *query.Query) error {
+		dao := tx.SysUserOauth
+
+		binding, err := dao.WithContext(ctx).Where(
+			dao.Provider.Eq(identity.Provider),
+			dao.Subject.Eq(identity.Subject),
+		).First()
+		if err == nil {
+			// 已绑定，加载系统账号并刷新登录时间
+			if user, err = tx.SysUser.WithContext(ctx).Where(tx.SysUser.ID.Eq(binding.UserID)).First(); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return errs.ErrUserNotFound
+				}
+				global.Logger.WithContext(ctx).Error(
+					"查询绑定用户失败",
+					zap.Int64("user_id", binding.UserID),
+					zap.Error(err),
+				)
+				return errs.ErrServer
+			}
+			if _, err = dao.WithContext(ctx).Where(dao.ID.Eq(binding.ID)).Update(dao.LastLoginAt, time.Now()); err != nil {
+				global.Logger.WithContext(ctx).Error(
+					"更新第三方登录时间失败",
+					zap.Int64("binding_id", binding.ID),
+					zap.Error(err),
+				)
+				return errs.ErrServer
+			}
+			return nil
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Logger.WithContext(ctx).Error(
+				"查询第三方账号绑定失败",
+				zap.String("provider", identity.Provider),
+				zap.String("subject", identity.Subject),
+				zap.Error(err),
+			)
+			return errs.ErrServer
+		}
+
+		// 未绑定且未开启自动开户
+		provision := provider.Config().Provision
+		if !provision.Enabled {
+			return errs.ErrOAuthNotBound
+		}
+
+		// 自动开户的账号归属配置的租户，用户名、邮箱也在该租户内判断重复
+		tenantID := provision.TenantID
+		if tenantID == 0 {
+			tenantID = auth.SuperTenantID
+		}
+		ctx := database.WithTenant(ctx, tenantID)
+		if user, err = s.provisionUser(ctx, tx, &provision, identity); err != nil {
+			return err
+		}
+		return s.createBinding(ctx, tx, user.ID, identity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueToken(ctx, user, req.DeviceType)
+}
+
+func (s *AuthService) PasswordKey(ctx context.Context) (*systemDTO.PasswordKeyRes, error) {
+	envelope, err := crypto.GetEnvelope()
+	if err != nil {
+		global.Logger.WithContext(ctx).Error("获取密码加密公钥失败", zap.Error(err))
+		return nil, errs.ErrServer
+	}
+
+	pub := envelope.PublicKey()
+	return &systemDTO.PasswordKeyRes{
+		KeyID:     pub.KeyID,
+		Algorithm: pub.Algorithm,
+		PublicKey: pub.PublicKey,
+	}, nil
+}
+
+func (s *AuthService) Login(ctx context.Context, req *systemDTO.LoginReq) (*systemDTO.LoginRes, error) {
+	if auth.IsReservedDevice(req.DeviceType) {
+		return nil, errs.ErrParams
+	}
+	password, err := s.openPassword(ctx, req.KeyID, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	// 用户名在租户内唯一，按登录的租户查找账号
+	tenant, err := activeTenant(ctx, req.Tenant)
+	if err != nil {
+		return nil, err
+	}
+	ctx = database.WithTenant(ctx, tenant.ID)
+
+	dao := global.Query.SysUser
+	user, err := dao.WithContext(ctx).Where(dao.Username.Eq(req.Username)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 不区分账号不存在与密码错误，避免枚举账号
+			return nil, errs.ErrPassword
+		}
+		global.Logger.WithContext(ctx).Error(
+			"查询用户失败",
+			zap.String("username", req.Username),
+			zap.Error(err),
+		)
+		return nil, errs.ErrServer
+	}
+	if !checkPassword(user, password) {
+		return nil, errs.ErrPassword
+	}
+
+	return s.issueToken(ctx, user, req.DeviceType)
+}
+
+func (s *AuthService) ChangePassword(ctx context.Context, uid int64, req *systemDTO.ChangePasswordReq) error {
+	oldPassword, err := s.openPassword(ctx, req.KeyID, req.OldPassword)
+	if err != nil {
+		return err
+	}
+	newPassword, err := s.openPassword(ctx, req.KeyID, req.NewPassword)
+	if err != nil {
+		return err
+	}
+	if len(newPassword) < 6 || len(newPassword) > 15 {
+		return errs.ErrPasswordLength
+	}
+	if newPassword == oldPassword {
+		return errs.ErrPasswordSame
+	}
+
+	dao := global.Query.SysUser
+	user, err := dao.WithContext(ctx).Where(dao.ID.Eq(uid)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrUserNotFound
+		}
+		global.Logger.WithContext(ctx).Error(
+			"查询用户失败",
+			zap.Int64("uid", uid),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+	if !checkPassword(user, oldPassword) {
+		return errs.ErrPassword
+	}
+
+	salt := crypto.Salt()
+	if _, err := dao.WithContext(ctx).Where(dao.ID.Eq(uid)).UpdateSimple(
+		dao.Password.Value(crypto.MD5(newPassword+salt)),
+		dao.Salt.Value(salt),
+		dao.PasswordReset.Value(2),
+	); err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"修改密码失败",
+			zap.Int64("uid", uid),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+	return nil
+}
+
+// openPassword 解密登录密码信封，并保证同一密文只能使用一次
+func (s *AuthService) openPassword(ctx context.Context, keyID, ciphertext string) (string, error) {
+	envelope, err := crypto.GetEnvelope()
+	if err != nil {
+		global.Logger.WithContext(ctx).Error("获取密码信封失败", zap.Error(err))
+		return "", errs.ErrServer
+	}
+	// 公钥已更换，客户端需重新获取公钥
+	if keyID != "" && keyID != envelope.PublicKey().KeyID {
+		return "", errs.ErrPasswordExpired
+	}
+
+	payload, err := envelope.Open(ciphertext)
+	if err != nil {
+		if errors.Is(err, crypto.ErrEnvelopeExpired) {
+			return "", errs.ErrPasswordExpired
+		}
+		return "", errs.ErrPasswordCipher
+	}
+
+	fresh, err := auth.RememberLoginNonce(ctx, payload.Nonce, 2*envelope.MaxSkew())
+	if err != nil {
+		global.Logger.WithContext(ctx).Error("记录登录随机数失败", zap.Error(err))
+		return "", errs.ErrServer
+	}
+	if !fresh {
+		global.Logger.WithContext(ctx).Warn("重复的密码密文", zap.String("nonce", payload.Nonce))
+		return "", errs.ErrPasswordExpired
+	}
+	return payload.Password, nil
+}
+
+// checkPassword 常量时间校验密码
+func checkPassword(user *entity.SysUser, password string) bool {
+	return subtle.ConstantTimeCompare([]byte(crypto.MD5(password+user.Salt)), []byte(user.Password)) == 1
+}
+
+func (s *AuthService) OIDCBind(ctx context.Context, uid int64, req *systemDTO.OIDCLoginReq) error {
+	_, identity, err := s.exchange(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return global.Query.Transaction(func(tx *query.Query) error {
+		dao := tx.SysUserOauth
+
+		// 同一外部账号只能绑定一个系统账号，唯一键不区分租户，查询也不限租户
+		if exist, err := dao.WithContext(database.WithAllTenants(ctx)).Where(
+			dao.Provider.Eq(identity.Provider),
+			dao.Subject.Eq(identity.Subject),
+		).First(); err == nil {
+			if exist.UserID == uid {
+				return nil
+			}
+			return errs.ErrOAuthBound
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Logger.WithContext(ctx).Error(
+				"查询第三方账号绑定失败",
+				zap.String("provider", identity.Provider),
+				zap.String("subject", identity.Subject),
+				zap.Error(err),
+			)
+			return errs.ErrServer
+		}
+
+		// 同一提供方下每个系统账号只保留一个绑定
+		count, err := dao.WithContext(ctx).Where(dao.UserID.Eq(uid), dao.Provider.Eq(identity.Provider)).Count()
+		if err != nil {
+			global.Logger.WithContext(ctx).Error(
+				"查询用户第三方账号绑定失败",
+				zap.Int64("uid", uid),
+				zap.Error(err),
+			)
+			return errs.ErrServer
+		}
+		if count > 0 {
+			return errs.ErrOAuthBound
+		}
+
+		return s.createBinding(ctx, tx, uid, identity)
+	})
+}
+
+func (s *AuthService) OIDCUnbind(ctx context.Context, uid int64, req *systemDTO.OAuthUnbindReq) error {
+	dao := global.Query.SysUserOauth
+	if _, err := dao.WithContext(ctx).Where(dao.UserID.Eq(uid), dao.Provider.Eq(req.Provider)).Delete(); err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"解除第三方账号绑定失败",
+			zap.Int64("uid", uid),
+			zap.String("provider", req.Provider),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+	return nil
+}
+
+func (s *AuthService) OAuthBindings(ctx context.Context, uid int64) (systemDTO.OAuthBindingRes, error) {
+	dao := global.Query.SysUserOauth
+	bindings, err := dao.WithContext(ctx).Where(dao.UserID.Eq(uid)).Order(dao.CreatedAt).Find()
+	if err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"查询第三方账号绑定列表失败",
+			zap.Int64("uid", uid),
+			zap.Error(err),
+		)
+		return nil, errs.ErrServer
+	}
+
+	list := make(systemDTO.OAuthBindingRes, 0, len(bindings))
+	for _, binding := range bindings {
+		list = append(list, &systemDTO.OAuthBindingItem{
+			ID:          binding.ID,
+			Provider:    binding.Provider,
+			Subject:     binding.Subject,
+			Email:       binding.Email,
+			Nickname:    binding.Nickname,
+			LastLoginAt: binding.LastLoginAt,
+			CreatedAt:   binding.CreatedAt,
+		})
+	}
+	return list, nil
+}
+
+func (s *AuthService) Impersonate(ctx context.Context, operator *auth.Claims, req *systemDTO.ImpersonateReq) (*systemDTO.ImpersonateRes, error) {
+	// 禁止在模拟登录状态下再次模拟
+	if operator.Impersonated {
+		return nil, errs.ErrImpersonationRestricted
+	}
+	if req.UserID == operator.Uid {
+		return nil, errs.ErrImpersonateTarget
+	}
+
+	// 以数据库中的角色为准校验超级管理员身份，避免使用令牌中过期的角色信息
+	admin, err := loadUserWithRole(ctx, operator.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if !isSuperUser(admin) {
+		global.Logger.WithContext(ctx).Warn(
+			"非超级管理员尝试模拟登录",
+			zap.Int64("operator_id", operator.Uid),
+			zap.Int64("target_id", req.UserID),
+		)
+		return nil, errs.ErrImpersonateForbidden
+	}
+
+	target, err := loadUserWithRole(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Status != nil && *target.Status != 1 {
+		return nil, errs.ErrUserDisabled
+	}
+	// 不允许模拟其他超级管理员
+	if isSuperUser(target) {
+		return nil, errs.ErrImpersonateTarget
+	}
+
+	ttl := 30 * time.Minute
+	if req.Duration > 0 {
+		ttl = time.Duration(req.Duration) * time.Minute
+	}
+	token, err := auth.GenerateImpersonationToken(ctx, admin.ID, target.ID, target.TenantID, target.Username, utils.Deref(target.RoleID), ttl)
+	if err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"生成模拟登录令牌失败",
+			zap.Int64("operator_id", admin.ID),
+			zap.Int64("target_id", target.ID),
+			zap.Error(err),
+		)
+		return nil, errs.ErrServer
+	}
+
+	global.Logger.WithContext(ctx).Info(
+		"管理员发起模拟登录",
+		zap.Int64("operator_id", admin.ID),
+		zap.String("operator", admin.Username),
+		zap.Int64("target_id", target.ID),
+		zap.String("target", target.Username),
+		zap.Duration("ttl", ttl),
+		zap.String("reason", req.Reason),
+	)
+
+	return &systemDTO.ImpersonateRes{
+		Token:          token,
+		Uid:            target.ID,
+		Username:       target.Username,
+		Impersonated:   true,
+		ImpersonatorID: admin.ID,
+		ExpiresAt:      time.Now().Add(ttl).Unix(),
+	}, nil
+}
+
+func (s *AuthService) StopImpersonate(ctx context.Context, claims *auth.Claims) error {
+	if !claims.Impersonated {
+		return nil
+	}
+	if err := auth.RevokeToken(ctx, claims); err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"结束模拟登录失败",
+			zap.Int64("operator_id", claims.ImpersonatorID),
+			zap.Int64("target_id", claims.Uid),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+
+	global.Logger.WithContext(ctx).Info(
+		"管理员结束模拟登录",
+		zap.Int64("operator_id", claims.ImpersonatorID),
+		zap.Int64("target_id", claims.Uid),
+	)
+	return nil
+}
+
+// loadUserWithRole 查询用户及其角色
+func loadUserWithRole(ctx context.Context, uid int64) (*entity.SysUser, error) {
+	dao := global.Query.SysUser
+	user, err := dao.WithContext(ctx).Preload(dao.Role).Where(dao.ID.Eq(uid)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrUserNotFound
+		}
+		global.Logger.WithContext(ctx).Error(
+			"查询用户失败",
+			zap.Int64("uid", uid),
+			zap.Error(err),
+		)
+		return nil, errs.ErrServer
+	}
+	return user, nil
+}
+
+// issueToken 校验账号状态并签发登录令牌
+func (s *AuthService) issueToken(ctx context.Context, user *entity.SysUser, deviceType string) (*systemDTO.LoginRes, error) {
+	if user.Status != nil && *user.Status != 1 {
+		return nil, errs.ErrUserDisabled
+	}
+	// 租户被禁用后其用户均不能登录
+	tenant, err := loadTenant(ctx, global.Query.SysTenant.ID.Eq(user.TenantID))
+	if err != nil {
+		return nil, err
+	}
+	if utils.Deref(tenant.Status) != 1 {
+		return nil, errs.ErrTenantDisabled
+	}
+
+	if deviceType == "" {
+		deviceType = "pc"
+	}
+	// 初始或管理员重置的密码须先修改，令牌仅可访问修改密码等接口
+	generate := auth.GenerateToken
+	if utils.Deref(user.PasswordReset) == 1 {
+		generate = auth.GeneratePasswordResetToken
+	}
+	token, err := generate(ctx, user.ID, user.TenantID, user.Username, utils.Deref(user.RoleID), deviceType, auth.BackendUser)
+	if err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"生成登录令牌失败",
+			zap.Int64("uid", user.ID),
+			zap.Error(err),
+		)
+		return nil, errs.ErrServer
+	}
+
+	return &systemDTO.LoginRes{
+		Token:         token,
+		Uid:           user.ID,
+		Username:      user.Username,
+		PasswordReset: utils.Deref(user.PasswordReset) == 1,
+	}, nil
+}
+
+// isSuperUser 判断用户是否为启用状态的超级管理员角色
+func isSuperUser(user *entity.SysUser) bool {
+	return user.Role != nil && utils.Deref(user.Role.IsSuper) == 1 && utils.Deref(user.Role.Status) == 1
+}
+
+// exchange 使用授权码换取外部身份
+func (s *AuthService) exchange(ctx context.Context, req *systemDTO.OIDCLoginReq) (*auth.OIDCProvider, *auth.OIDCIdentity, error) {
+	provider, err := auth.GetOIDCProvider(req.Provider)
+	if err != nil {
+		return nil, nil, errs.ErrOAuthProvider
+	}
+
+	identity, err := provider.Exchange(ctx, req.Code, req.State)
+	if err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"第三方登录授权码换取失败",
+			zap.String("provider", req.Provider),
+			zap.Error(err),
+		)
+		return nil, nil, errs.ErrOAuthLogin
+	}
+	return provider, identity, nil
+}
+
+// provisionUser 根据外部身份自动创建系统账号
+func (s *AuthService) provisionUser(ctx context.Context, tx *query.Query, provision *auth.OIDCProvisionConfig, identity *auth.OIDCIdentity) (*entity.SysUser, error) {
+	dao := tx.SysUser
+
+	username, err := s.availableUsername(ctx, tx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	nickname := identity.Name
+	if nickname == "" {
+		nickname = username
+	}
+
+	roleID, deptID, postID := provision.Resolve(identity.Claims)
+	salt := crypto.Salt()
+	user := &entity.SysUser{
+		Username: username,
+		// 第三方账号不使用本地密码登录，写入随机密码
+		Password: crypto.MD5(crypto.SaltWithLength(32) + salt),
+		Salt:     salt,
+		Realname: nickname,
+		Nickname: nickname,
+		Status:   utils.Ptr(int64(1)),
+		Remark:   utils.Ptr(fmt.Sprintf("由第三方登录[%s]自动创建", identity.Provider)),
+	}
+	if identity.Picture != "" {
+		user.Avatar = utils.Ptr(identity.Picture)
+	}
+	// 仅在邮箱已验证且未被占用时写入，避免通过第三方抢占邮箱
+	if identity.Email != "" && identity.EmailVerified {
+		emailIndex, err := blindIndex(ctx, "email", utils.Ptr(identity.Email))
+		if err != nil {
+			return nil, err
+		}
+		count, err := dao.WithContext(ctx).Where(dao.EmailIndex.Eq(*emailIndex)).Count()
+		if err != nil {
+			global.Logger.WithContext(ctx).Error(
+				"检查邮箱重复性失败",
+				zap.String("provider", identity.Provider),
+				zap.Error(err),
+			)
+			return nil, errs.ErrServer
+		}
+		if count == 0 {
+			user.Email = utils.Ptr(identity.Email)
+			user.EmailIndex = emailIndex
+		}
+	}
+	if roleID > 0 {
+		user.RoleID = utils.Ptr(roleID)
+	}
+	if deptID > 0 {
+		user.DeptID = utils.Ptr(deptID)
+	}
+	if postID > 0 {
+		user.PostID = utils.Ptr(postID)
+	}
+
+	if err = dao.WithContext(ctx).Create(user); err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"第三方登录自动创建用户失败",
+			zap.String("provider", identity.Provider),
+			zap.String("subject", identity.Subject),
+			zap.Error(err),
+		)
+		return nil, errs.ErrServer
+	}
+
+	global.Logger.WithContext(ctx).Info(
+		"第三方登录自动创建用户成功",
+		zap.Int64("uid", user.ID),
+		zap.String("provider", identity.Provider),
+		zap.Int64("role_id", roleID),
+		zap.Int64("dept_id", deptID),
+	)
+	return user, nil
+}
+
+// availableUsername 生成未被占用的用户名
+func (s *AuthService) availableUsername(ctx context.Context, tx *query.Query, identity *auth.OIDCIdentity) (string, error) {
+	dao := tx.SysUser
+
+	base := identity.PreferredUsername
+	if base == "" && identity.Email != "" {
+		base = strings.SplitN(identity.Email, "@", 2)[0]
+	}
+	if base == "" {
+		base = identity.Provider + "_" + identity.Subject
+	}
+	if len(base) > 24 {
+		base = base[:24]
+	}
+
+	candidate := base
+	for i := 0; i < 5; i++ {
+		count, err := dao.WithContext(ctx).Unscoped().Where(dao.Username.Eq(candidate)).Count()
+		if err != nil {
+			global.Logger.WithContext(ctx).Error(
+				"检查用户名重复性失败",
+				zap.String("username", candidate),
+				zap.Error(err),
+			)
+			return "", errs.ErrServer
+		}
+		if count == 0 {
+			return candidate, nil
+		}
+		candidate = base + "_" + strings.ToLower(crypto.SaltWithLength(6))
+	}
+	return "", errs.ErrUserExists
+}
+
+// createBinding 创建第三方账号绑定
+func (s *AuthService) createBinding(ctx context.Context, tx *query.Query, uid int64, identity *auth.OIDCIdentity) error {
+	now := time.Now()
+	binding := &entity.SysUserOauth{
+		UserID:      uid,
+		Provider:    identity.Provider,
+		Issuer:      identity.Issuer,
+		Subject:     identity.Subject,
+		LastLoginAt: &now,
+	}
+	if identity.Email != "" {
+		binding.Email = utils.Ptr(identity.Email)
+	}
+	if identity.Name != "" {
+		binding.Nickname = utils.Ptr(identity.Name)
+	}
+
+	if err := tx.SysUserOauth.WithContext(ctx).Create(binding); err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"创建第三方账号绑定失败",
+			zap.Int64("uid", uid),
+			zap.String("provider", identity.Provider),
+			zap.String("subject", identity.Subject),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sweet/internal/models"
 	systemDTO "sweet/internal/models/dto/system"
+	"sweet/pkg/auth"
 )
 
 // ISystemService 系统服务接口
@@ -12,6 +13,8 @@ type ISystemService interface {
 	User() IUserService
 	// Auth 认证服务接口
 	Auth() IAuthService
+	// ApiKey API密钥服务接口
+	ApiKey() IApiKeyService
 	// Role 角色服务接口
 	Role() IRoleService
 	// Menu 菜单服务接口
@@ -22,6 +25,8 @@ type ISystemService interface {
 	Dept() IDeptService
 	// Post 岗位服务接口
 	Post() IPostService
+	// Tenant 租户服务接口
+	Tenant() ITenantService
 }
 
 // IUserService 用户服务接口
@@ -39,7 +44,43 @@ type IUserService interface {
 }
 
 // IAuthService 认证服务接口
-type IAuthService interface{}
+type IAuthService interface {
+	// PasswordKey 获取登录密码加密公钥
+	PasswordKey(ctx context.Context) (*systemDTO.PasswordKeyRes, error)
+	// Login 账号密码登录，密码需使用公钥加密传输
+	Login(ctx context.Context, req *systemDTO.LoginReq) (*systemDTO.LoginRes, error)
+	// ChangePassword 修改当前用户密码，同时清除强制修改密码标记
+	// 需先修改密码的令牌不会因此解除限制，修改后需重新登录
+	ChangePassword(ctx context.Context, uid int64, req *systemDTO.ChangePasswordReq) error
+	// OIDCAuthURL 获取第三方授权地址
+	OIDCAuthURL(ctx context.Context, req *systemDTO.OIDCAuthURLReq) (*systemDTO.OIDCAuthURLRes, error)
+	// OIDCLogin 第三方登录回调，未绑定时按规则自动开户
+	OIDCLogin(ctx context.Context, req *systemDTO.OIDCLoginReq) (*systemDTO.LoginRes, error)
+	// OIDCBind 将第三方账号绑定到当前用户
+	OIDCBind(ctx context.Context, uid int64, req *systemDTO.OIDCLoginReq) error
+	// OIDCUnbind 解除第三方账号绑定
+	OIDCUnbind(ctx context.Context, uid int64, req *systemDTO.OAuthUnbindReq) error
+	// OAuthBindings 获取当前用户的第三方账号绑定列表
+	OAuthBindings(ctx context.Context, uid int64) (systemDTO.OAuthBindingRes, error)
+	// Impersonate 超级管理员模拟登录为指定用户
+	Impersonate(ctx context.Context, operator *auth.Claims, req *systemDTO.ImpersonateReq) (*systemDTO.ImpersonateRes, error)
+	// StopImpersonate 结束模拟登录，使模拟令牌失效
+	StopImpersonate(ctx context.Context, claims *auth.Claims) error
+}
+
+// IApiKeyService API密钥服务接口
+type IApiKeyService interface {
+	// CreateApiKey 创建API密钥，完整密钥仅在此时返回
+	CreateApiKey(ctx context.Context, req *systemDTO.CreateApiKeyReq) (*systemDTO.CreateApiKeyRes, error)
+	// RevokeApiKey 吊销API密钥
+	RevokeApiKey(ctx context.Context, req *systemDTO.RevokeApiKeyReq) error
+	// AssignApiKeyApiIds 修改API密钥授权范围
+	AssignApiKeyApiIds(ctx context.Context, req *systemDTO.AssignApiKeyApiIdsReq) error
+	// ListApiKey 获取API密钥列表
+	ListApiKey(ctx context.Context, req *systemDTO.ApiKeyListReq) (*systemDTO.ApiKeyListRes, error)
+	// Authenticate 校验API密钥及其对当前接口的授权范围
+	Authenticate(ctx context.Context, req *systemDTO.ApiKeyAuthReq) (*systemDTO.ApiKeyPrincipal, error)
+}
 
 // IRoleService 角色服务接口
 type IRoleService interface {
@@ -98,3 +139,13 @@ type IDeptService interface {
 // IPostService 岗位服务接口
 type IPostService interface {
 }
+
+// ITenantService 租户服务接口，仅超级租户可管理租户
+type ITenantService interface {
+	// CreateTenant 创建租户
+	CreateTenant(ctx context.Context, req *systemDTO.CreateTenantReq) error
+	// UpdateTenant 更新租户
+	UpdateTenant(ctx context.Context, req *systemDTO.UpdateTenantReq) error
+	// ListTenant 获取租户列表
+	ListTenant(ctx context.Context, req *systemDTO.TenantListReq) (*systemDTO.TenantListRes, error)
+}
@@ -8,10 +8,12 @@ import (
 	systemDTO "sweet/internal/models/dto/system"
 	"sweet/internal/models/entity"
 	"sweet/internal/models/query"
+	"sweet/pkg/database"
 	"sweet/pkg/errs"
 	"time"
 
 	"go.uber.org/zap"
+	"gorm.io/gen/field"
 	"gorm.io/gorm"
 )
 
@@ -20,14 +22,19 @@ type RoleService struct{}
 func (s *RoleService) CreateRole(ctx context.Context, req *systemDTO.CreateRoleReq) error {
 	return global.Query.Transaction(func(tx *query.Query) error {
 		dao := tx.SysRole
-		if exist, err := dao.WithContext(ctx).Where(dao.Code.Eq(req.Code)).Or(dao.Name.Eq(req.Name)).First(); err == nil {
+		// 角色标识、名称在租户内唯一
+		tenantID := database.CurrentTenant(ctx)
+		if exist, err := dao.WithContext(ctx).Where(
+			dao.TenantID.Eq(tenantID),
+			field.Or(dao.Code.Eq(req.Code), dao.Name.Eq(req.Name)),
+		).First(); err == nil {
 			// 判断是哪个字段存在
 			if exist.Code == req.Code {
 				return errs.ErrRoleCodeExists
 			}
 			return errs.ErrRoleNameExists
 		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
-			global.Logger.Error(
+			global.Logger.WithContext(ctx).Error(
 				"查询角色失败",
 				zap.String("code", req.Code),
 				zap.String("name", req.Name),
@@ -36,15 +43,16 @@ func (s *RoleService) CreateRole(ctx context.Context, req *systemDTO.CreateRoleR
 			return errs.ErrServer
 		}
 		roleEntity := entity.SysRole{
-			Name:    req.Name,
-			Code:    req.Code,
-			Sort:    req.Sort,
-			IsSuper: req.IsSuper,
-			Status:  req.Status,
-			Remark:  req.Remark,
+			TenantID: tenantID,
+			Name:     req.Name,
+			Code:     req.Code,
+			Sort:     req.Sort,
+			IsSuper:  req.IsSuper,
+			Status:   req.Status,
+			Remark:   req.Remark,
 		}
 		if err := dao.WithContext(ctx).Create(&roleEntity); err != nil {
-			global.Logger.Error(
+			global.Logger.WithContext(ctx).Error(
 				"创建角色失败",
 				zap.Any("req", req),
 				zap.Error(err),
@@ -58,7 +66,7 @@ func (s *RoleService) CreateRole(ctx context.Context, req *systemDTO.CreateRoleR
 func (s *RoleService) DeleteRole(ctx context.Context, req *systemDTO.DeleteRoleReq) error {
 	dao := global.Query.SysRole
 	if _, err := dao.WithContext(ctx).Where(dao.ID.In(req.Ids...)).Delete(); err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"删除角色失败",
 			zap.Int64s("ids", req.Ids),
 			zap.Error(err),
@@ -76,14 +84,14 @@ func (s *RoleService) UpdateRole(ctx context.Context, req *systemDTO.UpdateRoleR
 		existingRole, err := dao.WithContext(ctx).Where(dao.ID.Eq(req.ID)).First()
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				global.Logger.Error(
+				global.Logger.WithContext(ctx).Error(
 					"角色不存在",
 					zap.Int64("id", req.ID),
 					zap.Error(err),
 				)
 				return errs.ErrRoleNotFound
 			}
-			global.Logger.Error(
+			global.Logger.WithContext(ctx).Error(
 				"查询角色失败",
 				zap.Int64("id", req.ID),
 				zap.Error(err),
@@ -93,7 +101,7 @@ func (s *RoleService) UpdateRole(ctx context.Context, req *systemDTO.UpdateRoleR
 
 		// 检查是否为系统内置角色
 		if existingRole.IsSystem != nil && *existingRole.IsSystem == 1 {
-			global.Logger.Error(
+			global.Logger.WithContext(ctx).Error(
 				"系统内置角色不允许修改",
 				zap.Int64("id", req.ID),
 				zap.String("name", existingRole.Name),
@@ -101,14 +109,20 @@ func (s *RoleService) UpdateRole(ctx context.Context, req *systemDTO.UpdateRoleR
 			return errs.ErrSystemRoleCannotModify
 		}
 
+		// 版本号不一致说明读取后已被他人修改
+		if existingRole.Version != req.Version {
+			return errs.ErrVersionConflict
+		}
+
 		// 检查角色名称是否重复（排除自己）
 		if req.Name != "" && req.Name != existingRole.Name {
 			count, err := dao.WithContext(ctx).Where(
+				dao.TenantID.Eq(existingRole.TenantID),
 				dao.Name.Eq(req.Name),
 				dao.ID.Neq(req.ID),
 			).Count()
 			if err != nil {
-				global.Logger.Error(
+				global.Logger.WithContext(ctx).Error(
 					"检查角色名称重复失败",
 					zap.String("name", req.Name),
 					zap.Error(err),
@@ -116,7 +130,7 @@ func (s *RoleService) UpdateRole(ctx context.Context, req *systemDTO.UpdateRoleR
 				return errs.ErrServer
 			}
 			if count > 0 {
-				global.Logger.Error(
+				global.Logger.WithContext(ctx).Error(
 					"角色名称已存在",
 					zap.String("name", req.Name),
 				)
@@ -127,11 +141,12 @@ func (s *RoleService) UpdateRole(ctx context.Context, req *systemDTO.UpdateRoleR
 		// 检查角色标识是否重复（排除自己）
 		if req.Code != "" && req.Code != existingRole.Code {
 			count, err := dao.WithContext(ctx).Where(
+				dao.TenantID.Eq(existingRole.TenantID),
 				dao.Code.Eq(req.Code),
 				dao.ID.Neq(req.ID),
 			).Count()
 			if err != nil {
-				global.Logger.Error(
+				global.Logger.WithContext(ctx).Error(
 					"检查角色标识重复失败",
 					zap.String("code", req.Code),
 					zap.Error(err),
@@ -139,7 +154,7 @@ func (s *RoleService) UpdateRole(ctx context.Context, req *systemDTO.UpdateRoleR
 				return errs.ErrServer
 			}
 			if count > 0 {
-				global.Logger.Error(
+				global.Logger.WithContext(ctx).Error(
 					"角色标识已存在",
 					zap.String("code", req.Code),
 				)
@@ -168,11 +183,12 @@ func (s *RoleService) UpdateRole(ctx context.Context, req *systemDTO.UpdateRoleR
 			updateData["remark"] = *req.Remark
 		}
 		updateData["updated_at"] = time.Now()
+		updateData["version"] = req.Version + 1
 
-		// 执行更新
-		_, err = dao.WithContext(ctx).Where(dao.ID.Eq(req.ID)).Updates(updateData)
+		// 执行更新，以版本号为条件，期间被并发修改时不会命中
+		info, err := dao.WithContext(ctx).Where(dao.ID.Eq(req.ID), dao.Version.Eq(req.Version)).Updates(updateData)
 		if err != nil {
-			global.Logger.Error(
+			global.Logger.WithContext(ctx).Error(
 				"更新角色失败",
 				zap.Int64("id", req.ID),
 				zap.Any("updateData", updateData),
@@ -180,6 +196,9 @@ func (s *RoleService) UpdateRole(ctx context.Context, req *systemDTO.UpdateRoleR
 			)
 			return errs.ErrServer
 		}
+		if info.RowsAffected == 0 {
+			return errs.ErrVersionConflict
+		}
 
 		return nil
 	})
@@ -255,7 +274,7 @@ func (s *RoleService) ListRole(ctx context.Context, req *systemDTO.RoleListReq)
 	offset := (req.Page - 1) * req.Size
 	roles, total, err := query.FindByPage(offset, req.Size)
 	if err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"查询角色列表失败",
 			zap.Any("req", req),
 			zap.Error(err),
@@ -274,6 +293,7 @@ func (s *RoleService) ListRole(ctx context.Context, req *systemDTO.RoleListReq)
 			IsSystem:  role.IsSystem,
 			IsSuper:   role.IsSuper,
 			Status:    role.Status,
+			Version:   role.Version,
 			CreatedAt: role.CreatedAt,
 		})
 	}
@@ -292,14 +312,14 @@ func (s *RoleService) GetRoleDetail(ctx context.Context, req *models.IDReq) (*sy
 	err := dao.WithContext(ctx).Where(dao.ID.Eq(req.ID)).Scan(&detail)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			global.Logger.Error(
+			global.Logger.WithContext(ctx).Error(
 				"角色不存在",
 				zap.Int64("id", req.ID),
 				zap.Error(err),
 			)
 			return nil, errs.ErrRoleNotFound
 		}
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"查询角色详情失败",
 			zap.Int64("id", req.ID),
 			zap.Error(err),
@@ -323,7 +343,7 @@ func (s *RoleService) RoleOptions(ctx context.Context) (*systemDTO.RoleOptionRes
 	// 使用Find方法查询所有角色选项
 	roles, err := query.Find()
 	if err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"查询角色选项失败",
 			zap.Error(err),
 		)
@@ -352,7 +372,7 @@ func (s *RoleService) RoleMenuIds(ctx context.Context, req *models.IDReq) (*syst
 	// 根据角色ID查询角色菜单关联记录
 	roleMenus, err := query.Where(dao.RoleID.Eq(req.ID)).Find()
 	if err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"查询角色菜单关联失败",
 			zap.Int64("role_id", req.ID),
 			zap.Error(err),
@@ -374,7 +394,7 @@ func (s *RoleService) RoleMenuIds(ctx context.Context, req *models.IDReq) (*syst
 func (s *RoleService) AssignRoleMenuIds(ctx context.Context, req *systemDTO.AssignRoleMenuIdsReq) error {
 	// 如果没有新的菜单ID，需要确认是否为有意的清空操作
 	if len(req.MenuIds) == 0 && !req.ConfirmClear {
-		global.Logger.Warn(
+		global.Logger.WithContext(ctx).Warn(
 			"尝试清空角色菜单权限但未确认",
 			zap.Int64("role_id", req.ID),
 		)
@@ -386,7 +406,7 @@ func (s *RoleService) AssignRoleMenuIds(ctx context.Context, req *systemDTO.Assi
 
 		// 先删除该角色的所有现有菜单关联
 		if _, err := dao.WithContext(ctx).Where(dao.RoleID.Eq(req.ID)).Delete(); err != nil {
-			global.Logger.Error(
+			global.Logger.WithContext(ctx).Error(
 				"删除角色菜单关联失败",
 				zap.Int64("role_id", req.ID),
 				zap.Error(err),
@@ -396,7 +416,7 @@ func (s *RoleService) AssignRoleMenuIds(ctx context.Context, req *systemDTO.Assi
 
 		// 如果没有新的菜单ID，直接返回（清空权限）
 		if len(req.MenuIds) == 0 {
-			global.Logger.Info(
+			global.Logger.WithContext(ctx).Info(
 				"角色菜单权限已清空",
 				zap.Int64("role_id", req.ID),
 			)
@@ -413,7 +433,7 @@ func (s *RoleService) AssignRoleMenuIds(ctx context.Context, req *systemDTO.Assi
 		}
 
 		if err := dao.WithContext(ctx).CreateInBatches(roleMenus, 100); err != nil {
-			global.Logger.Error(
+			global.Logger.WithContext(ctx).Error(
 				"批量创建角色菜单关联失败",
 				zap.Int64("role_id", req.ID),
 				zap.Any("menu_ids", req.MenuIds),
@@ -422,7 +442,7 @@ func (s *RoleService) AssignRoleMenuIds(ctx context.Context, req *systemDTO.Assi
 			return errs.ErrServer
 		}
 
-		global.Logger.Info(
+		global.Logger.WithContext(ctx).Info(
 			"角色菜单分配成功",
 			zap.Int64("role_id", req.ID),
 			zap.Int("menu_count", len(req.MenuIds)),
@@ -438,7 +458,7 @@ func (s *RoleService) RoleApiIds(ctx context.Context, req *models.IDReq) (*syste
 	// 根据角色ID查询角色API关联记录
 	roleApis, err := query.Where(dao.RoleID.Eq(req.ID)).Find()
 	if err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"查询角色API关联失败",
 			zap.Int64("role_id", req.ID),
 			zap.Error(err),
@@ -460,7 +480,7 @@ func (s *RoleService) RoleApiIds(ctx context.Context, req *models.IDReq) (*syste
 func (s *RoleService) AssignRoleApiIds(ctx context.Context, req *systemDTO.AssignRoleApiIdsReq) error {
 	// 如果没有新的API ID，需要确认是否为有意的清空操作
 	if len(req.ApiIds) == 0 && (req.ConfirmClear == nil || !*req.ConfirmClear) {
-		global.Logger.Warn(
+		global.Logger.WithContext(ctx).Warn(
 			"尝试清空角色API权限但未确认",
 			zap.Int64("role_id", req.ID),
 		)
@@ -472,7 +492,7 @@ func (s *RoleService) AssignRoleApiIds(ctx context.Context, req *systemDTO.Assig
 
 		// 先删除该角色的所有现有API关联
 		if _, err := dao.WithContext(ctx).Where(dao.RoleID.Eq(req.ID)).Delete(); err != nil {
-			global.Logger.Error(
+			global.Logger.WithContext(ctx).Error(
 				"删除角色API关联失败",
 				zap.Int64("role_id", req.ID),
 				zap.Error(err),
@@ -482,7 +502,7 @@ func (s *RoleService) AssignRoleApiIds(ctx context.Context, req *systemDTO.Assig
 
 		// 如果没有新的API ID，直接返回（清空权限）
 		if len(req.ApiIds) == 0 {
-			global.Logger.Info(
+			global.Logger.WithContext(ctx).Info(
 				"角色API权限已清空",
 				zap.Int64("role_id", req.ID),
 			)
@@ -499,7 +519,7 @@ func (s *RoleService) AssignRoleApiIds(ctx context.Context, req *systemDTO.Assig
 		}
 
 		if err := dao.WithContext(ctx).CreateInBatches(roleApis, 100); err != nil {
-			global.Logger.Error(
+			global.Logger.WithContext(ctx).Error(
 				"批量创建角色API关联失败",
 				zap.Int64("role_id", req.ID),
 				zap.Any("api_ids", req.ApiIds),
@@ -508,7 +528,7 @@ func (s *RoleService) AssignRoleApiIds(ctx context.Context, req *systemDTO.Assig
 			return errs.ErrServer
 		}
 
-		global.Logger.Info(
+		global.Logger.WithContext(ctx).Info(
 			"角色API分配成功",
 			zap.Int64("role_id", req.ID),
 			zap.Int("api_count", len(req.ApiIds)),
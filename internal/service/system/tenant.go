@@ -0,0 +1,168 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"sweet/internal/global"
+	"sweet/internal/listing"
+	systemDTO "sweet/internal/models/dto/system"
+	"sweet/internal/models/entity"
+	"sweet/pkg/auth"
+	"sweet/pkg/errs"
+	"sweet/pkg/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+// TenantService 租户服务实现
+type TenantService struct{}
+
+func NewTenantService() ITenantService {
+	return &TenantService{}
+}
+
+func (s *TenantService) CreateTenant(ctx context.Context, req *systemDTO.CreateTenantReq) error {
+	if err := requireSuperTenant(ctx); err != nil {
+		return err
+	}
+
+	dao := global.Query.SysTenant
+	count, err := dao.WithContext(ctx).Unscoped().Where(dao.Code.Eq(req.Code)).Count()
+	if err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"检查租户编码重复失败",
+			zap.String("code", req.Code),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+	if count > 0 {
+		return errs.ErrTenantCodeExists
+	}
+
+	if err := dao.WithContext(ctx).Create(&entity.SysTenant{
+		Name:   req.Name,
+		Code:   req.Code,
+		Status: req.Status,
+		Remark: req.Remark,
+	}); err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"创建租户失败",
+			zap.String("code", req.Code),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+	return nil
+}
+
+func (s *TenantService) UpdateTenant(ctx context.Context, req *systemDTO.UpdateTenantReq) error {
+	if err := requireSuperTenant(ctx); err != nil {
+		return err
+	}
+	// 超级租户不允许禁用
+	if req.ID == auth.SuperTenantID && req.Status != nil && *req.Status != 1 {
+		return errs.ErrTenantForbidden
+	}
+
+	dao := global.Query.SysTenant
+	updates := make(map[string]any)
+	if req.Name != "" {
+		updates[dao.Name.ColumnName().String()] = req.Name
+	}
+	if req.Status != nil {
+		updates[dao.Status.ColumnName().String()] = *req.Status
+	}
+	if req.Remark != nil {
+		updates[dao.Remark.ColumnName().String()] = *req.Remark
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	info, err := dao.WithContext(ctx).Where(dao.ID.Eq(req.ID)).Updates(updates)
+	if err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"更新租户失败",
+			zap.Int64("id", req.ID),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+	if info.RowsAffected == 0 {
+		if _, err := loadTenant(ctx, dao.ID.Eq(req.ID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *TenantService) ListTenant(ctx context.Context, req *systemDTO.TenantListReq) (*systemDTO.TenantListRes, error) {
+	if err := requireSuperTenant(ctx); err != nil {
+		return nil, err
+	}
+
+	dao := global.Query.SysTenant
+	res, err := listing.Find(dao.WithContext(ctx), &dao, req, listing.Options{
+		Sorts:       []string{"id", "code", "created_at"},
+		DefaultSort: []field.Expr{dao.ID.Asc()},
+	}, toTenantListItem)
+	if err != nil {
+		global.Logger.WithContext(ctx).Error("查询租户列表失败", zap.Error(err))
+		return nil, errs.ErrServer
+	}
+	return (*systemDTO.TenantListRes)(res), nil
+}
+
+// toTenantListItem 转换为租户列表项
+func toTenantListItem(tenant *entity.SysTenant) *systemDTO.TenantListItem {
+	return &systemDTO.TenantListItem{
+		ID:        tenant.ID,
+		Name:      tenant.Name,
+		Code:      tenant.Code,
+		Status:    tenant.Status,
+		Remark:    tenant.Remark,
+		CreatedAt: tenant.CreatedAt,
+	}
+}
+
+// requireSuperTenant 校验当前用户属于超级租户
+func requireSuperTenant(ctx context.Context) error {
+	if p, ok := auth.PrincipalFrom(ctx); ok && p.IsSuperTenant() {
+		return nil
+	}
+	return errs.ErrTenantForbidden
+}
+
+// loadTenant 查询租户
+func loadTenant(ctx context.Context, cond field.Expr) (*entity.SysTenant, error) {
+	dao := global.Query.SysTenant
+	tenant, err := dao.WithContext(ctx).Where(cond).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrTenantNotFound
+		}
+		global.Logger.WithContext(ctx).Error("查询租户失败", zap.Error(err))
+		return nil, errs.ErrServer
+	}
+	return tenant, nil
+}
+
+// activeTenant 查询启用状态的租户，编码为空时为超级租户
+func activeTenant(ctx context.Context, code string) (*entity.SysTenant, error) {
+	dao := global.Query.SysTenant
+	cond := dao.ID.Eq(auth.SuperTenantID)
+	if code != "" {
+		cond = dao.Code.Eq(code)
+	}
+	tenant, err := loadTenant(ctx, cond)
+	if err != nil {
+		return nil, err
+	}
+	if utils.Deref(tenant.Status) != 1 {
+		return nil, errs.ErrTenantDisabled
+	}
+	return tenant, nil
+}
@@ -9,10 +9,12 @@ import (
 	"sweet/internal/models/entity"
 	"sweet/internal/models/query"
 	"sweet/pkg/crypto"
+	"sweet/pkg/database"
 	"sweet/pkg/errs"
 	"sweet/pkg/utils"
 
 	"go.uber.org/zap"
+	"gorm.io/gen/field"
 	"gorm.io/gorm"
 )
 
@@ -25,32 +27,45 @@ func NewUserService() IUserService {
 func (s *UserService) CreateUser(ctx context.Context, req *systemDTO.CreateUserReq) error {
 	return global.Query.Transaction(func(tx *query.Query) error {
 		dao := tx.SysUser
-		do := dao.WithContext(ctx)
+		// 用户名、手机号、邮箱在租户内唯一，超级租户跨租户查询时也只在新用户所属租户内判断
+		tenantID := database.CurrentTenant(ctx)
+		if err := checkUserRefs(ctx, tx, tenantID, req.RoleID, req.DeptID, req.PostID); err != nil {
+			return err
+		}
+
+		// 邮箱、手机号加密存储，使用盲索引判断重复
+		emailIndex, err := blindIndex(ctx, "email", req.Email)
+		if err != nil {
+			return err
+		}
+		phoneIndex, err := blindIndex(ctx, "phone", req.Phone)
+		if err != nil {
+			return err
+		}
 
 		// 检查用户名、手机号、邮箱是否已存在
-		do = do.Where(dao.Username.Eq(req.Username))
-		if req.Email != nil {
-			do = do.Where(dao.Email.Eq(*req.Email))
+		conds := []field.Expr{dao.Username.Eq(req.Username)}
+		if emailIndex != nil {
+			conds = append(conds, dao.EmailIndex.Eq(*emailIndex))
 		}
-		if req.Phone != nil {
-			do = do.Where(dao.Phone.Eq(*req.Phone))
+		if phoneIndex != nil {
+			conds = append(conds, dao.PhoneIndex.Eq(*phoneIndex))
 		}
+		do := dao.WithContext(ctx).Where(dao.TenantID.Eq(tenantID), field.Or(conds...))
 
 		if user, err := do.First(); err == nil {
 			// 判断是哪个字段重复
 			if user.Username == req.Username {
 				return errs.ErrUserExists
 			}
-			if user.Email == req.Email {
+			if emailIndex != nil && user.EmailIndex != nil && *user.EmailIndex == *emailIndex {
 				return errs.ErrEmailExists
 			}
 			return errs.ErrPhoneExists
 		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
-			global.Logger.Error(
+			global.Logger.WithContext(ctx).Error(
 				"查询用户失败",
 				zap.String("Username", req.Username),
-				zap.String("Email", *req.Email),
-				zap.String("Phone", *req.Phone),
 				zap.Error(err),
 			)
 			return errs.ErrServer
@@ -59,25 +74,28 @@ func (s *UserService) CreateUser(ctx context.Context, req *systemDTO.CreateUserR
 			salt := crypto.Salt()
 			// 创建用户
 			userEntity := entity.SysUser{
-				Username: req.Username,
-				Password: crypto.MD5(req.Password + salt),
-				Salt:     salt,
-				Realname: req.Realname,
-				Nickname: req.Nickname,
-				Avatar:   req.Avatar,
-				Email:    req.Email,
-				Phone:    req.Phone,
-				Status:   req.Status,
-				RoleID:   req.RoleID,
-				DeptID:   req.DeptID,
-				PostID:   req.PostID,
-				Remark:   req.Remark,
+				TenantID:   tenantID,
+				Username:   req.Username,
+				Password:   crypto.MD5(req.Password + salt),
+				Salt:       salt,
+				Realname:   req.Realname,
+				Nickname:   req.Nickname,
+				Avatar:     req.Avatar,
+				Email:      req.Email,
+				Phone:      req.Phone,
+				EmailIndex: emailIndex,
+				PhoneIndex: phoneIndex,
+				Status:     req.Status,
+				RoleID:     req.RoleID,
+				DeptID:     req.DeptID,
+				PostID:     req.PostID,
+				Remark:     req.Remark,
 			}
 			if err = dao.WithContext(ctx).Create(&userEntity); err != nil {
 				// 创建用户失败
-				global.Logger.Error(
+				global.Logger.WithContext(ctx).Error(
 					"创建用户失败",
-					zap.Any("entity", userEntity),
+					zap.String("username", req.Username),
 					zap.Error(err),
 				)
 				return errs.ErrServer
@@ -91,7 +109,7 @@ func (s *UserService) CreateUser(ctx context.Context, req *systemDTO.CreateUserR
 func (s *UserService) DeleteUser(ctx context.Context, req *systemDTO.DeleteUserReq) error {
 	dao := global.Query.SysUser
 	if _, err := dao.WithContext(ctx).Where(dao.ID.In(req.Ids...)).Delete(); err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"删除用户失败",
 			zap.Any("ids", req.Ids),
 			zap.Error(err),
@@ -106,11 +124,12 @@ func (s *UserService) UpdateUser(ctx context.Context, req *systemDTO.UpdateUserR
 		dao := tx.SysUser
 
 		// 检查用户是否存在
-		if _, err := dao.WithContext(ctx).Where(dao.ID.Eq(req.ID)).First(); err != nil {
+		user, err := dao.WithContext(ctx).Where(dao.ID.Eq(req.ID)).First()
+		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return errs.ErrUserNotFound
 			}
-			global.Logger.Error(
+			global.Logger.WithContext(ctx).Error(
 				"查询用户失败",
 				zap.Int64("id", req.ID),
 				zap.Error(err),
@@ -118,18 +137,36 @@ func (s *UserService) UpdateUser(ctx context.Context, req *systemDTO.UpdateUserR
 			return errs.ErrServer
 		}
 
+		// 版本号不一致说明读取后已被他人修改
+		if user.Version != req.Version {
+			return errs.ErrVersionConflict
+		}
+		if err := checkUserRefs(ctx, tx, user.TenantID, req.RoleID, req.DeptID, req.PostID); err != nil {
+			return err
+		}
+
+		// 邮箱、手机号加密存储，使用盲索引判断重复
+		emailIndex, err := blindIndex(ctx, "email", req.Email)
+		if err != nil {
+			return err
+		}
+		phoneIndex, err := blindIndex(ctx, "phone", req.Phone)
+		if err != nil {
+			return err
+		}
+
 		// 检查邮箱重复性（如果提供了邮箱且不为空）
-		if req.Email != nil && *req.Email != "" {
+		if emailIndex != nil {
 			if existingUser, err := dao.WithContext(ctx).Where(
+				dao.TenantID.Eq(user.TenantID),
 				dao.ID.Neq(req.ID),
-				dao.Email.Eq(*req.Email),
+				dao.EmailIndex.Eq(*emailIndex),
 			).First(); err == nil && existingUser != nil {
 				return errs.ErrEmailExists
 			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
-				global.Logger.Error(
+				global.Logger.WithContext(ctx).Error(
 					"检查邮箱重复性失败",
 					zap.Int64("id", req.ID),
-					zap.String("email", *req.Email),
 					zap.Error(err),
 				)
 				return errs.ErrServer
@@ -137,17 +174,17 @@ func (s *UserService) UpdateUser(ctx context.Context, req *systemDTO.UpdateUserR
 		}
 
 		// 检查手机号重复性（如果提供了手机号且不为空）
-		if req.Phone != nil && *req.Phone != "" {
+		if phoneIndex != nil {
 			if existingUser, err := dao.WithContext(ctx).Where(
+				dao.TenantID.Eq(user.TenantID),
 				dao.ID.Neq(req.ID),
-				dao.Phone.Eq(*req.Phone),
+				dao.PhoneIndex.Eq(*phoneIndex),
 			).First(); err == nil && existingUser != nil {
 				return errs.ErrPhoneExists
 			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
-				global.Logger.Error(
+				global.Logger.WithContext(ctx).Error(
 					"检查手机号重复性失败",
 					zap.Int64("id", req.ID),
-					zap.String("phone", *req.Phone),
 					zap.Error(err),
 				)
 				return errs.ErrServer
@@ -156,35 +193,61 @@ func (s *UserService) UpdateUser(ctx context.Context, req *systemDTO.UpdateUserR
 
 		// 构建更新实体
 		updateEntity := entity.SysUser{
-			Realname: req.Realname,
-			Nickname: req.Nickname,
-			Avatar:   req.Avatar,
-			Email:    req.Email,
-			Phone:    req.Phone,
-			Status:   req.Status,
-			RoleID:   req.RoleID,
-			DeptID:   req.DeptID,
-			PostID:   req.PostID,
-			Remark:   req.Remark,
+			Realname:   req.Realname,
+			Nickname:   req.Nickname,
+			Avatar:     req.Avatar,
+			Email:      req.Email,
+			Phone:      req.Phone,
+			EmailIndex: emailIndex,
+			PhoneIndex: phoneIndex,
+			Status:     req.Status,
+			RoleID:     req.RoleID,
+			DeptID:     req.DeptID,
+			PostID:     req.PostID,
+			Remark:     req.Remark,
+			Version:    req.Version + 1,
 		}
 
-		// 如果需要更新密码
+		// 如果需要更新密码，管理员设置的密码需用户登录后自行修改
 		if req.Password != "" {
 			salt := crypto.Salt()
 			updateEntity.Password = crypto.MD5(req.Password + salt)
 			updateEntity.Salt = salt
+			updateEntity.PasswordReset = utils.Ptr[int64](1)
 		}
 
-		// 执行更新
-		if _, err := dao.WithContext(ctx).Where(dao.ID.Eq(req.ID)).Updates(updateEntity); err != nil {
-			global.Logger.Error(
+		// 执行更新，以版本号为条件，期间被并发修改时不会命中
+		info, err := dao.WithContext(ctx).Where(dao.ID.Eq(req.ID), dao.Version.Eq(req.Version)).Updates(updateEntity)
+		if err != nil {
+			global.Logger.WithContext(ctx).Error(
 				"更新用户失败",
 				zap.Int64("id", req.ID),
-				zap.Any("entity", updateEntity),
 				zap.Error(err),
 			)
 			return errs.ErrServer
 		}
+		if info.RowsAffected == 0 {
+			return errs.ErrVersionConflict
+		}
+
+		// 清空邮箱、手机号时同步清空盲索引，Updates 会忽略空指针字段
+		var clears []field.AssignExpr
+		if req.Email != nil && *req.Email == "" {
+			clears = append(clears, dao.EmailIndex.Null())
+		}
+		if req.Phone != nil && *req.Phone == "" {
+			clears = append(clears, dao.PhoneIndex.Null())
+		}
+		if len(clears) > 0 {
+			if _, err := dao.WithContext(ctx).Where(dao.ID.Eq(req.ID)).UpdateSimple(clears...); err != nil {
+				global.Logger.WithContext(ctx).Error(
+					"清空用户盲索引失败",
+					zap.Int64("id", req.ID),
+					zap.Error(err),
+				)
+				return errs.ErrServer
+			}
+		}
 
 		return nil
 	})
@@ -240,7 +303,7 @@ func (s *UserService) ListUser(ctx context.Context, req *systemDTO.ListUserReq)
 	offset := (req.Page - 1) * req.Size
 	users, count, err := do.FindByPage(offset, req.Size)
 	if err != nil {
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"查询用户列表失败",
 			zap.Any("req", req),
 			zap.Error(err),
@@ -259,6 +322,7 @@ func (s *UserService) ListUser(ctx context.Context, req *systemDTO.ListUserReq)
 			Avatar:    user.Avatar,
 			Status:    user.Status,
 			RoleID:    utils.Deref(user.RoleID),
+			Version:   user.Version,
 			CreatedAt: user.CreatedAt,
 		}
 		// 设置角色名称
@@ -284,7 +348,7 @@ func (s *UserService) GetUserDetail(ctx context.Context, req *models.IDReq) (*sy
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errs.ErrUserNotFound
 		}
-		global.Logger.Error(
+		global.Logger.WithContext(ctx).Error(
 			"查询用户详情失败",
 			zap.Int64("id", req.ID),
 			zap.Error(err),
@@ -308,6 +372,7 @@ func (s *UserService) GetUserDetail(ctx context.Context, req *models.IDReq) (*sy
 		DeptID:    utils.Deref(user.DeptID),
 		PostID:    utils.Deref(user.PostID),
 		Remark:    user.Remark,
+		Version:   user.Version,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
@@ -325,3 +390,56 @@ func (s *UserService) GetUserDetail(ctx context.Context, req *models.IDReq) (*sy
 
 	return detail, nil
 }
+
+// checkUserRefs 校验角色、部门、岗位属于用户所在租户，未传入的不校验
+// 超级租户查询不限租户，因此显式带上租户条件，避免将用户挂到其他租户的（超级）角色上越权
+func checkUserRefs(ctx context.Context, tx *query.Query, tenantID int64, roleID, deptID, postID *int64) error {
+	if roleID != nil && *roleID > 0 {
+		dao := tx.SysRole
+		count, err := dao.WithContext(ctx).Where(dao.ID.Eq(*roleID), dao.TenantID.Eq(tenantID)).Count()
+		if err != nil {
+			global.Logger.WithContext(ctx).Error("查询角色失败", zap.Int64("role_id", *roleID), zap.Error(err))
+			return errs.ErrServer
+		}
+		if count == 0 {
+			return errs.ErrRoleNotFound
+		}
+	}
+	if deptID != nil && *deptID > 0 {
+		dao := tx.SysDept
+		count, err := dao.WithContext(ctx).Where(dao.ID.Eq(*deptID), dao.TenantID.Eq(tenantID)).Count()
+		if err != nil {
+			global.Logger.WithContext(ctx).Error("查询部门失败", zap.Int64("dept_id", *deptID), zap.Error(err))
+			return errs.ErrServer
+		}
+		if count == 0 {
+			return errs.ErrNotFound
+		}
+	}
+	if postID != nil && *postID > 0 {
+		dao := tx.SysPost
+		count, err := dao.WithContext(ctx).Where(dao.ID.Eq(*postID), dao.TenantID.Eq(tenantID)).Count()
+		if err != nil {
+			global.Logger.WithContext(ctx).Error("查询岗位失败", zap.Int64("post_id", *postID), zap.Error(err))
+			return errs.ErrServer
+		}
+		if count == 0 {
+			return errs.ErrNotFound
+		}
+	}
+	return nil
+}
+
+// blindIndex 计算加密字段的盲索引，字段加密未初始化时不能跳过唯一性校验
+func blindIndex(ctx context.Context, name string, value *string) (*string, error) {
+	index, err := crypto.BlindIndex(value)
+	if err != nil {
+		global.Logger.WithContext(ctx).Error(
+			"计算盲索引失败",
+			zap.String("field", name),
+			zap.Error(err),
+		)
+		return nil, errs.ErrServer
+	}
+	return index, nil
+}
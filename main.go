@@ -0,0 +1,11 @@
+package main
+
+import (
+	"os"
+
+	"sweet/cmd"
+)
+
+func main() {
+	os.Exit(cmd.Execute(os.Args[1:]))
+}
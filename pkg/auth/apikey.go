@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+
+	"sweet/pkg/crypto"
+)
+
+const (
+	// APIKeyHeader API密钥请求头
+	APIKeyHeader = "X-API-Key"
+	// APIKeyScheme Authorization头中使用API密钥的认证方案
+	APIKeyScheme = "ApiKey"
+	// APIKeyTag API密钥固定标识，便于日志与密钥扫描工具识别
+	APIKeyTag = "sk"
+
+	apiKeyPrefixLen = 8
+	apiKeySecretLen = 32
+)
+
+// APIKey 新生成的API密钥
+type APIKey struct {
+	// Key 完整密钥（仅在创建时返回一次，不落库）
+	Key string
+	// Prefix 密钥前缀（明文存储，用于查找）
+	Prefix string
+	// SecretHash 密钥哈希（落库）
+	SecretHash string
+}
+
+// GenerateAPIKey 生成API密钥，格式为 sk_<前缀>_<密钥>
+func GenerateAPIKey() *APIKey {
+	prefix := crypto.SaltWithLength(apiKeyPrefixLen)
+	secret := crypto.SaltWithLength(apiKeySecretLen)
+	return &APIKey{
+		Key:        APIKeyTag + "_" + prefix + "_" + secret,
+		Prefix:     prefix,
+		SecretHash: HashAPIKeySecret(secret),
+	}
+}
+
+// ParseAPIKey 解析完整密钥，返回前缀与密钥
+func ParseAPIKey(key string) (prefix, secret string, err error) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != APIKeyTag ||
+		len(parts[1]) != apiKeyPrefixLen || len(parts[2]) != apiKeySecretLen {
+		return "", "", ErrAPIKeyFormat
+	}
+	return parts[1], parts[2], nil
+}
+
+// HashAPIKeySecret 计算密钥哈希
+// 密钥本身为高熵随机串，使用SHA256即可，无需慢哈希
+func HashAPIKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyAPIKeySecret 使用常量时间比较校验密钥
+func VerifyAPIKeySecret(secret, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIKeySecret(secret)), []byte(hash)) == 1
+}
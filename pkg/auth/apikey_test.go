@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key := GenerateAPIKey()
+	assert.True(t, strings.HasPrefix(key.Key, APIKeyTag+"_"+key.Prefix+"_"))
+	assert.NotContains(t, key.SecretHash, key.Key)
+
+	prefix, secret, err := ParseAPIKey(key.Key)
+	require.NoError(t, err)
+	assert.Equal(t, key.Prefix, prefix)
+	assert.True(t, VerifyAPIKeySecret(secret, key.SecretHash))
+	assert.False(t, VerifyAPIKeySecret(secret+"x", key.SecretHash))
+
+	// 两次生成的密钥互不相同
+	assert.NotEqual(t, key.Key, GenerateAPIKey().Key)
+}
+
+func TestParseAPIKey(t *testing.T) {
+	cases := []string{
+		"",
+		"sk_abc",
+		"pk_abcdefgh_" + strings.Repeat("a", 32),
+		"sk_abcdefg_" + strings.Repeat("a", 32),
+		"sk_abcdefgh_" + strings.Repeat("a", 31),
+		"sk_abcdefgh_" + strings.Repeat("a", 32) + "_x",
+	}
+	for _, c := range cases {
+		_, _, err := ParseAPIKey(c)
+		assert.ErrorIs(t, err, ErrAPIKeyFormat, c)
+	}
+}
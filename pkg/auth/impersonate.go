@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// DeviceImpersonate 模拟登录设备类型，使模拟会话与被模拟用户的正常会话互不影响
+	DeviceImpersonate = "impersonate"
+	// MaxImpersonationTTL 模拟登录最长有效期
+	MaxImpersonationTTL = 2 * time.Hour
+)
+
+// IsReservedDevice 是否为系统保留的设备类型，登录时不允许客户端指定，避免覆盖模拟会话
+func IsReservedDevice(deviceType string) bool {
+	return deviceType == DeviceImpersonate
+}
+
+// GenerateImpersonationToken 生成模拟登录令牌
+// 令牌以被模拟用户身份签发，同时携带发起模拟的管理员ID，到期后不会自动刷新
+func GenerateImpersonationToken(ctx context.Context, impersonatorID, uid, tenantID int64, username string, rid int64, ttl time.Duration) (string, error) {
+	if ttl <= 0 || ttl > MaxImpersonationTTL {
+		return "", ErrImpersonationTTL
+	}
+
+	claims := &Claims{
+		Uid:            uid,
+		TenantID:       tenantID,
+		Username:       username,
+		Rid:            rid,
+		DeviceType:     DeviceImpersonate,
+		UserType:       BackendUser,
+		BufferTime:     time.Now().Add(ttl).Unix(),
+		Impersonated:   true,
+		ImpersonatorID: impersonatorID,
+	}
+	return signToken(ctx, claims, ttl)
+}
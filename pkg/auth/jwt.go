@@ -60,36 +60,76 @@ func NewJwt(cfg *JwtConfig, client *redis.Client) error {
 }
 
 // GenerateToken 生成Token
-func GenerateToken(ctx context.Context, uid int64, username string, rid int64, deviceType string, userType UserType) (string, error) {
-	bufferTime := time.Now().Add(localJwt.bufferTime)
-	expire := time.Now().Add(localJwt.expireTime)
-	claims := &Claims{
+func GenerateToken(ctx context.Context, uid, tenantID int64, username string, rid int64, deviceType string, userType UserType) (string, error) {
+	return generateToken(ctx, &Claims{
 		Uid:        uid,
+		TenantID:   tenantID,
 		Username:   username,
 		Rid:        rid,
 		DeviceType: deviceType,
 		UserType:   userType,
-		BufferTime: bufferTime.Unix(),
-		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    localJwt.issuer,
-			Subject:   localJwt.subject,
-			ExpiresAt: jwt.NewNumericDate(expire),
-			NotBefore: jwt.NewNumericDate(time.Now()),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+	})
+}
+
+// GeneratePasswordResetToken 生成需先修改密码的Token
+// 令牌携带 PasswordReset 标记，刷新后保留，修改密码后需重新登录获取不带标记的令牌
+func GeneratePasswordResetToken(ctx context.Context, uid, tenantID int64, username string, rid int64, deviceType string, userType UserType) (string, error) {
+	return generateToken(ctx, &Claims{
+		Uid:           uid,
+		TenantID:      tenantID,
+		Username:      username,
+		Rid:           rid,
+		DeviceType:    deviceType,
+		UserType:      userType,
+		PasswordReset: true,
+	})
+}
+
+// generateToken 按配置的有效期签发Token
+func generateToken(ctx context.Context, claims *Claims) (string, error) {
+	claims.BufferTime = time.Now().Add(localJwt.bufferTime).Unix()
+	return signToken(ctx, claims, localJwt.expireTime)
+}
+
+// signToken 签发并缓存Token
+func signToken(ctx context.Context, claims *Claims, expireTime time.Duration) (string, error) {
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		Issuer:    localJwt.issuer,
+		Subject:   localJwt.subject,
+		ExpiresAt: jwt.NewNumericDate(now.Add(expireTime)),
+		NotBefore: jwt.NewNumericDate(now),
+		IssuedAt:  jwt.NewNumericDate(now),
 	}
 
 	if token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(localJwt.key); err != nil {
 		return "", fmt.Errorf("生成token失败: %w", err)
 	} else {
-		key := fmt.Sprintf(TokenCache, userType, uid, username, rid, deviceType)
-		if err = localJwt.client.Set(ctx, key, token, localJwt.expireTime).Err(); err != nil {
+		if err = localJwt.client.Set(ctx, tokenCacheKey(claims), token, expireTime).Err(); err != nil {
 			return "", fmt.Errorf("缓存token失败: %w", err)
 		}
 		return token, nil
 	}
 }
 
+// RevokeToken 使Token失效
+func RevokeToken(ctx context.Context, claims *Claims) error {
+	if err := localJwt.client.Del(ctx, tokenCacheKey(claims)).Err(); err != nil {
+		return fmt.Errorf("删除token缓存失败: %w", err)
+	}
+	return nil
+}
+
+// tokenCacheKey Token缓存键
+// 模拟会话按发起的管理员区分，多个管理员同时模拟同一用户时互不挤占
+func tokenCacheKey(claims *Claims) string {
+	key := fmt.Sprintf(TokenCache, claims.UserType, claims.Uid, claims.Username, claims.Rid, claims.DeviceType)
+	if claims.Impersonated {
+		key += fmt.Sprintf("::%d", claims.ImpersonatorID)
+	}
+	return key
+}
+
 // ParseToken 解析Token
 func ParseToken(token string) (*Claims, error) {
 	// 检查token是否为空
@@ -150,8 +190,7 @@ func CheckToken(ctx context.Context, token string) (*CheckTokenResult, error) {
 		return nil, err
 	} else {
 		var result string
-		key := fmt.Sprintf(TokenCache, claims.UserType, claims.Uid, claims.Username, claims.Rid, claims.DeviceType)
-		if result, err = localJwt.client.Get(ctx, key).Result(); err != nil {
+		if result, err = localJwt.client.Get(ctx, tokenCacheKey(claims)).Result(); err != nil {
 			if errors.Is(err, redis.Nil) {
 				// 需要重新登录
 				return nil, ErrNeedLogin
@@ -163,9 +202,18 @@ func CheckToken(ctx context.Context, token string) (*CheckTokenResult, error) {
 			return nil, ErrUserAlreadyLogin
 		}
 
-		if claims.BufferTime < time.Now().Unix() {
+		// 模拟登录令牌到期即失效，不自动刷新
+		if !claims.Impersonated && claims.BufferTime < time.Now().Unix() {
 			// 需要刷新token
-			newToken, err := GenerateToken(ctx, claims.Uid, claims.Username, claims.Rid, claims.DeviceType, claims.UserType)
+			newToken, err := generateToken(ctx, &Claims{
+				Uid:           claims.Uid,
+				TenantID:      claims.TenantID,
+				Username:      claims.Username,
+				Rid:           claims.Rid,
+				DeviceType:    claims.DeviceType,
+				UserType:      claims.UserType,
+				PasswordReset: claims.PasswordReset,
+			})
 			if err != nil {
 				return nil, fmt.Errorf("刷新token失败: %w", err)
 			}
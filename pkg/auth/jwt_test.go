@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenCacheKey_Impersonation(t *testing.T) {
+	normal := &Claims{Uid: 5, Username: "alice", Rid: 2, DeviceType: DeviceImpersonate, UserType: BackendUser}
+	first := &Claims{Uid: 5, Username: "alice", Rid: 2, DeviceType: DeviceImpersonate, UserType: BackendUser, Impersonated: true, ImpersonatorID: 1}
+	second := &Claims{Uid: 5, Username: "alice", Rid: 2, DeviceType: DeviceImpersonate, UserType: BackendUser, Impersonated: true, ImpersonatorID: 2}
+
+	assert.NotEqual(t, tokenCacheKey(normal), tokenCacheKey(first), "伪造设备类型的正常登录不能覆盖模拟会话")
+	assert.NotEqual(t, tokenCacheKey(first), tokenCacheKey(second), "不同管理员的模拟会话互不影响")
+	assert.Equal(t, tokenCacheKey(first), tokenCacheKey(&Claims{Uid: 5, Username: "alice", Rid: 2, DeviceType: DeviceImpersonate, UserType: BackendUser, Impersonated: true, ImpersonatorID: 1}))
+
+	assert.True(t, IsReservedDevice(DeviceImpersonate))
+	assert.False(t, IsReservedDevice("pc"))
+	assert.False(t, IsReservedDevice(""))
+}
@@ -0,0 +1,13 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RememberLoginNonce 记录登录密码密文中的随机数，已使用过时返回false
+// 有效期应覆盖密文时间窗口两端，确保窗口内无法重放
+func RememberLoginNonce(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	return localJwt.client.SetNX(ctx, fmt.Sprintf(LoginNonceCache, nonce), 1, ttl).Result()
+}
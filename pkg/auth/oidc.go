@@ -0,0 +1,583 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+// OIDCConfig OIDC身份提供方配置
+type OIDCConfig struct {
+	Name         string              `yaml:"name"`          // 提供方名称（如 keycloak、azure）
+	Issuer       string              `yaml:"issuer"`        // 签发者地址，用于拼接发现文档地址
+	ClientID     string              `yaml:"client_id"`     // 客户端ID
+	ClientSecret string              `yaml:"client_secret"` // 客户端密钥
+	RedirectURL  string              `yaml:"redirect_url"`  // 回调地址
+	Scopes       []string            `yaml:"scopes"`        // 授权范围，默认 openid profile email
+	StateTTL     string              `yaml:"state_ttl"`     // state有效期 1m、10m
+	Provision    OIDCProvisionConfig `yaml:"provision"`
+}
+
+// OIDCProvisionConfig 首次登录自动开户配置
+type OIDCProvisionConfig struct {
+	Enabled       bool            `yaml:"enabled"`         // 是否允许自动开户
+	DefaultRoleID int64           `yaml:"default_role_id"` // 默认角色ID
+	DefaultDeptID int64           `yaml:"default_dept_id"` // 默认部门ID
+	DefaultPostID int64           `yaml:"default_post_id"` // 默认岗位ID
+	TenantID      int64           `yaml:"tenant_id"`       // 自动开户所属租户ID，0表示超级租户
+	Rules         []OIDCClaimRule `yaml:"rules"`           // 声明映射规则，按顺序匹配，命中第一条即停止
+}
+
+// OIDCClaimRule 声明映射规则
+type OIDCClaimRule struct {
+	Claim  string `yaml:"claim"`   // 声明名称（如 groups、department）
+	Value  string `yaml:"value"`   // 声明值，数组类型声明只要包含该值即命中
+	RoleID int64  `yaml:"role_id"` // 命中后分配的角色ID，0表示使用默认值
+	DeptID int64  `yaml:"dept_id"` // 命中后分配的部门ID，0表示使用默认值
+	PostID int64  `yaml:"post_id"` // 命中后分配的岗位ID，0表示使用默认值
+}
+
+// OIDCDiscovery OIDC发现文档
+type OIDCDiscovery struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserinfoEndpoint      string   `json:"userinfo_endpoint"`
+	JwksURI               string   `json:"jwks_uri"`
+	IDTokenSigningAlgs    []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// OIDCState 授权请求状态，保存在缓存中供回调时校验
+type OIDCState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// OIDCIdentity ID Token中解析出的外部身份
+type OIDCIdentity struct {
+	Provider          string         `json:"provider"`
+	Issuer            string         `json:"issuer"`
+	Subject           string         `json:"subject"`
+	Email             string         `json:"email"`
+	EmailVerified     bool           `json:"email_verified"`
+	Name              string         `json:"name"`
+	PreferredUsername string         `json:"preferred_username"`
+	Picture           string         `json:"picture"`
+	Claims            map[string]any `json:"claims"`
+}
+
+// OIDCStateStore 授权状态存储
+type OIDCStateStore interface {
+	// Save 保存状态
+	Save(ctx context.Context, state string, data *OIDCState, ttl time.Duration) error
+	// Take 取出并删除状态，状态只能使用一次
+	Take(ctx context.Context, state string) (*OIDCState, error)
+}
+
+// OIDCProvider OIDC依赖方实现（授权码 + PKCE）
+type OIDCProvider struct {
+	config    *OIDCConfig
+	discovery *OIDCDiscovery
+	store     OIDCStateStore
+	client    *http.Client
+	stateTTL  time.Duration
+
+	mu   sync.RWMutex
+	keys map[string]any // kid -> 公钥
+}
+
+var (
+	oidcMu        sync.RWMutex
+	oidcProviders = map[string]*OIDCProvider{}
+)
+
+// NewOIDC 根据配置初始化并注册OIDC提供方
+func NewOIDC(ctx context.Context, cfgs []*OIDCConfig, client *redis.Client) error {
+	if client == nil {
+		return errors.New("redis client is nil")
+	}
+
+	store := NewRedisOIDCStateStore(client)
+	for _, cfg := range cfgs {
+		provider, err := NewOIDCProvider(ctx, cfg, store, nil)
+		if err != nil {
+			return fmt.Errorf("初始化OIDC提供方[%s]失败: %w", cfg.Name, err)
+		}
+		RegisterOIDCProvider(provider)
+	}
+	return nil
+}
+
+// RegisterOIDCProvider 注册OIDC提供方
+func RegisterOIDCProvider(provider *OIDCProvider) {
+	oidcMu.Lock()
+	defer oidcMu.Unlock()
+	oidcProviders[provider.config.Name] = provider
+}
+
+// GetOIDCProvider 获取OIDC提供方
+func GetOIDCProvider(name string) (*OIDCProvider, error) {
+	oidcMu.RLock()
+	defer oidcMu.RUnlock()
+	provider, ok := oidcProviders[name]
+	if !ok {
+		return nil, ErrOIDCProviderNotFound
+	}
+	return provider, nil
+}
+
+// NewOIDCProvider 创建OIDC提供方，会立即拉取发现文档
+func NewOIDCProvider(ctx context.Context, cfg *OIDCConfig, store OIDCStateStore, httpClient *http.Client) (*OIDCProvider, error) {
+	if cfg == nil || cfg.Name == "" || cfg.Issuer == "" || cfg.ClientID == "" {
+		return nil, errors.New("OIDC配置不完整: name、issuer、client_id 不能为空")
+	}
+	if store == nil {
+		return nil, errors.New("OIDC状态存储不能为空")
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	stateTTL := 10 * time.Minute
+	if cfg.StateTTL != "" {
+		ttl, err := time.ParseDuration(cfg.StateTTL)
+		if err != nil {
+			return nil, fmt.Errorf("解析state有效期失败: %w", err)
+		}
+		stateTTL = ttl
+	}
+
+	p := &OIDCProvider{
+		config:   cfg,
+		store:    store,
+		client:   httpClient,
+		stateTTL: stateTTL,
+		keys:     map[string]any{},
+	}
+
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Config 获取提供方配置
+func (p *OIDCProvider) Config() *OIDCConfig {
+	return p.config
+}
+
+// Discovery 获取发现文档
+func (p *OIDCProvider) Discovery() *OIDCDiscovery {
+	return p.discovery
+}
+
+// discover 拉取发现文档
+func (p *OIDCProvider) discover(ctx context.Context) error {
+	wellKnown := strings.TrimSuffix(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	var doc OIDCDiscovery
+	if err := p.getJSON(ctx, wellKnown, &doc); err != nil {
+		return fmt.Errorf("获取发现文档失败: %w", err)
+	}
+
+	// 发现文档中的issuer必须与配置一致，防止混淆攻击
+	if strings.TrimSuffix(doc.Issuer, "/") != strings.TrimSuffix(p.config.Issuer, "/") {
+		return fmt.Errorf("发现文档issuer不匹配: 期望 %s，实际 %s", p.config.Issuer, doc.Issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JwksURI == "" {
+		return errors.New("发现文档缺少必要的端点")
+	}
+
+	p.discovery = &doc
+	return nil
+}
+
+// AuthCodeURL 生成授权地址，并保存 state、nonce 与 PKCE code_verifier
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context) (string, error) {
+	state, err := randomString(32)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomString(32)
+	if err != nil {
+		return "", err
+	}
+	verifier, err := randomString(64)
+	if err != nil {
+		return "", err
+	}
+
+	if err = p.store.Save(ctx, state, &OIDCState{
+		Provider:     p.config.Name,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+	}, p.stateTTL); err != nil {
+		return "", fmt.Errorf("保存OIDC状态失败: %w", err)
+	}
+
+	scopes := p.config.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "profile", "email"}
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.config.ClientID)
+	params.Set("redirect_uri", p.config.RedirectURL)
+	params.Set("scope", strings.Join(scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", codeChallengeS256(verifier))
+	params.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(p.discovery.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.discovery.AuthorizationEndpoint + sep + params.Encode(), nil
+}
+
+// oidcTokenResponse 令牌端点响应
+type oidcTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	IDToken          string `json:"id_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange 使用授权码换取并校验ID Token
+func (p *OIDCProvider) Exchange(ctx context.Context, code, state string) (*OIDCIdentity, error) {
+	if code == "" || state == "" {
+		return nil, ErrOIDCStateInvalid
+	}
+
+	saved, err := p.store.Take(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if saved == nil || saved.Provider != p.config.Name {
+		return nil, ErrOIDCStateInvalid
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("client_id", p.config.ClientID)
+	form.Set("code_verifier", saved.CodeVerifier)
+	if p.config.ClientSecret != "" {
+		form.Set("client_secret", p.config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("创建令牌请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求令牌端点失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var token oidcTokenResponse
+	if err = json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("解析令牌响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || token.Error != "" {
+		return nil, fmt.Errorf("%w: %s %s", ErrOIDCExchange, token.Error, token.ErrorDescription)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: 响应中缺少id_token", ErrOIDCExchange)
+	}
+
+	return p.VerifyIDToken(ctx, token.IDToken, saved.Nonce)
+}
+
+// VerifyIDToken 校验ID Token签名、签发者、受众、有效期与nonce
+func (p *OIDCProvider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*OIDCIdentity, error) {
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(p.discovery.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(30*time.Second),
+	)
+
+	_, err := parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.publicKey(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCTokenInvalid, err)
+	}
+
+	if got, _ := claims["nonce"].(string); nonce == "" || got != nonce {
+		return nil, ErrOIDCNonceMismatch
+	}
+
+	identity := &OIDCIdentity{
+		Provider: p.config.Name,
+		Issuer:   p.discovery.Issuer,
+		Claims:   claims,
+	}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.EmailVerified, _ = claims["email_verified"].(bool)
+	identity.Name, _ = claims["name"].(string)
+	identity.PreferredUsername, _ = claims["preferred_username"].(string)
+	identity.Picture, _ = claims["picture"].(string)
+
+	if identity.Subject == "" {
+		return nil, fmt.Errorf("%w: 缺少sub声明", ErrOIDCTokenInvalid)
+	}
+	return identity, nil
+}
+
+// publicKey 根据kid获取公钥，未命中时刷新一次JWKS以支持密钥轮换
+func (p *OIDCProvider) publicKey(ctx context.Context, kid string) (any, error) {
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	if err := p.refreshKeys(ctx); err != nil {
+		return nil, err
+	}
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("未找到签名公钥: kid=%s", kid)
+}
+
+// lookupKey 查找公钥，kid为空且只有一把公钥时直接使用
+func (p *OIDCProvider) lookupKey(kid string) (any, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+// jsonWebKey JWKS中的单个密钥
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// refreshKeys 重新拉取JWKS
+func (p *OIDCProvider) refreshKeys(ctx context.Context) error {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, p.discovery.JwksURI, &set); err != nil {
+		return fmt.Errorf("获取JWKS失败: %w", err)
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = key
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+	return nil
+}
+
+// publicKey 将JWK转换为公钥
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("不支持的曲线: %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, fmt.Errorf("不支持的密钥类型: %s", k.Kty)
+	}
+}
+
+// getJSON 发起GET请求并解析JSON
+func (p *OIDCProvider) getJSON(ctx context.Context, endpoint string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, endpoint)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Resolve 根据声明匹配角色、部门和岗位
+func (c *OIDCProvisionConfig) Resolve(claims map[string]any) (roleID, deptID, postID int64) {
+	roleID, deptID, postID = c.DefaultRoleID, c.DefaultDeptID, c.DefaultPostID
+	for _, rule := range c.Rules {
+		if !claimContains(claims[rule.Claim], rule.Value) {
+			continue
+		}
+		if rule.RoleID != 0 {
+			roleID = rule.RoleID
+		}
+		if rule.DeptID != 0 {
+			deptID = rule.DeptID
+		}
+		if rule.PostID != 0 {
+			postID = rule.PostID
+		}
+		break
+	}
+	return roleID, deptID, postID
+}
+
+// claimContains 判断声明值是否等于或包含目标值
+func claimContains(value any, target string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == target
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == target {
+				return true
+			}
+		}
+	case []string:
+		for _, item := range v {
+			if item == target {
+				return true
+			}
+		}
+	case bool:
+		return fmt.Sprintf("%t", v) == target
+	case float64:
+		return fmt.Sprintf("%v", v) == target
+	}
+	return false
+}
+
+// codeChallengeS256 计算PKCE code_challenge
+func codeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// randomString 生成URL安全的随机字符串
+func randomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)[:n], nil
+}
+
+// redisOIDCStateStore 基于Redis的授权状态存储
+type redisOIDCStateStore struct {
+	client *redis.Client
+}
+
+// NewRedisOIDCStateStore 创建基于Redis的授权状态存储
+func NewRedisOIDCStateStore(client *redis.Client) OIDCStateStore {
+	return &redisOIDCStateStore{client: client}
+}
+
+// Save 保存状态
+func (s *redisOIDCStateStore) Save(ctx context.Context, state string, data *OIDCState, ttl time.Duration) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, fmt.Sprintf(OIDCStateCache, state), raw, ttl).Err()
+}
+
+// Take 取出并删除状态
+func (s *redisOIDCStateStore) Take(ctx context.Context, state string) (*OIDCState, error) {
+	raw, err := s.client.GetDel(ctx, fmt.Sprintf(OIDCStateCache, state)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrOIDCStateInvalid
+		}
+		return nil, fmt.Errorf("缓存查询失败: %w", err)
+	}
+
+	var data OIDCState
+	if err = json.Unmarshal(raw, &data); err != nil {
+		return nil, ErrOIDCStateInvalid
+	}
+	return &data, nil
+}
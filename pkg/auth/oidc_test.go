@@ -0,0 +1,270 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStateStore 测试用内存状态存储
+type memoryStateStore struct {
+	mu     sync.Mutex
+	states map[string]*OIDCState
+}
+
+func newMemoryStateStore() *memoryStateStore {
+	return &memoryStateStore{states: map[string]*OIDCState{}}
+}
+
+func (s *memoryStateStore) Save(_ context.Context, state string, data *OIDCState, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[state] = data
+	return nil
+}
+
+func (s *memoryStateStore) Take(_ context.Context, state string) (*OIDCState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.states[state]
+	if !ok {
+		return nil, ErrOIDCStateInvalid
+	}
+	delete(s.states, state)
+	return data, nil
+}
+
+// stubIdP 本地模拟的OIDC身份提供方
+type stubIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu         sync.Mutex
+	challenges map[string]string // code -> code_challenge
+	nonces     map[string]string // code -> nonce
+	audience   string
+	claims     jwt.MapClaims
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &stubIdP{
+		key:        key,
+		challenges: map[string]string{},
+		nonces:     map[string]string{},
+		audience:   "sweet-admin",
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(OIDCDiscovery{
+			Issuer:                idp.server.URL,
+			AuthorizationEndpoint: idp.server.URL + "/authorize",
+			TokenEndpoint:         idp.server.URL + "/token",
+			JwksURI:               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test-key",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		code := r.PostForm.Get("code")
+
+		idp.mu.Lock()
+		challenge, nonce := idp.challenges[code], idp.nonces[code]
+		delete(idp.challenges, code)
+		idp.mu.Unlock()
+
+		if challenge == "" || codeChallengeS256(r.PostForm.Get("code_verifier")) != challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		claims := jwt.MapClaims{
+			"iss":                idp.server.URL,
+			"sub":                "external-42",
+			"aud":                idp.audience,
+			"exp":                time.Now().Add(time.Minute).Unix(),
+			"iat":                time.Now().Unix(),
+			"nonce":              nonce,
+			"email":              "alice@example.com",
+			"email_verified":     true,
+			"preferred_username": "alice",
+			"groups":             []string{"ops", "admins"},
+		}
+		for k, v := range idp.claims {
+			claims[k] = v
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test-key"
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     signed,
+			"expires_in":   60,
+		})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// authorize 模拟用户在IdP完成登录，返回授权码
+func (idp *stubIdP) authorize(t *testing.T, authURL string) (code, state string) {
+	t.Helper()
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	q := u.Query()
+	require.Equal(t, "S256", q.Get("code_challenge_method"))
+
+	code = "code-" + q.Get("state")[:8]
+	idp.mu.Lock()
+	idp.challenges[code] = q.Get("code_challenge")
+	idp.nonces[code] = q.Get("nonce")
+	idp.mu.Unlock()
+	return code, q.Get("state")
+}
+
+func newTestProvider(t *testing.T, idp *stubIdP) *OIDCProvider {
+	t.Helper()
+	provider, err := NewOIDCProvider(context.Background(), &OIDCConfig{
+		Name:        "stub",
+		Issuer:      idp.server.URL,
+		ClientID:    "sweet-admin",
+		RedirectURL: "http://localhost/callback",
+	}, newMemoryStateStore(), idp.server.Client())
+	require.NoError(t, err)
+	return provider
+}
+
+func TestOIDCProvider_Exchange(t *testing.T) {
+	ctx := context.Background()
+	idp := newStubIdP(t)
+	provider := newTestProvider(t, idp)
+
+	authURL, err := provider.AuthCodeURL(ctx)
+	require.NoError(t, err)
+	code, state := idp.authorize(t, authURL)
+
+	identity, err := provider.Exchange(ctx, code, state)
+	require.NoError(t, err)
+	assert.Equal(t, "external-42", identity.Subject)
+	assert.Equal(t, "alice@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+	assert.Equal(t, "alice", identity.PreferredUsername)
+	assert.Equal(t, "stub", identity.Provider)
+
+	// state只能使用一次
+	_, err = provider.Exchange(ctx, code, state)
+	assert.ErrorIs(t, err, ErrOIDCStateInvalid)
+}
+
+func TestOIDCProvider_ExchangeRejectsInvalidTokens(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unknown state", func(t *testing.T) {
+		idp := newStubIdP(t)
+		provider := newTestProvider(t, idp)
+		_, err := provider.Exchange(ctx, "code", "missing")
+		assert.ErrorIs(t, err, ErrOIDCStateInvalid)
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		idp := newStubIdP(t)
+		idp.claims = jwt.MapClaims{"nonce": "forged"}
+		provider := newTestProvider(t, idp)
+
+		authURL, err := provider.AuthCodeURL(ctx)
+		require.NoError(t, err)
+		code, state := idp.authorize(t, authURL)
+		_, err = provider.Exchange(ctx, code, state)
+		assert.ErrorIs(t, err, ErrOIDCNonceMismatch)
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		idp := newStubIdP(t)
+		idp.audience = "another-client"
+		provider := newTestProvider(t, idp)
+
+		authURL, err := provider.AuthCodeURL(ctx)
+		require.NoError(t, err)
+		code, state := idp.authorize(t, authURL)
+		_, err = provider.Exchange(ctx, code, state)
+		assert.ErrorIs(t, err, ErrOIDCTokenInvalid)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		idp := newStubIdP(t)
+		idp.claims = jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}
+		provider := newTestProvider(t, idp)
+
+		authURL, err := provider.AuthCodeURL(ctx)
+		require.NoError(t, err)
+		code, state := idp.authorize(t, authURL)
+		_, err = provider.Exchange(ctx, code, state)
+		assert.ErrorIs(t, err, ErrOIDCTokenInvalid)
+	})
+
+	t.Run("pkce verifier mismatch", func(t *testing.T) {
+		idp := newStubIdP(t)
+		provider := newTestProvider(t, idp)
+
+		authURL, err := provider.AuthCodeURL(ctx)
+		require.NoError(t, err)
+		code, state := idp.authorize(t, authURL)
+		idp.challenges[code] = codeChallengeS256("another-verifier")
+		_, err = provider.Exchange(ctx, code, state)
+		assert.ErrorIs(t, err, ErrOIDCExchange)
+	})
+}
+
+func TestOIDCProvisionConfig_Resolve(t *testing.T) {
+	cfg := &OIDCProvisionConfig{
+		DefaultRoleID: 2,
+		DefaultDeptID: 1,
+		DefaultPostID: 1,
+		Rules: []OIDCClaimRule{
+			{Claim: "groups", Value: "admins", RoleID: 1},
+			{Claim: "department", Value: "finance", DeptID: 5, PostID: 3},
+		},
+	}
+
+	roleID, deptID, postID := cfg.Resolve(map[string]any{"groups": []any{"ops", "admins"}})
+	assert.Equal(t, []int64{1, 1, 1}, []int64{roleID, deptID, postID})
+
+	roleID, deptID, postID = cfg.Resolve(map[string]any{"department": "finance"})
+	assert.Equal(t, []int64{2, 5, 3}, []int64{roleID, deptID, postID})
+
+	roleID, deptID, postID = cfg.Resolve(map[string]any{})
+	assert.Equal(t, []int64{2, 1, 1}, []int64{roleID, deptID, postID})
+}
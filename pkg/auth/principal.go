@@ -0,0 +1,77 @@
+package auth
+
+import "context"
+
+// PrincipalKey gin.Context 中认证主体的键
+const PrincipalKey = "principal"
+
+// principalCtxKey context.Context 中认证主体的键
+type principalCtxKey struct{}
+
+// Principal 当前请求的认证主体，由认证中间件写入请求上下文
+type Principal struct {
+	// 用户ID，服务密钥为0
+	Uid int64
+	// 租户ID
+	TenantID int64
+	// 用户名
+	Username string
+	// 角色ID
+	Rid int64
+	// 模拟登录的管理员ID（非模拟登录为0）
+	ImpersonatorID int64
+	// API密钥ID（非密钥认证为0）
+	APIKeyID int64
+	// 认证方式（jwt,api_key）
+	AuthType string
+}
+
+// NewPrincipal 根据令牌声明创建认证主体
+func NewPrincipal(claims *Claims, authType string) *Principal {
+	tenantID := claims.TenantID
+	if tenantID == 0 {
+		// 启用多租户前签发的令牌不含租户，此前的数据均属于超级租户
+		tenantID = SuperTenantID
+	}
+	return &Principal{
+		Uid:            claims.Uid,
+		TenantID:       tenantID,
+		Username:       claims.Username,
+		Rid:            claims.Rid,
+		ImpersonatorID: claims.ImpersonatorID,
+		AuthType:       authType,
+	}
+}
+
+// WithPrincipal 将认证主体写入上下文
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey{}, p)
+}
+
+// PrincipalFrom 从上下文中读取认证主体，兼容直接传入 gin.Context 的情况
+func PrincipalFrom(ctx context.Context) (*Principal, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	if p, ok := ctx.Value(principalCtxKey{}).(*Principal); ok && p != nil {
+		return p, true
+	}
+	if p, ok := ctx.Value(PrincipalKey).(*Principal); ok && p != nil {
+		return p, true
+	}
+	return nil, false
+}
+
+// IsSuperTenant 是否属于超级租户
+func (p *Principal) IsSuperTenant() bool {
+	return p.TenantID == SuperTenantID
+}
+
+// UidFrom 从上下文中读取当前用户ID，未认证或服务密钥返回 false
+func UidFrom(ctx context.Context) (int64, bool) {
+	p, ok := PrincipalFrom(ctx)
+	if !ok || p.Uid == 0 {
+		return 0, false
+	}
+	return p.Uid, true
+}
@@ -0,0 +1,292 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sweet/pkg/crypto"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 请求签名相关请求头
+const (
+	// SignClientHeader 客户端标识
+	SignClientHeader = "X-Sw-Client"
+	// SignTimestampHeader 签名时间（秒级时间戳）
+	SignTimestampHeader = "X-Sw-Timestamp"
+	// SignNonceHeader 随机数，同一客户端在有效期内不可重复
+	SignNonceHeader = "X-Sw-Nonce"
+	// SignContentHashHeader 请求体SHA256（十六进制）
+	SignContentHashHeader = "X-Sw-Content-Sha256"
+	// SignatureHeader 签名（十六进制HMAC-SHA256）
+	SignatureHeader = "X-Sw-Signature"
+
+	signNonceLen = 32
+	// defaultSignMaxBodySize 默认请求体大小上限
+	defaultSignMaxBodySize = 4 << 20
+)
+
+// SignatureClient 签名客户端
+type SignatureClient struct {
+	ID     string `yaml:"id"`     // 客户端标识
+	Secret string `yaml:"secret"` // 签名密钥
+}
+
+// SignatureConfig 请求签名配置
+type SignatureConfig struct {
+	MaxSkew     string            `yaml:"max_skew"`      // 允许的时间偏差，默认5m
+	MaxBodySize int64             `yaml:"max_body_size"` // 请求体大小上限（字节），默认4MB，校验签名前读取请求体时生效
+	Clients     []SignatureClient `yaml:"clients"`       // 客户端列表
+}
+
+// NonceStore 随机数存储，用于防重放
+type NonceStore interface {
+	// Remember 记录随机数，已存在时返回false
+	Remember(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// SignatureVerifier 请求签名校验器
+type SignatureVerifier struct {
+	secrets map[string][]byte
+	nonces  NonceStore
+	maxSkew time.Duration
+	maxBody int64
+	now     func() time.Time
+}
+
+var (
+	localSignature *SignatureVerifier
+)
+
+// NewSignature 初始化全局请求签名校验器
+func NewSignature(cfg *SignatureConfig, client *redis.Client) error {
+	if client == nil {
+		return fmt.Errorf("redis client is nil")
+	}
+	verifier, err := NewSignatureVerifier(cfg, NewRedisNonceStore(client))
+	if err != nil {
+		return err
+	}
+	localSignature = verifier
+	return nil
+}
+
+// VerifySignature 使用全局校验器校验请求签名，返回客户端标识
+func VerifySignature(ctx context.Context, req *http.Request) (string, error) {
+	if localSignature == nil {
+		return "", fmt.Errorf("请求签名未初始化")
+	}
+	return localSignature.Verify(ctx, req)
+}
+
+// NewSignatureVerifier 创建请求签名校验器
+func NewSignatureVerifier(cfg *SignatureConfig, nonces NonceStore) (*SignatureVerifier, error) {
+	maxSkew := 5 * time.Minute
+	if cfg.MaxSkew != "" {
+		d, err := time.ParseDuration(cfg.MaxSkew)
+		if err != nil {
+			return nil, fmt.Errorf("解析签名时间偏差失败: %w", err)
+		}
+		maxSkew = d
+	}
+
+	secrets := make(map[string][]byte, len(cfg.Clients))
+	for _, client := range cfg.Clients {
+		if client.ID == "" || client.Secret == "" {
+			return nil, fmt.Errorf("签名客户端配置不完整: %q", client.ID)
+		}
+		secrets[client.ID] = []byte(client.Secret)
+	}
+
+	maxBody := cfg.MaxBodySize
+	if maxBody <= 0 {
+		maxBody = defaultSignMaxBodySize
+	}
+
+	return &SignatureVerifier{
+		secrets: secrets,
+		nonces:  nonces,
+		maxSkew: maxSkew,
+		maxBody: maxBody,
+		now:     time.Now,
+	}, nil
+}
+
+// Verify 校验请求签名，校验通过后请求体可被再次读取
+func (v *SignatureVerifier) Verify(ctx context.Context, req *http.Request) (string, error) {
+	clientID := req.Header.Get(SignClientHeader)
+	timestamp := req.Header.Get(SignTimestampHeader)
+	nonce := req.Header.Get(SignNonceHeader)
+	contentHash := req.Header.Get(SignContentHashHeader)
+	signature := req.Header.Get(SignatureHeader)
+	if clientID == "" || timestamp == "" || nonce == "" || contentHash == "" || signature == "" {
+		return "", ErrSignatureMissing
+	}
+
+	secret, ok := v.secrets[clientID]
+	if !ok {
+		return "", ErrSignatureClient
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return "", ErrSignatureExpired
+	}
+	if skew := v.now().Sub(time.Unix(ts, 0)); skew > v.maxSkew || skew < -v.maxSkew {
+		return "", ErrSignatureExpired
+	}
+
+	// 签名通过前请求体不可信，限制读取大小
+	body, err := readBody(req, v.maxBody)
+	if err != nil {
+		return "", err
+	}
+	if !hmac.Equal([]byte(hashBody(body)), []byte(strings.ToLower(contentHash))) {
+		return "", ErrSignatureBody
+	}
+
+	expected := signRequest(secret, canonicalRequest(req, clientID, timestamp, nonce, contentHash))
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return "", ErrSignatureInvalid
+	}
+
+	// 签名通过后再记录随机数，避免伪造请求占用随机数
+	// 有效期覆盖时间窗口两端，确保窗口内无法重放
+	fresh, err := v.nonces.Remember(ctx, fmt.Sprintf(SignatureNonceCache, clientID, nonce), 2*v.maxSkew)
+	if err != nil {
+		return "", fmt.Errorf("记录签名随机数失败: %w", err)
+	}
+	if !fresh {
+		return "", ErrSignatureReplay
+	}
+
+	return clientID, nil
+}
+
+// Signer 请求签名器，供调用方服务使用
+type Signer struct {
+	clientID string
+	secret   []byte
+	now      func() time.Time
+}
+
+// NewSigner 创建请求签名器
+func NewSigner(clientID, secret string) *Signer {
+	return &Signer{
+		clientID: clientID,
+		secret:   []byte(secret),
+		now:      time.Now,
+	}
+}
+
+// NewRequest 创建已签名的请求
+func (s *Signer) NewRequest(ctx context.Context, method, url string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if err = s.Sign(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// Sign 为请求添加签名头，签名后请求体仍可正常发送
+// 签名覆盖方法、路径、查询参数与请求体，签名之后不应再修改这些内容
+func (s *Signer) Sign(req *http.Request) error {
+	body, err := readBody(req, 0)
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	nonce := crypto.SaltWithLength(signNonceLen)
+	contentHash := hashBody(body)
+
+	req.Header.Set(SignClientHeader, s.clientID)
+	req.Header.Set(SignTimestampHeader, timestamp)
+	req.Header.Set(SignNonceHeader, nonce)
+	req.Header.Set(SignContentHashHeader, contentHash)
+	req.Header.Set(SignatureHeader, signRequest(s.secret, canonicalRequest(req, s.clientID, timestamp, nonce, contentHash)))
+	return nil
+}
+
+// canonicalRequest 构造规范请求
+// 格式：方法\n路径\n排序后的查询参数\n客户端标识\n时间戳\n随机数\n请求体哈希
+func canonicalRequest(req *http.Request, clientID, timestamp, nonce, contentHash string) string {
+	return strings.Join([]string{
+		strings.ToUpper(req.Method),
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		clientID,
+		timestamp,
+		nonce,
+		strings.ToLower(contentHash),
+	}, "\n")
+}
+
+// signRequest 计算HMAC-SHA256签名
+func signRequest(secret []byte, canonical string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(canonical))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// hashBody 计算请求体SHA256
+func hashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// readBody 读取请求体并回填，limit 大于0时超出返回 ErrSignatureBodyTooLarge
+func readBody(req *http.Request, limit int64) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	reader := req.Body
+	if limit > 0 {
+		reader = http.MaxBytesReader(nil, req.Body, limit)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, ErrSignatureBodyTooLarge
+		}
+		return nil, fmt.Errorf("读取请求体失败: %w", err)
+	}
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// redisNonceStore 基于Redis的随机数存储
+type redisNonceStore struct {
+	client *redis.Client
+}
+
+// NewRedisNonceStore 创建基于Redis的随机数存储
+func NewRedisNonceStore(client *redis.Client) NonceStore {
+	return &redisNonceStore{client: client}
+}
+
+func (s *redisNonceStore) Remember(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, key, 1, ttl).Result()
+}
@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryNonceStore 测试用内存随机数存储
+type memoryNonceStore struct {
+	mu   sync.Mutex
+	keys map[string]struct{}
+}
+
+func (s *memoryNonceStore) Remember(_ context.Context, key string, _ time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[key]; ok {
+		return false, nil
+	}
+	s.keys[key] = struct{}{}
+	return true, nil
+}
+
+func newTestVerifier(t *testing.T) *SignatureVerifier {
+	t.Helper()
+	verifier, err := NewSignatureVerifier(&SignatureConfig{
+		MaxSkew:     "1m",
+		MaxBodySize: 64,
+		Clients:     []SignatureClient{{ID: "billing", Secret: "billing-secret"}},
+	}, &memoryNonceStore{keys: map[string]struct{}{}})
+	require.NoError(t, err)
+	return verifier
+}
+
+func newSignedRequest(t *testing.T, signer *Signer) *http.Request {
+	t.Helper()
+	req, err := signer.NewRequest(context.Background(), http.MethodPost,
+		"http://admin.local/api/v1/users?b=2&a=1", []byte(`{"name":"alice"}`))
+	require.NoError(t, err)
+	return req
+}
+
+func TestSignatureVerifier_Verify(t *testing.T) {
+	ctx := context.Background()
+	verifier := newTestVerifier(t)
+	req := newSignedRequest(t, NewSigner("billing", "billing-secret"))
+
+	clientID, err := verifier.Verify(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "billing", clientID)
+
+	// 校验后请求体仍可读取
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"alice"}`, string(body))
+
+	// 同一请求不能重放
+	req.Body, err = req.GetBody()
+	require.NoError(t, err)
+	_, err = verifier.Verify(ctx, req)
+	assert.ErrorIs(t, err, ErrSignatureReplay)
+}
+
+func TestSignatureVerifier_Rejects(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name   string
+		signer *Signer
+		tamper func(req *http.Request)
+		err    error
+	}{
+		{
+			name:   "missing header",
+			signer: NewSigner("billing", "billing-secret"),
+			tamper: func(req *http.Request) { req.Header.Del(SignatureHeader) },
+			err:    ErrSignatureMissing,
+		},
+		{
+			name:   "unknown client",
+			signer: NewSigner("unknown", "billing-secret"),
+			err:    ErrSignatureClient,
+		},
+		{
+			name:   "wrong secret",
+			signer: NewSigner("billing", "another-secret"),
+			err:    ErrSignatureInvalid,
+		},
+		{
+			name:   "expired timestamp",
+			signer: &Signer{clientID: "billing", secret: []byte("billing-secret"), now: func() time.Time { return time.Now().Add(-2 * time.Minute) }},
+			err:    ErrSignatureExpired,
+		},
+		{
+			name:   "tampered body",
+			signer: NewSigner("billing", "billing-secret"),
+			tamper: func(req *http.Request) {
+				req.Body = io.NopCloser(http.NoBody)
+			},
+			err: ErrSignatureBody,
+		},
+		{
+			name:   "body too large",
+			signer: NewSigner("billing", "billing-secret"),
+			tamper: func(req *http.Request) {
+				req.Body = io.NopCloser(strings.NewReader(strings.Repeat("a", 65)))
+			},
+			err: ErrSignatureBodyTooLarge,
+		},
+		{
+			name:   "tampered query",
+			signer: NewSigner("billing", "billing-secret"),
+			tamper: func(req *http.Request) { req.URL.RawQuery = "a=1&b=3" },
+			err:    ErrSignatureInvalid,
+		},
+		{
+			name:   "tampered method",
+			signer: NewSigner("billing", "billing-secret"),
+			tamper: func(req *http.Request) { req.Method = http.MethodDelete },
+			err:    ErrSignatureInvalid,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := newSignedRequest(t, c.signer)
+			if c.tamper != nil {
+				c.tamper(req)
+			}
+			_, err := newTestVerifier(t).Verify(ctx, req)
+			assert.ErrorIs(t, err, c.err)
+		})
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// SuperTenantID 超级租户ID，其用户可跨租户操作，迁移前的数据均归属该租户
+const SuperTenantID int64 = 1
+
 // UserType 用户类型
 type UserType string
 
@@ -16,11 +19,19 @@ const (
 	BackendUser UserType = "backend"
 	// TokenCache 令牌缓存
 	TokenCache = "token::%s::%d::%s::%d::%s" // 用户类型 Token类型 用户ID 用户名 角色ID 设备类型
+	// OIDCStateCache OIDC授权状态缓存
+	OIDCStateCache = "oidc::state::%s" // state
+	// SignatureNonceCache 请求签名随机数缓存
+	SignatureNonceCache = "signature::nonce::%s::%s" // 客户端标识 随机数
+	// LoginNonceCache 登录密码密文随机数缓存
+	LoginNonceCache = "login::nonce::%s" // 随机数
 )
 
 type Claims struct {
 	// 用户ID
 	Uid int64 `json:"uid"`
+	// 租户ID
+	TenantID int64 `json:"tid,omitempty"`
 	// 用户名
 	Username string `json:"username"`
 	// 角色ID
@@ -31,6 +42,12 @@ type Claims struct {
 	DeviceType string `json:"device_type"`
 	// 用户类型（frontend,backend）
 	UserType UserType `json:"user_type"`
+	// 是否为模拟登录（供前端展示提示）
+	Impersonated bool `json:"impersonated,omitempty"`
+	// 模拟登录时发起操作的管理员ID
+	ImpersonatorID int64 `json:"impersonator_id,omitempty"`
+	// 是否需要先修改密码，为 true 时仅可访问修改密码、退出登录等接口
+	PasswordReset bool `json:"password_reset,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -54,4 +71,36 @@ var (
 	ErrUserType = errors.New("错误的用户类型")
 	// ErrUserAlreadyLogin 用户已在其他设备登录
 	ErrUserAlreadyLogin = errors.New("用户已在其他设备登录")
+
+	// ErrOIDCProviderNotFound OIDC提供方不存在
+	ErrOIDCProviderNotFound = errors.New("OIDC提供方不存在")
+	// ErrOIDCStateInvalid state无效或已过期
+	ErrOIDCStateInvalid = errors.New("OIDC state无效或已过期")
+	// ErrOIDCNonceMismatch nonce不匹配
+	ErrOIDCNonceMismatch = errors.New("OIDC nonce不匹配")
+	// ErrOIDCTokenInvalid ID Token无效
+	ErrOIDCTokenInvalid = errors.New("OIDC ID Token无效")
+	// ErrOIDCExchange 授权码换取令牌失败
+	ErrOIDCExchange = errors.New("OIDC授权码换取令牌失败")
+
+	// ErrImpersonationTTL 模拟登录有效期无效
+	ErrImpersonationTTL = errors.New("模拟登录有效期无效")
+
+	// ErrAPIKeyFormat API密钥格式错误
+	ErrAPIKeyFormat = errors.New("API密钥格式错误")
+
+	// ErrSignatureMissing 缺少签名信息
+	ErrSignatureMissing = errors.New("缺少请求签名信息")
+	// ErrSignatureClient 未知的签名客户端
+	ErrSignatureClient = errors.New("未知的签名客户端")
+	// ErrSignatureExpired 签名时间超出允许范围
+	ErrSignatureExpired = errors.New("请求签名已过期")
+	// ErrSignatureBody 请求体哈希不匹配
+	ErrSignatureBody = errors.New("请求体哈希不匹配")
+	// ErrSignatureInvalid 签名无效
+	ErrSignatureInvalid = errors.New("请求签名无效")
+	// ErrSignatureReplay 重放请求
+	ErrSignatureReplay = errors.New("重复的请求签名")
+	// ErrSignatureBodyTooLarge 请求体超出大小上限
+	ErrSignatureBodyTooLarge = errors.New("请求体超出大小上限")
 )
@@ -0,0 +1,141 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Algorithm 对称加密算法
+type Algorithm byte
+
+const (
+	// AES256GCM AES-256-GCM
+	AES256GCM Algorithm = 1
+	// ChaCha20Poly1305 ChaCha20-Poly1305
+	ChaCha20Poly1305 Algorithm = 2
+)
+
+// envelopeVersion 密文信封版本
+// 信封格式：版本(1字节) | 算法(1字节) | nonce | 密文+认证标签
+const envelopeVersion byte = 1
+
+// KeySize 对称加密密钥长度（字节）
+const KeySize = 32
+
+var (
+	// ErrCipherKey 密钥长度错误
+	ErrCipherKey = errors.New("密钥长度必须为32字节")
+	// ErrCipherAlgorithm 不支持的加密算法
+	ErrCipherAlgorithm = errors.New("不支持的加密算法")
+	// ErrCipherVersion 不支持的密文版本
+	ErrCipherVersion = errors.New("不支持的密文版本")
+	// ErrCipherText 密文格式错误或认证失败
+	ErrCipherText = errors.New("密文无效")
+)
+
+// String 算法名称
+func (a Algorithm) String() string {
+	switch a {
+	case AES256GCM:
+		return "AES-256-GCM"
+	case ChaCha20Poly1305:
+		return "ChaCha20-Poly1305"
+	default:
+		return fmt.Sprintf("Algorithm(%d)", byte(a))
+	}
+}
+
+// newAEAD 根据算法创建AEAD
+func newAEAD(alg Algorithm, key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, ErrCipherKey
+	}
+	switch alg {
+	case AES256GCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	case ChaCha20Poly1305:
+		return chacha20poly1305.New(key)
+	default:
+		return nil, ErrCipherAlgorithm
+	}
+}
+
+// Encrypt 使用认证加密算法加密数据，返回带版本和算法标识的密文信封
+// aad 为附加认证数据，不加密但参与认证，解密时必须一致
+func Encrypt(alg Algorithm, key, plaintext, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(alg, key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := []byte{envelopeVersion, byte(alg)}
+	nonce, err := RandomBytes(aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(header)+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, header...)
+	out = append(out, nonce...)
+	// 信封头同时作为附加数据，防止篡改版本或算法
+	return aead.Seal(out, nonce, plaintext, append(header, aad...)), nil
+}
+
+// Decrypt 解密 Encrypt 生成的密文信封，算法从信封头读取
+func Decrypt(key, ciphertext, aad []byte) ([]byte, error) {
+	if len(ciphertext) < 2 {
+		return nil, ErrCipherText
+	}
+	if ciphertext[0] != envelopeVersion {
+		return nil, ErrCipherVersion
+	}
+
+	header := ciphertext[:2]
+	aead, err := newAEAD(Algorithm(ciphertext[1]), key)
+	if err != nil {
+		return nil, err
+	}
+
+	body := ciphertext[2:]
+	if len(body) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrCipherText
+	}
+	nonce, sealed := body[:aead.NonceSize()], body[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, sealed, append(header[:2:2], aad...))
+	if err != nil {
+		return nil, ErrCipherText
+	}
+	return plaintext, nil
+}
+
+// EncryptString 加密字符串，返回URL安全的Base64密文
+func EncryptString(alg Algorithm, key []byte, plaintext string) (string, error) {
+	ciphertext, err := Encrypt(alg, key, []byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// DecryptString 解密 EncryptString 生成的密文
+func DecryptString(key []byte, ciphertext string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", ErrCipherText
+	}
+	plaintext, err := Decrypt(key, raw, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,103 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCipherKey = bytes.Repeat([]byte{0x42}, KeySize)
+
+func TestEncrypt_RoundTrip(t *testing.T) {
+	for _, alg := range []Algorithm{AES256GCM, ChaCha20Poly1305} {
+		t.Run(alg.String(), func(t *testing.T) {
+			for _, plaintext := range [][]byte{nil, []byte("a"), bytes.Repeat([]byte("sweet"), 1000)} {
+				ciphertext, err := Encrypt(alg, testCipherKey, plaintext, []byte("uid:1"))
+				require.NoError(t, err)
+				assert.Equal(t, envelopeVersion, ciphertext[0])
+				assert.Equal(t, byte(alg), ciphertext[1])
+
+				got, err := Decrypt(testCipherKey, ciphertext, []byte("uid:1"))
+				require.NoError(t, err)
+				assert.Equal(t, string(plaintext), string(got))
+			}
+
+			s, err := EncryptString(alg, testCipherKey, "你好，sweet")
+			require.NoError(t, err)
+			got, err := DecryptString(testCipherKey, s)
+			require.NoError(t, err)
+			assert.Equal(t, "你好，sweet", got)
+		})
+	}
+}
+
+func TestDecrypt_Rejects(t *testing.T) {
+	ciphertext, err := Encrypt(AES256GCM, testCipherKey, []byte("secret"), []byte("aad"))
+	require.NoError(t, err)
+
+	_, err = Decrypt(testCipherKey, ciphertext, []byte("other"))
+	assert.ErrorIs(t, err, ErrCipherText)
+
+	_, err = Decrypt(bytes.Repeat([]byte{0x01}, KeySize), ciphertext, []byte("aad"))
+	assert.ErrorIs(t, err, ErrCipherText)
+
+	tampered := bytes.Clone(ciphertext)
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = Decrypt(testCipherKey, tampered, []byte("aad"))
+	assert.ErrorIs(t, err, ErrCipherText)
+
+	// 篡改算法标识
+	swapped := bytes.Clone(ciphertext)
+	swapped[1] = byte(ChaCha20Poly1305)
+	_, err = Decrypt(testCipherKey, swapped, []byte("aad"))
+	assert.ErrorIs(t, err, ErrCipherText)
+
+	versioned := bytes.Clone(ciphertext)
+	versioned[0] = 9
+	_, err = Decrypt(testCipherKey, versioned, []byte("aad"))
+	assert.ErrorIs(t, err, ErrCipherVersion)
+
+	_, err = Encrypt(Algorithm(9), testCipherKey, nil, nil)
+	assert.ErrorIs(t, err, ErrCipherAlgorithm)
+	_, err = Encrypt(AES256GCM, []byte("short"), nil, nil)
+	assert.ErrorIs(t, err, ErrCipherKey)
+	_, err = DecryptString(testCipherKey, "!!")
+	assert.ErrorIs(t, err, ErrCipherText)
+}
+
+func FuzzEncryptRoundTrip(f *testing.F) {
+	f.Add([]byte("hello"), []byte("aad"), true)
+	f.Add([]byte{}, []byte{}, false)
+	f.Fuzz(func(t *testing.T, plaintext, aad []byte, chacha bool) {
+		alg := AES256GCM
+		if chacha {
+			alg = ChaCha20Poly1305
+		}
+		ciphertext, err := Encrypt(alg, testCipherKey, plaintext, aad)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Decrypt(testCipherKey, ciphertext, aad)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, plaintext) {
+			t.Fatalf("round trip mismatch: %x != %x", got, plaintext)
+		}
+	})
+}
+
+func FuzzDecrypt(f *testing.F) {
+	valid, _ := Encrypt(AES256GCM, testCipherKey, []byte("seed"), nil)
+	f.Add(valid)
+	f.Add([]byte{1, 2})
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, ciphertext []byte) {
+		// 任意输入都不能panic，且除种子密文外不能通过认证
+		if plaintext, err := Decrypt(testCipherKey, ciphertext, nil); err == nil && string(plaintext) != "seed" {
+			t.Fatalf("forged ciphertext accepted: %x -> %q", ciphertext, plaintext)
+		}
+	})
+}
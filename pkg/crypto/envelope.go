@@ -0,0 +1,198 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// EnvelopeAlgorithm 密码信封算法，与浏览器 WebCrypto 的 RSA-OAEP + SHA-256 对应
+const EnvelopeAlgorithm = "RSA-OAEP-256"
+
+var (
+	// ErrEnvelopeNotInit 密码信封未初始化
+	ErrEnvelopeNotInit = errors.New("密码信封未初始化")
+	// ErrEnvelopeInvalid 密文无法解密或格式错误
+	ErrEnvelopeInvalid = errors.New("密码密文无效")
+	// ErrEnvelopeExpired 密文时间超出允许范围
+	ErrEnvelopeExpired = errors.New("密码密文已过期")
+)
+
+// EnvelopeConfig 密码信封配置
+type EnvelopeConfig struct {
+	PrivateKey string `json:"private_key" yaml:"private_key"` // PEM格式RSA私钥，为空时启动时生成，多实例部署需配置相同私钥
+	KeyBits    int    `json:"key_bits" yaml:"key_bits"`       // 自动生成私钥的长度，默认2048
+	MaxSkew    string `json:"max_skew" yaml:"max_skew"`       // 允许的时间偏差，默认5m
+}
+
+// EnvelopePublicKey 下发给客户端的公钥
+type EnvelopePublicKey struct {
+	KeyID     string `json:"key_id"`     // 公钥指纹
+	Algorithm string `json:"algorithm"`  // 加密算法
+	PublicKey string `json:"public_key"` // PEM格式公钥（SPKI）
+}
+
+// EnvelopePayload 客户端加密前的明文内容
+// 客户端将其序列化为JSON后使用公钥加密，再进行Base64编码
+type EnvelopePayload struct {
+	Password  string `json:"password"`  // 明文密码
+	Timestamp int64  `json:"timestamp"` // 加密时间（毫秒级时间戳）
+	Nonce     string `json:"nonce"`     // 随机数，用于防重放
+}
+
+// Envelope 密码信封，用于客户端加密传输密码
+type Envelope struct {
+	key       *rsa.PrivateKey
+	publicKey *EnvelopePublicKey
+	maxSkew   time.Duration
+	now       func() time.Time
+}
+
+var localEnvelope atomic.Pointer[Envelope]
+
+// NewPasswordEnvelope 初始化全局密码信封
+func NewPasswordEnvelope(cfg *EnvelopeConfig) error {
+	e, err := NewEnvelope(cfg)
+	if err != nil {
+		return err
+	}
+	localEnvelope.Store(e)
+	return nil
+}
+
+// GetEnvelope 获取全局密码信封
+func GetEnvelope() (*Envelope, error) {
+	e := localEnvelope.Load()
+	if e == nil {
+		return nil, ErrEnvelopeNotInit
+	}
+	return e, nil
+}
+
+// NewEnvelope 创建密码信封
+func NewEnvelope(cfg *EnvelopeConfig) (*Envelope, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("envelope config is nil")
+	}
+
+	maxSkew := 5 * time.Minute
+	if cfg.MaxSkew != "" {
+		d, err := time.ParseDuration(cfg.MaxSkew)
+		if err != nil {
+			return nil, fmt.Errorf("解析密码信封时间偏差失败: %w", err)
+		}
+		maxSkew = d
+	}
+
+	var (
+		key *rsa.PrivateKey
+		err error
+	)
+	if cfg.PrivateKey != "" {
+		key, err = parseRSAPrivateKey(cfg.PrivateKey)
+	} else {
+		bits := cfg.KeyBits
+		if bits == 0 {
+			bits = 2048
+		}
+		key, err = rsa.GenerateKey(rand.Reader, bits)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if key.N.BitLen() < 2048 {
+		return nil, fmt.Errorf("RSA私钥长度不能少于2048位")
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("导出公钥失败: %w", err)
+	}
+	fingerprint := sha256.Sum256(der)
+
+	return &Envelope{
+		key: key,
+		publicKey: &EnvelopePublicKey{
+			KeyID:     hex.EncodeToString(fingerprint[:8]),
+			Algorithm: EnvelopeAlgorithm,
+			PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+		},
+		maxSkew: maxSkew,
+		now:     time.Now,
+	}, nil
+}
+
+// PublicKey 获取下发给客户端的公钥
+func (e *Envelope) PublicKey() EnvelopePublicKey {
+	return *e.publicKey
+}
+
+// MaxSkew 获取允许的时间偏差，防重放记录的有效期应不少于其两倍
+func (e *Envelope) MaxSkew() time.Duration {
+	return e.maxSkew
+}
+
+// Seal 使用公钥加密，供测试及服务端调用方使用
+func (e *Envelope) Seal(payload *EnvelopePayload) (string, error) {
+	plaintext, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, &e.key.PublicKey, plaintext, nil)
+	if err != nil {
+		return "", fmt.Errorf("加密密码失败: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Open 解密客户端密文并校验时间戳
+// 随机数的唯一性需由调用方结合缓存校验
+func (e *Envelope) Open(ciphertext string) (*EnvelopePayload, error) {
+	raw, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, ErrEnvelopeInvalid
+	}
+	plaintext, err := rsa.DecryptOAEP(sha256.New(), nil, e.key, raw, nil)
+	if err != nil {
+		return nil, ErrEnvelopeInvalid
+	}
+
+	var payload EnvelopePayload
+	if err = json.Unmarshal(plaintext, &payload); err != nil || payload.Nonce == "" || payload.Timestamp == 0 {
+		return nil, ErrEnvelopeInvalid
+	}
+
+	if skew := e.now().Sub(time.UnixMilli(payload.Timestamp)); skew > e.maxSkew || skew < -e.maxSkew {
+		return nil, ErrEnvelopeExpired
+	}
+	return &payload, nil
+}
+
+// parseRSAPrivateKey 解析PEM格式RSA私钥，支持PKCS#1与PKCS#8
+func parseRSAPrivateKey(data string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("解析RSA私钥失败: 非PEM格式")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("解析RSA私钥失败: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("解析RSA私钥失败: 非RSA私钥")
+	}
+	return rsaKey, nil
+}
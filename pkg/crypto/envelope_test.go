@@ -0,0 +1,82 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelope_OpenSealed(t *testing.T) {
+	e, err := NewEnvelope(&EnvelopeConfig{MaxSkew: "1m"})
+	require.NoError(t, err)
+
+	pub := e.PublicKey()
+	assert.Equal(t, EnvelopeAlgorithm, pub.Algorithm)
+	assert.Len(t, pub.KeyID, 16)
+	block, _ := pem.Decode([]byte(pub.PublicKey))
+	require.NotNil(t, block)
+	assert.Equal(t, "PUBLIC KEY", block.Type)
+
+	ciphertext, err := e.Seal(&EnvelopePayload{Password: "p@ssw0rd", Timestamp: time.Now().UnixMilli(), Nonce: "n1"})
+	require.NoError(t, err)
+
+	payload, err := e.Open(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "p@ssw0rd", payload.Password)
+	assert.Equal(t, "n1", payload.Nonce)
+}
+
+func TestEnvelope_Rejects(t *testing.T) {
+	e, err := NewEnvelope(&EnvelopeConfig{MaxSkew: "1m"})
+	require.NoError(t, err)
+
+	expired, err := e.Seal(&EnvelopePayload{Password: "p", Timestamp: time.Now().Add(-2 * time.Minute).UnixMilli(), Nonce: "n"})
+	require.NoError(t, err)
+	_, err = e.Open(expired)
+	assert.ErrorIs(t, err, ErrEnvelopeExpired)
+
+	noNonce, err := e.Seal(&EnvelopePayload{Password: "p", Timestamp: time.Now().UnixMilli()})
+	require.NoError(t, err)
+	_, err = e.Open(noNonce)
+	assert.ErrorIs(t, err, ErrEnvelopeInvalid)
+
+	// 其他密钥加密的密文无法解密
+	other, err := NewEnvelope(&EnvelopeConfig{})
+	require.NoError(t, err)
+	foreign, err := other.Seal(&EnvelopePayload{Password: "p", Timestamp: time.Now().UnixMilli(), Nonce: "n"})
+	require.NoError(t, err)
+	_, err = e.Open(foreign)
+	assert.ErrorIs(t, err, ErrEnvelopeInvalid)
+
+	_, err = e.Open("not base64!")
+	assert.ErrorIs(t, err, ErrEnvelopeInvalid)
+}
+
+func TestEnvelope_PrivateKeyPEM(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	data := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	a, err := NewEnvelope(&EnvelopeConfig{PrivateKey: data})
+	require.NoError(t, err)
+	b, err := NewEnvelope(&EnvelopeConfig{PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))})
+	require.NoError(t, err)
+
+	// 相同私钥的实例可互相解密，公钥指纹一致
+	assert.Equal(t, a.PublicKey(), b.PublicKey())
+	ciphertext, err := a.Seal(&EnvelopePayload{Password: "p", Timestamp: time.Now().UnixMilli(), Nonce: "n"})
+	require.NoError(t, err)
+	_, err = b.Open(ciphertext)
+	assert.NoError(t, err)
+
+	_, err = NewEnvelope(&EnvelopeConfig{PrivateKey: "invalid"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,193 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// fieldCipherPrefix 加密字段前缀，格式为 enc:v1:<密钥ID>:<base64(nonce+密文)>
+const fieldCipherPrefix = "enc:v1:"
+
+var (
+	// ErrFieldCipherNotInit 字段加密未初始化
+	ErrFieldCipherNotInit = errors.New("字段加密未初始化")
+	// ErrFieldCipherKey 未知的字段加密密钥
+	ErrFieldCipherKey = errors.New("未知的字段加密密钥")
+	// ErrFieldCipherText 密文格式错误
+	ErrFieldCipherText = errors.New("字段密文格式错误")
+)
+
+// FieldKey 字段加密密钥
+type FieldKey struct {
+	ID  string `json:"id" yaml:"id"`   // 密钥ID，写入密文用于轮换
+	Key string `json:"key" yaml:"key"` // Base64编码的密钥，长度16/24/32字节
+}
+
+// FieldConfig 字段加密配置
+type FieldConfig struct {
+	ActiveKey string     `json:"active_key" yaml:"active_key"` // 当前用于加密的密钥ID
+	Keys      []FieldKey `json:"keys" yaml:"keys"`             // 全部密钥，旧密钥仅用于解密
+	IndexKey  string     `json:"index_key" yaml:"index_key"`   // Base64编码的盲索引密钥
+}
+
+// FieldCipher 字段加密器
+// 使用AES-GCM加密字段值，使用HMAC-SHA256生成盲索引用于等值查询
+type FieldCipher struct {
+	aeads    map[string]cipher.AEAD
+	active   string
+	indexKey []byte
+}
+
+var localFieldCipher atomic.Pointer[FieldCipher]
+
+// NewFieldCrypto 初始化全局字段加密器，供 encrypted 序列化器使用
+func NewFieldCrypto(cfg *FieldConfig) error {
+	c, err := NewFieldCipher(cfg)
+	if err != nil {
+		return err
+	}
+	localFieldCipher.Store(c)
+	return nil
+}
+
+// GetFieldCipher 获取全局字段加密器
+func GetFieldCipher() (*FieldCipher, error) {
+	c := localFieldCipher.Load()
+	if c == nil {
+		return nil, ErrFieldCipherNotInit
+	}
+	return c, nil
+}
+
+// NewFieldCipher 创建字段加密器
+func NewFieldCipher(cfg *FieldConfig) (*FieldCipher, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("field config is nil")
+	}
+
+	aeads := make(map[string]cipher.AEAD, len(cfg.Keys))
+	for _, k := range cfg.Keys {
+		if k.ID == "" || strings.Contains(k.ID, ":") {
+			return nil, fmt.Errorf("字段加密密钥ID无效: %q", k.ID)
+		}
+		if _, ok := aeads[k.ID]; ok {
+			return nil, fmt.Errorf("字段加密密钥ID重复: %q", k.ID)
+		}
+		raw, err := base64.StdEncoding.DecodeString(k.Key)
+		if err != nil {
+			return nil, fmt.Errorf("解析字段加密密钥 %q 失败: %w", k.ID, err)
+		}
+		block, err := aes.NewCipher(raw)
+		if err != nil {
+			return nil, fmt.Errorf("字段加密密钥 %q 无效: %w", k.ID, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		aeads[k.ID] = aead
+	}
+	if _, ok := aeads[cfg.ActiveKey]; !ok {
+		return nil, fmt.Errorf("当前字段加密密钥 %q 不存在", cfg.ActiveKey)
+	}
+
+	indexKey, err := base64.StdEncoding.DecodeString(cfg.IndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("解析盲索引密钥失败: %w", err)
+	}
+	if len(indexKey) < 16 {
+		return nil, fmt.Errorf("盲索引密钥长度不能少于16字节")
+	}
+
+	return &FieldCipher{
+		aeads:    aeads,
+		active:   cfg.ActiveKey,
+		indexKey: indexKey,
+	}, nil
+}
+
+// Encrypt 使用当前密钥加密字段值
+func (c *FieldCipher) Encrypt(plaintext string) (string, error) {
+	aead := c.aeads[c.active]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+	// 密钥ID作为附加数据，防止密文被挪用到其他密钥下
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(c.active))
+	return fieldCipherPrefix + c.active + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 解密字段值，未加密的历史数据原样返回
+func (c *FieldCipher) Decrypt(value string) (string, error) {
+	keyID, payload, ok := c.split(value)
+	if !ok {
+		return value, nil
+	}
+	aead, found := c.aeads[keyID]
+	if !found {
+		return "", fmt.Errorf("%w: %s", ErrFieldCipherKey, keyID)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrFieldCipherText
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(keyID))
+	if err != nil {
+		return "", ErrFieldCipherText
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation 判断字段值是否需要使用当前密钥重新加密
+// 明文或使用旧密钥加密的值返回true
+func (c *FieldCipher) NeedsRotation(value string) bool {
+	keyID, _, ok := c.split(value)
+	return !ok || keyID != c.active
+}
+
+// BlindIndex 计算字段值的盲索引（十六进制HMAC-SHA256）
+// 计算前去除首尾空白并转为小写，相同明文得到相同索引，可用于唯一约束和等值查询
+func (c *FieldCipher) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write([]byte(normalizeIndexValue(value)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// normalizeIndexValue 规范化盲索引输入，邮箱、手机号、IP 均不区分大小写
+func normalizeIndexValue(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// split 拆分密文中的密钥ID和数据
+func (c *FieldCipher) split(value string) (keyID, payload string, ok bool) {
+	rest, found := strings.CutPrefix(value, fieldCipherPrefix)
+	if !found {
+		return "", "", false
+	}
+	keyID, payload, found = strings.Cut(rest, ":")
+	return keyID, payload, found
+}
+
+// BlindIndex 使用全局字段加密器计算盲索引，空值返回nil
+// 字段加密未初始化时返回 ErrFieldCipherNotInit，避免调用方在没有索引时跳过唯一性校验或查询条件
+func BlindIndex(value *string) (*string, error) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil, nil
+	}
+	c, err := GetFieldCipher()
+	if err != nil {
+		return nil, err
+	}
+	index := c.BlindIndex(*value)
+	return &index, nil
+}
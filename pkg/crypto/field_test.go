@@ -0,0 +1,158 @@
+package crypto
+
+import (
+	"context"
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), 32)))
+}
+
+func newTestFieldCipher(t *testing.T, active string) *FieldCipher {
+	t.Helper()
+	c, err := NewFieldCipher(&FieldConfig{
+		ActiveKey: active,
+		Keys: []FieldKey{
+			{ID: "k1", Key: testKey('a')},
+			{ID: "k2", Key: testKey('b')},
+		},
+		IndexKey: testKey('i'),
+	})
+	require.NoError(t, err)
+	return c
+}
+
+func TestFieldCipher_RoundTrip(t *testing.T) {
+	c := newTestFieldCipher(t, "k1")
+
+	enc, err := c.Encrypt("13800138000")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(enc, "enc:v1:k1:"))
+	assert.NotContains(t, enc, "13800138000")
+
+	// 相同明文每次加密结果不同
+	enc2, err := c.Encrypt("13800138000")
+	require.NoError(t, err)
+	assert.NotEqual(t, enc, enc2)
+
+	dec, err := c.Decrypt(enc)
+	require.NoError(t, err)
+	assert.Equal(t, "13800138000", dec)
+
+	// 历史明文数据原样返回
+	dec, err = c.Decrypt("alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", dec)
+}
+
+func TestFieldCipher_Rotation(t *testing.T) {
+	old := newTestFieldCipher(t, "k1")
+	enc, err := old.Encrypt("alice@example.com")
+	require.NoError(t, err)
+
+	current := newTestFieldCipher(t, "k2")
+	assert.True(t, current.NeedsRotation(enc))
+	assert.True(t, current.NeedsRotation("alice@example.com"))
+
+	dec, err := current.Decrypt(enc)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", dec)
+
+	reenc, err := current.Encrypt(dec)
+	require.NoError(t, err)
+	assert.False(t, current.NeedsRotation(reenc))
+
+	// 盲索引与加密密钥无关，轮换后保持不变
+	assert.Equal(t, old.BlindIndex("alice@example.com"), current.BlindIndex("alice@example.com"))
+	assert.NotEqual(t, current.BlindIndex("alice@example.com"), current.BlindIndex("bob@example.com"))
+	// 忽略首尾空白与大小写
+	assert.Equal(t, current.BlindIndex("alice@example.com"), current.BlindIndex(" Alice@Example.COM "))
+}
+
+func TestFieldCipher_Rejects(t *testing.T) {
+	c := newTestFieldCipher(t, "k1")
+	enc, err := c.Encrypt("secret")
+	require.NoError(t, err)
+
+	_, err = c.Decrypt(strings.Replace(enc, "enc:v1:k1:", "enc:v1:k3:", 1))
+	assert.ErrorIs(t, err, ErrFieldCipherKey)
+
+	// 将k1密文标记为k2，附加数据校验失败
+	_, err = c.Decrypt(strings.Replace(enc, "enc:v1:k1:", "enc:v1:k2:", 1))
+	assert.ErrorIs(t, err, ErrFieldCipherText)
+
+	_, err = c.Decrypt(enc[:len(enc)-2])
+	assert.ErrorIs(t, err, ErrFieldCipherText)
+
+	_, err = NewFieldCipher(&FieldConfig{ActiveKey: "k9", Keys: []FieldKey{{ID: "k1", Key: testKey('a')}}, IndexKey: testKey('i')})
+	assert.Error(t, err)
+}
+
+type encryptedModel struct {
+	ID    int64
+	Email *string `gorm:"serializer:encrypted"`
+	IP    string  `gorm:"serializer:encrypted"`
+}
+
+func TestEncryptedSerializer(t *testing.T) {
+	require.NoError(t, NewFieldCrypto(&FieldConfig{
+		ActiveKey: "k1",
+		Keys:      []FieldKey{{ID: "k1", Key: testKey('a')}},
+		IndexKey:  testKey('i'),
+	}))
+
+	s, err := schema.Parse(&encryptedModel{}, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	email := "alice@example.com"
+	for _, c := range []struct {
+		field string
+		value interface{}
+		want  interface{}
+	}{
+		{field: "Email", value: &email, want: &email},
+		{field: "IP", value: "10.0.0.1", want: "10.0.0.1"},
+	} {
+		field := s.LookUpField(c.field)
+		require.NotNil(t, field)
+
+		stored, err := EncryptedSerializer{}.Value(ctx, field, reflect.Value{}, c.value)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(stored.(string), fieldCipherPrefix))
+
+		dst := reflect.ValueOf(&encryptedModel{}).Elem()
+		require.NoError(t, EncryptedSerializer{}.Scan(ctx, field, dst, []byte(stored.(string))))
+		assert.Equal(t, c.want, dst.FieldByName(c.field).Interface())
+	}
+
+	// 空值不加密
+	stored, err := EncryptedSerializer{}.Value(ctx, s.LookUpField("Email"), reflect.Value{}, (*string)(nil))
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+
+	index, err := BlindIndex(&email)
+	require.NoError(t, err)
+	assert.Equal(t, newTestFieldCipher(t, "k1").BlindIndex(email), *index)
+	index, err = BlindIndex(nil)
+	require.NoError(t, err)
+	assert.Nil(t, index)
+}
+
+func TestBlindIndex_NotInit(t *testing.T) {
+	prev := localFieldCipher.Swap(nil)
+	defer localFieldCipher.Store(prev)
+
+	ip := "10.0.0.1"
+	_, err := BlindIndex(&ip)
+	assert.ErrorIs(t, err, ErrFieldCipherNotInit, "未初始化时不能返回空索引跳过校验")
+}
@@ -0,0 +1,56 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"hash"
+)
+
+// HMACSign 计算HMAC-SHA256
+func HMACSign(key, data []byte) []byte {
+	return hmacSum(sha256.New, key, data)
+}
+
+// HMACVerify 常量时间校验HMAC-SHA256
+func HMACVerify(key, data, mac []byte) bool {
+	return hmac.Equal(HMACSign(key, data), mac)
+}
+
+// HMACSign512 计算HMAC-SHA512
+func HMACSign512(key, data []byte) []byte {
+	return hmacSum(sha512.New, key, data)
+}
+
+// HMACVerify512 常量时间校验HMAC-SHA512
+func HMACVerify512(key, data, mac []byte) bool {
+	return hmac.Equal(HMACSign512(key, data), mac)
+}
+
+// HMACHex 计算字符串的HMAC-SHA256，返回十六进制
+func HMACHex(key, data string) string {
+	return hex.EncodeToString(HMACSign([]byte(key), []byte(data)))
+}
+
+// HMACVerifyHex 常量时间校验十六进制HMAC-SHA256
+func HMACVerifyHex(key, data, mac string) bool {
+	raw, err := hex.DecodeString(mac)
+	if err != nil {
+		return false
+	}
+	return HMACVerify([]byte(key), []byte(data), raw)
+}
+
+// Equal 常量时间比较两个字符串，用于比较令牌、摘要等敏感值
+func Equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// hmacSum 计算HMAC
+func hmacSum(h func() hash.Hash, key, data []byte) []byte {
+	mac := hmac.New(h, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
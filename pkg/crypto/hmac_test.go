@@ -0,0 +1,36 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHMAC(t *testing.T) {
+	// RFC 4231 测试用例2
+	assert.Equal(t, "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", HMACHex("Jefe", "what do ya want for nothing?"))
+
+	key, data := []byte("key"), []byte("data")
+	assert.True(t, HMACVerify(key, data, HMACSign(key, data)))
+	assert.False(t, HMACVerify(key, []byte("date"), HMACSign(key, data)))
+	assert.False(t, HMACVerify([]byte("kay"), data, HMACSign(key, data)))
+	assert.True(t, HMACVerify512(key, data, HMACSign512(key, data)))
+	assert.False(t, HMACVerify512(key, data, HMACSign(key, data)))
+
+	mac := HMACHex("key", "data")
+	assert.True(t, HMACVerifyHex("key", "data", mac))
+	assert.False(t, HMACVerifyHex("key", "data", mac[:len(mac)-2]))
+	assert.False(t, HMACVerifyHex("key", "data", "not-hex"))
+
+	assert.True(t, Equal("token", "token"))
+	assert.False(t, Equal("token", "token2"))
+}
+
+func FuzzHMACVerify(f *testing.F) {
+	f.Add([]byte("key"), []byte("data"))
+	f.Fuzz(func(t *testing.T, key, data []byte) {
+		if !HMACVerify(key, data, HMACSign(key, data)) {
+			t.Fatal("HMACVerify rejected its own signature")
+		}
+	})
+}
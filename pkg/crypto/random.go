@@ -0,0 +1,87 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RandomBytes 生成指定长度的加密安全随机字节
+func RandomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("生成随机数失败: %w", err)
+	}
+	return b, nil
+}
+
+// RandomToken 生成URL安全的随机令牌，n 为随机字节数，建议不少于32
+func RandomToken(n int) (string, error) {
+	b, err := RandomBytes(n)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// RandomHex 生成十六进制随机字符串，n 为随机字节数
+func RandomHex(n int) (string, error) {
+	b, err := RandomBytes(n)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+var (
+	uuidMu   sync.Mutex
+	uuidLast int64 // 上一次生成时使用的毫秒时间戳
+	uuidSeq  uint16
+)
+
+// UUIDv7 生成 RFC 9562 UUIDv7，按生成时间单调递增，适合作为数据库主键
+// 同一毫秒内使用12位计数器保证顺序，计数器溢出时借用下一毫秒
+func UUIDv7() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[8:]); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+
+	uuidMu.Lock()
+	ms := time.Now().UnixMilli()
+	if ms <= uuidLast {
+		uuidSeq++
+		if uuidSeq > 0x0fff {
+			uuidLast++
+			uuidSeq = 0
+		}
+		ms = uuidLast
+	} else {
+		uuidLast = ms
+		uuidSeq = 0
+	}
+	seq := uuidSeq
+	uuidMu.Unlock()
+
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(ms))
+	copy(u[:6], ts[2:])
+	binary.BigEndian.PutUint16(u[6:8], 0x7000|seq)
+	u[8] = u[8]&0x3f | 0x80 // RFC 9562 变体
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:]), nil
+}
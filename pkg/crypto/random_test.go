@@ -0,0 +1,42 @@
+package crypto
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandom(t *testing.T) {
+	b, err := RandomBytes(32)
+	require.NoError(t, err)
+	assert.Len(t, b, 32)
+
+	token, err := RandomToken(32)
+	require.NoError(t, err)
+	assert.Len(t, token, 43)
+	assert.Regexp(t, `^[A-Za-z0-9_-]+$`, token)
+
+	other, err := RandomToken(32)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+
+	h, err := RandomHex(16)
+	require.NoError(t, err)
+	assert.Regexp(t, `^[0-9a-f]{32}$`, h)
+}
+
+func TestUUIDv7(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	prev := ""
+	for i := 0; i < 10000; i++ {
+		id, err := UUIDv7()
+		require.NoError(t, err)
+		require.Regexp(t, pattern, id)
+		// 同一进程内生成的UUID按字典序严格递增
+		require.Greater(t, id, prev)
+		prev = id
+	}
+}
@@ -0,0 +1,87 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm/schema"
+)
+
+// EncryptedSerializerName 加密字段序列化器名称
+// 在模型字段上使用 `gorm:"serializer:encrypted"` 即可透明加解密
+const EncryptedSerializerName = "encrypted"
+
+func init() {
+	schema.RegisterSerializer(EncryptedSerializerName, EncryptedSerializer{})
+}
+
+// EncryptedSerializer 加密字段GORM序列化器，支持 string 与 *string 字段
+// 写入时使用当前密钥加密，读取时按密文中的密钥ID解密；空值不加密
+type EncryptedSerializer struct{}
+
+// Scan 从数据库读取并解密
+func (EncryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	fieldValue := reflect.New(field.FieldType).Elem()
+
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+		field.ReflectValueOf(ctx, dst).Set(fieldValue)
+		return nil
+	case []byte:
+		value = string(v)
+	case string:
+		value = v
+	default:
+		return fmt.Errorf("加密字段 %s 的数据库类型不支持: %T", field.Name, dbValue)
+	}
+
+	if value != "" {
+		c, err := GetFieldCipher()
+		if err != nil {
+			return err
+		}
+		if value, err = c.Decrypt(value); err != nil {
+			return fmt.Errorf("解密字段 %s 失败: %w", field.Name, err)
+		}
+	}
+
+	switch field.FieldType.Kind() {
+	case reflect.String:
+		fieldValue.SetString(value)
+	case reflect.Ptr:
+		ptr := reflect.New(field.FieldType.Elem())
+		ptr.Elem().SetString(value)
+		fieldValue.Set(ptr)
+	default:
+		return fmt.Errorf("加密字段 %s 的类型不支持: %s", field.Name, field.FieldType)
+	}
+	field.ReflectValueOf(ctx, dst).Set(fieldValue)
+	return nil
+}
+
+// Value 加密后写入数据库
+func (EncryptedSerializer) Value(_ context.Context, field *schema.Field, _ reflect.Value, fieldValue interface{}) (interface{}, error) {
+	var value string
+	switch v := fieldValue.(type) {
+	case string:
+		value = v
+	case *string:
+		if v == nil {
+			return nil, nil
+		}
+		value = *v
+	default:
+		return nil, fmt.Errorf("加密字段 %s 的类型不支持: %T", field.Name, fieldValue)
+	}
+
+	if value == "" {
+		return value, nil
+	}
+	c, err := GetFieldCipher()
+	if err != nil {
+		return nil, err
+	}
+	return c.Encrypt(value)
+}
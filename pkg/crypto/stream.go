@@ -0,0 +1,46 @@
+package crypto
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+)
+
+// HashReader 流式计算 io.Reader 的摘要，返回十六进制
+// 适用于大文件等无法一次性读入内存的数据
+func HashReader(h hash.Hash, r io.Reader) (string, error) {
+	if _, err := io.Copy(h, r); err != nil {
+		return "", fmt.Errorf("读取数据失败: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// MD5Reader 流式计算 MD5
+func MD5Reader(r io.Reader) (string, error) {
+	return HashReader(md5.New(), r)
+}
+
+// Hash256Reader 流式计算 SHA256
+func Hash256Reader(r io.Reader) (string, error) {
+	return HashReader(sha256.New(), r)
+}
+
+// Hash512Reader 流式计算 SHA512
+func Hash512Reader(r io.Reader) (string, error) {
+	return HashReader(sha512.New(), r)
+}
+
+// HashFile 流式计算文件的 SHA256
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("打开文件失败: %w", err)
+	}
+	defer f.Close()
+	return Hash256Reader(f)
+}
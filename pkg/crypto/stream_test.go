@@ -0,0 +1,37 @@
+package crypto
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashReader(t *testing.T) {
+	data := strings.Repeat("sweet", 10000)
+
+	sum, err := Hash256Reader(strings.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, Hash256(data), sum)
+
+	sum, err = MD5Reader(iotest.OneByteReader(strings.NewReader(data)))
+	require.NoError(t, err)
+	assert.Equal(t, MD5(data), sum)
+
+	sum, err = Hash512Reader(strings.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, Hash512(data), sum)
+
+	_, err = Hash256Reader(iotest.ErrReader(os.ErrClosed))
+	assert.ErrorIs(t, err, os.ErrClosed)
+
+	path := filepath.Join(t.TempDir(), "data.txt")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	sum, err = HashFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, Hash256(data), sum)
+}
@@ -0,0 +1,351 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"sweet/pkg/auth"
+)
+
+const (
+	// 审计插件名称
+	auditPluginName = "audit"
+	// AuditTable 审计记录表名
+	AuditTable = "sw_sys_audit_log"
+	// 变更前快照在语句中的存储键
+	auditSnapshotKey = "audit:snapshot"
+)
+
+// 审计动作
+const (
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// ErrAuditTooManyRows 语句影响的行数超过 MaxRows，为避免审计遗漏拒绝执行
+var ErrAuditTooManyRows = errors.New("audit: affected rows exceed max_rows")
+
+// AuditLog 数据变更审计记录
+type AuditLog struct {
+	ID        int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	TenantID  int64     `gorm:"column:tenant_id;not null;default:1" json:"tenant_id"` // 租户ID
+	Entity    string    `gorm:"column:entity;not null" json:"entity"`                 // 表名
+	EntityID  string    `gorm:"column:entity_id;not null" json:"entity_id"`           // 主键值
+	Action    string    `gorm:"column:action;not null" json:"action"`                 // update / delete
+	Before    *string   `gorm:"column:before" json:"before"`                          // 变更前快照（JSON）
+	After     *string   `gorm:"column:after" json:"after"`                            // 变更后快照（JSON），删除时为空
+	Changes   *string   `gorm:"column:changes" json:"changes"`                        // 字段差异（JSON），{列名: {old, new}}
+	UserID    *int64    `gorm:"column:user_id" json:"user_id"`                        // 操作用户ID
+	CreatedAt time.Time `gorm:"column:created_at;not null" json:"created_at"`
+}
+
+// TableName AuditLog's table name
+func (*AuditLog) TableName() string {
+	return AuditTable
+}
+
+// AuditChange 单个字段的变更
+type AuditChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// AuditPlugin 数据变更审计插件
+// 在更新、删除前按相同条件读取受影响的行，执行后再次读取并比较差异，
+// 结果与业务语句写入同一事务，审计失败时业务语句随之回滚
+type AuditPlugin struct {
+	config  AuditConfig
+	tables  map[string]struct{}
+	ignores map[string]struct{}
+}
+
+// NewAuditPlugin 创建审计插件
+func NewAuditPlugin(config AuditConfig) *AuditPlugin {
+	p := &AuditPlugin{
+		config:  config,
+		tables:  make(map[string]struct{}, len(config.Tables)),
+		ignores: make(map[string]struct{}, len(config.IgnoreColumns)),
+	}
+	for _, table := range config.Tables {
+		p.tables[table] = struct{}{}
+	}
+	for _, column := range config.IgnoreColumns {
+		p.ignores[column] = struct{}{}
+	}
+	if p.config.MaxRows <= 0 {
+		p.config.MaxRows = 1000
+	}
+	return p
+}
+
+// Name 返回插件名称
+func (p *AuditPlugin) Name() string {
+	return auditPluginName
+}
+
+// Initialize 初始化插件
+func (p *AuditPlugin) Initialize(db *gorm.DB) error {
+	if !p.config.Enabled || len(p.tables) == 0 {
+		return nil
+	}
+
+	// 注册回调函数
+	if err := p.registerCallbacks(db); err != nil {
+		return fmt.Errorf("failed to register audit callbacks: %w", err)
+	}
+
+	return nil
+}
+
+// registerCallbacks 注册回调函数
+func (p *AuditPlugin) registerCallbacks(db *gorm.DB) error {
+	// Update操作
+	if err := db.Callback().Update().Before("gorm:update").Register("audit:before_update", p.beforeUpdate); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("audit:after_update", p.afterUpdate); err != nil {
+		return err
+	}
+
+	// Delete操作
+	if err := db.Callback().Delete().Before("gorm:delete").Register("audit:before_delete", p.beforeDelete); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().After("gorm:delete").Register("audit:after_delete", p.afterDelete); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// beforeUpdate Update操作前回调
+func (p *AuditPlugin) beforeUpdate(db *gorm.DB) {
+	p.before(db)
+}
+
+// afterUpdate Update操作后回调
+func (p *AuditPlugin) afterUpdate(db *gorm.DB) {
+	p.after(db, AuditActionUpdate)
+}
+
+// beforeDelete Delete操作前回调
+func (p *AuditPlugin) beforeDelete(db *gorm.DB) {
+	p.before(db)
+}
+
+// afterDelete Delete操作后回调
+func (p *AuditPlugin) afterDelete(db *gorm.DB) {
+	p.after(db, AuditActionDelete)
+}
+
+// audited 判断语句所在的表是否需要审计
+func (p *AuditPlugin) audited(db *gorm.DB) bool {
+	stmt := db.Statement
+	if db.Error != nil || db.DryRun || stmt.Schema == nil || stmt.Schema.PrioritizedPrimaryField == nil {
+		return false
+	}
+	_, ok := p.tables[stmt.Table]
+	return ok
+}
+
+// before 记录变更前快照
+func (p *AuditPlugin) before(db *gorm.DB) {
+	if !p.audited(db) {
+		return
+	}
+	conds := conditions(db.Statement)
+	// 无条件的全表更新会被 gorm 拒绝，除非显式允许
+	if len(conds) == 0 && !db.AllowGlobalUpdate {
+		return
+	}
+
+	rows, err := p.snapshot(db, conds)
+	if err != nil {
+		db.AddError(fmt.Errorf("audit: snapshot before change: %w", err))
+		return
+	}
+	db.Set(auditSnapshotKey, rows)
+}
+
+// after 读取变更后快照，比较差异并写入审计记录
+func (p *AuditPlugin) after(db *gorm.DB, action string) {
+	value, exists := db.Get(auditSnapshotKey)
+	if !exists || db.Error != nil {
+		return
+	}
+	before, _ := value.([]map[string]any)
+	if len(before) == 0 {
+		return
+	}
+
+	pk := db.Statement.Schema.PrioritizedPrimaryField.DBName
+	afterByID := make(map[string]map[string]any)
+	if action == AuditActionUpdate {
+		ids := make([]any, 0, len(before))
+		for _, row := range before {
+			ids = append(ids, row[pk])
+		}
+		rows, err := p.snapshot(db, []clause.Expression{clause.IN{Column: clause.Column{Name: pk}, Values: ids}})
+		if err != nil {
+			db.AddError(fmt.Errorf("audit: snapshot after change: %w", err))
+			return
+		}
+		for _, row := range rows {
+			afterByID[fmt.Sprint(row[pk])] = row
+		}
+	}
+
+	var userID *int64
+	if uid, ok := auth.UidFrom(db.Statement.Context); ok {
+		userID = &uid
+	}
+	now := time.Now()
+	logs := make([]*AuditLog, 0, len(before))
+	for _, row := range before {
+		id := fmt.Sprint(row[pk])
+		log := &AuditLog{
+			Entity:    db.Statement.Table,
+			EntityID:  id,
+			Action:    action,
+			Before:    marshalAudit(row),
+			UserID:    userID,
+			CreatedAt: now,
+		}
+		if action == AuditActionUpdate {
+			after, ok := afterByID[id]
+			if !ok {
+				continue
+			}
+			changes := diffRows(row, after)
+			// 值未变化的行不记录
+			if len(changes) == 0 {
+				continue
+			}
+			log.After = marshalAudit(after)
+			log.Changes = marshalAudit(changes)
+		}
+		logs = append(logs, log)
+	}
+	if len(logs) == 0 {
+		return
+	}
+
+	// 复用当前连接，与业务语句处于同一事务
+	if err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Create(&logs).Error; err != nil {
+		db.AddError(fmt.Errorf("audit: save logs: %w", err))
+	}
+}
+
+// snapshot 按条件读取受影响的行，忽略配置中排除的列
+func (p *AuditPlugin) snapshot(db *gorm.DB, conds []clause.Expression) ([]map[string]any, error) {
+	stmt := db.Statement
+	tx := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).
+		Model(reflect.New(stmt.Schema.ModelType).Interface())
+	if stmt.Unscoped {
+		tx = tx.Unscoped()
+	}
+	if len(conds) > 0 {
+		tx = tx.Clauses(clause.Where{Exprs: conds})
+	}
+
+	// 多读一行判断是否超出上限，超出部分无法审计，拒绝执行而不是静默漏记
+	var rows []map[string]any
+	if err := tx.Limit(p.config.MaxRows + 1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) > p.config.MaxRows {
+		return nil, fmt.Errorf("%w: %s", ErrAuditTooManyRows, stmt.Table)
+	}
+	pk := stmt.Schema.PrioritizedPrimaryField.DBName
+	for _, row := range rows {
+		for column, value := range row {
+			if _, ok := p.ignores[column]; ok && column != pk {
+				delete(row, column)
+				continue
+			}
+			// 驱动返回的字节切片按字符串记录
+			if b, ok := value.([]byte); ok {
+				row[column] = string(b)
+			}
+		}
+	}
+	return rows, nil
+}
+
+// conditions 收集语句的 WHERE 条件；Model(&entity)、Delete(&entity) 的主键条件由 gorm 在执行阶段才添加，这里提前补上
+func conditions(stmt *gorm.Statement) []clause.Expression {
+	var exprs []clause.Expression
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			exprs = append(exprs, where.Exprs...)
+		}
+	}
+	if stmt.ReflectValue.IsValid() {
+		_, identities := schema.GetIdentityFieldValuesMap(stmt.Context, stmt.ReflectValue, stmt.Schema.PrimaryFields)
+		column, values := schema.ToQueryValues(stmt.Table, stmt.Schema.PrimaryFieldDBNames, identities)
+		if len(values) > 0 {
+			exprs = append(exprs, clause.IN{Column: column, Values: values})
+		}
+	}
+	return exprs
+}
+
+// diffRows 比较变更前后的行，返回值发生变化的列
+func diffRows(before, after map[string]any) map[string]AuditChange {
+	changes := make(map[string]AuditChange)
+	for column, old := range before {
+		value, ok := after[column]
+		if !ok {
+			continue
+		}
+		if !auditEqual(old, value) {
+			changes[column] = AuditChange{Old: old, New: value}
+		}
+	}
+	return changes
+}
+
+// auditEqual 比较两个列值，时间按时刻比较
+func auditEqual(a, b any) bool {
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Equal(tb)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// marshalAudit 序列化为 JSON 字符串
+func marshalAudit(v any) *string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	s := string(data)
+	return &s
+}
+
+// FindAuditLogs 按实体与主键查询审计记录，按时间倒序
+func FindAuditLogs(ctx context.Context, db *gorm.DB, entity, entityID string, offset, limit int) ([]*AuditLog, int64, error) {
+	var (
+		logs  []*AuditLog
+		total int64
+	)
+	tx := db.WithContext(ctx).Model(&AuditLog{}).Where("entity = ? AND entity_id = ?", entity, entityID).
+		Session(&gorm.Session{})
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := tx.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
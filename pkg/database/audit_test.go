@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"sweet/pkg/auth"
+)
+
+type auditUser struct {
+	ID        int64 `gorm:"primaryKey"`
+	Name      string
+	Password  string
+	Status    int
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt
+}
+
+func (auditUser) TableName() string { return "t_user" }
+
+type auditPost struct {
+	ID    int64 `gorm:"primaryKey"`
+	Title string
+}
+
+func (auditPost) TableName() string { return "t_post" }
+
+func newAuditDB(t *testing.T) *gorm.DB {
+	return newAuditDBWith(t, AuditConfig{
+		Enabled:       true,
+		Tables:        []string{"t_user"},
+		IgnoreColumns: []string{"password", "updated_at"},
+	})
+}
+
+func newAuditDBWith(t *testing.T, config AuditConfig) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	require.NoError(t, db.AutoMigrate(&auditUser{}, &auditPost{}, &AuditLog{}))
+	require.NoError(t, db.Use(NewAuditPlugin(config)))
+	return db
+}
+
+func TestAuditPlugin_Update(t *testing.T) {
+	db := newAuditDB(t)
+	users := []auditUser{{ID: 1, Name: "a", Password: "x", Status: 1}, {ID: 2, Name: "b", Status: 1}}
+	require.NoError(t, db.Create(&users).Error)
+
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Uid: 9})
+	require.NoError(t, db.WithContext(ctx).Model(&auditUser{}).Where("id = ?", 1).
+		Updates(map[string]any{"name": "a2", "password": "y"}).Error)
+
+	logs, total, err := FindAuditLogs(ctx, db, "t_user", "1", 0, 10)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, total)
+	log := logs[0]
+	assert.Equal(t, AuditActionUpdate, log.Action)
+	require.NotNil(t, log.UserID)
+	assert.EqualValues(t, 9, *log.UserID)
+
+	var changes map[string]AuditChange
+	require.NoError(t, json.Unmarshal([]byte(*log.Changes), &changes))
+	assert.Equal(t, map[string]AuditChange{"name": {Old: "a", New: "a2"}}, changes, "忽略的列不参与比较")
+	assert.NotContains(t, *log.Before, "password")
+
+	// 主键来自模型的更新
+	user := users[1]
+	user.Status = 2
+	require.NoError(t, db.Save(&user).Error)
+	_, total, err = FindAuditLogs(ctx, db, "t_user", "2", 0, 10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+
+	// 值未变化时不记录
+	require.NoError(t, db.Model(&auditUser{}).Where("id = ?", 2).Update("status", 2).Error)
+	_, total, err = FindAuditLogs(ctx, db, "t_user", "2", 0, 10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+}
+
+func TestAuditPlugin_Delete(t *testing.T) {
+	db := newAuditDB(t)
+	require.NoError(t, db.Create(&[]auditUser{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}).Error)
+	require.NoError(t, db.Create(&auditPost{ID: 1, Title: "p"}).Error)
+
+	require.NoError(t, db.Delete(&auditUser{ID: 1}).Error)
+	require.NoError(t, db.Unscoped().Where("id IN ?", []int64{2}).Delete(&auditUser{}).Error)
+	require.NoError(t, db.Delete(&auditPost{ID: 1}).Error)
+
+	var logs []AuditLog
+	require.NoError(t, db.Order("id").Find(&logs).Error)
+	require.Len(t, logs, 2, "未配置的表不审计")
+	for i, log := range logs {
+		assert.Equal(t, "t_user", log.Entity)
+		assert.Equal(t, AuditActionDelete, log.Action)
+		assert.Nil(t, log.UserID)
+		assert.Nil(t, log.After)
+		assert.Equal(t, []string{"1", "2"}[i], log.EntityID)
+	}
+	assert.Contains(t, *logs[0].Before, `"name":"a"`)
+}
+
+func TestAuditPlugin_RollbackWithTransaction(t *testing.T) {
+	db := newAuditDB(t)
+	require.NoError(t, db.Create(&auditUser{ID: 1, Name: "a"}).Error)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&auditUser{ID: 1}).Update("name", "b").Error; err != nil {
+			return err
+		}
+		return assert.AnError
+	})
+	require.ErrorIs(t, err, assert.AnError)
+
+	var count int64
+	require.NoError(t, db.Model(&AuditLog{}).Count(&count).Error)
+	assert.Zero(t, count, "审计记录随事务回滚")
+}
+
+func TestAuditPlugin_MaxRows(t *testing.T) {
+	db := newAuditDBWith(t, AuditConfig{Enabled: true, Tables: []string{"t_user"}, MaxRows: 2})
+	require.NoError(t, db.Create(&[]auditUser{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}).Error)
+
+	err := db.Model(&auditUser{}).Where("id > ?", 0).Update("status", 2).Error
+	require.ErrorIs(t, err, ErrAuditTooManyRows)
+	var count int64
+	require.NoError(t, db.Model(&auditUser{}).Where("status = ?", 2).Count(&count).Error)
+	assert.Zero(t, count, "超出上限时语句不执行，避免未审计的变更")
+
+	require.NoError(t, db.Model(&auditUser{}).Where("id <= ?", 2).Update("status", 2).Error)
+	require.NoError(t, db.Model(&AuditLog{}).Count(&count).Error)
+	assert.EqualValues(t, 2, count)
+}
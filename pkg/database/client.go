@@ -2,9 +2,10 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"gorm.io/plugin/dbresolver"
@@ -12,8 +13,11 @@ import (
 
 // Client 数据库客户端
 type Client struct {
-	db     *gorm.DB
-	config *Config
+	db          *gorm.DB
+	config      *Config
+	dialect     Dialect
+	replicas    *replicaSet        // 从库集合，未配置从库时为空
+	slowQueries *SlowQueryRecorder // 进程内慢查询统计，未启用慢查询监控时为空
 }
 
 // NewClient 创建数据库客户端
@@ -26,28 +30,56 @@ func NewClient(config *Config) (*Client, error) {
 	if err := validateConfig(config); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
+	dialect, err := GetDialect(config.Driver)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
 
-	// 创建GORM配置
+	// 创建GORM配置，慢查询按SQL指纹在进程内统计
+	customLogger := NewCustomLogger(config.Log, config.SlowQuery)
+	var slowQueries *SlowQueryRecorder
+	if config.SlowQuery.Enabled {
+		slowQueries = NewSlowQueryRecorder(config.SlowQuery, dialect.IdentifierQuote())
+		customLogger.WithRecorder(slowQueries)
+	}
 	gormConfig := &gorm.Config{
-		Logger: NewCustomLogger(config.Log, config.SlowQuery),
+		Logger: customLogger,
 	}
 
 	// 连接主库
-	db, err := gorm.Open(mysql.Open(config.Master), gormConfig)
+	db, err := gorm.Open(dialect.Open(config.Master), gormConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to master database: %w", err)
 	}
 
 	// 配置读写分离
-	if err := configureDBResolver(db, config); err != nil {
+	replicas, err := configureDBResolver(db, dialect, config)
+	if err != nil {
 		return nil, fmt.Errorf("failed to configure db resolver: %w", err)
 	}
 
+	// 安装读己之写插件，写入后的窗口期内读主库
+	if len(config.Slaves) > 0 && config.Consistency.Enabled {
+		if err := db.Use(NewConsistencyPlugin(config.Consistency.Window)); err != nil {
+			return nil, fmt.Errorf("failed to install consistency plugin: %w", err)
+		}
+	}
+
 	// 配置连接池
 	if err := configureConnectionPool(db, config.Pool); err != nil {
 		return nil, fmt.Errorf("failed to configure connection pool: %w", err)
 	}
 
+	// 安装操作人插件，自动填充 create_by / update_by
+	if err := db.Use(NewOperatorPlugin()); err != nil {
+		return nil, fmt.Errorf("failed to install operator plugin: %w", err)
+	}
+
+	// 安装多租户插件，需先于审计插件安装，审计快照才会带上租户条件
+	if err := db.Use(NewTenantPlugin()); err != nil {
+		return nil, fmt.Errorf("failed to install tenant plugin: %w", err)
+	}
+
 	// 安装OpenTelemetry插件
 	if config.Tracing.Enabled {
 		tracingPlugin := NewTracingPlugin(config.Tracing)
@@ -56,9 +88,24 @@ func NewClient(config *Config) (*Client, error) {
 		}
 	}
 
+	// 安装数据变更审计插件
+	if config.Audit.Enabled {
+		if err := db.Use(NewAuditPlugin(config.Audit)); err != nil {
+			return nil, fmt.Errorf("failed to install audit plugin: %w", err)
+		}
+	}
+
 	client := &Client{
-		db:     db,
-		config: config,
+		db:          db,
+		config:      config,
+		dialect:     dialect,
+		replicas:    replicas,
+		slowQueries: slowQueries,
+	}
+
+	// 定时探测从库，自动摘除异常或延迟过大的从库
+	if replicas != nil && config.Replica.Enabled {
+		replicas.start()
 	}
 
 	return client, nil
@@ -72,33 +119,53 @@ func validateConfig(config *Config) error {
 	return nil
 }
 
-// configureDBResolver 配置读写分离
-func configureDBResolver(db *gorm.DB, config *Config) error {
+// configureDBResolver 配置读写分离，返回从库集合
+func configureDBResolver(db *gorm.DB, dialect Dialect, config *Config) (*replicaSet, error) {
 	if len(config.Slaves) == 0 {
 		// 没有从库配置，跳过读写分离设置
-		return nil
+		return nil, nil
+	}
+	master, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB: %w", err)
 	}
 
-	// 准备从库连接
-	replicas := make([]gorm.Dialector, 0, len(config.Slaves))
+	// 从库连接由客户端持有，用于健康探测与统计
+	dbs := make([]*sql.DB, 0, len(config.Slaves))
 	for _, slaveDSN := range config.Slaves {
-		replicas = append(replicas, mysql.Open(slaveDSN))
+		sqlDB, err := dialect.OpenDB(slaveDSN)
+		if err != nil {
+			for _, opened := range dbs {
+				opened.Close()
+			}
+			return nil, fmt.Errorf("failed to open slave database: %w", err)
+		}
+		applyPool(sqlDB, config.Pool)
+		dbs = append(dbs, sqlDB)
+	}
+	replicas := newReplicaSet(config.Replica, dialect, master, dbs, db.Logger)
+
+	// 主库作为最后一个候选，从库全部摘除时由策略选中；候选多于一个时 dbresolver 才会调用策略
+	dialectors := make([]gorm.Dialector, 0, len(dbs)+1)
+	for _, sqlDB := range append(dbs, master) {
+		dialectors = append(dialectors, dialect.New(sqlDB))
 	}
 
 	// 配置dbresolver插件
 	resolverConfig := dbresolver.Config{
 		// 从库用于读操作
-		Replicas: replicas,
-		// 读写分离策略
-		Policy: dbresolver.RandomPolicy{},
+		Replicas: dialectors,
+		// 读写分离策略：在正常的从库中随机选择
+		Policy: replicas,
 	}
 
 	// 安装dbresolver插件
 	if err := db.Use(dbresolver.Register(resolverConfig)); err != nil {
-		return fmt.Errorf("failed to register dbresolver: %w", err)
+		replicas.close()
+		return nil, fmt.Errorf("failed to register dbresolver: %w", err)
 	}
 
-	return nil
+	return replicas, nil
 }
 
 // configureConnectionPool 配置连接池
@@ -108,13 +175,16 @@ func configureConnectionPool(db *gorm.DB, poolConfig PoolConfig) error {
 		return fmt.Errorf("failed to get sql.DB: %w", err)
 	}
 
-	// 设置连接池参数
+	applyPool(sqlDB, poolConfig)
+	return nil
+}
+
+// applyPool 设置连接池参数
+func applyPool(sqlDB *sql.DB, poolConfig PoolConfig) {
 	sqlDB.SetMaxIdleConns(poolConfig.MaxIdleConns)
 	sqlDB.SetMaxOpenConns(poolConfig.MaxOpenConns)
 	sqlDB.SetConnMaxLifetime(poolConfig.ConnMaxLifetime)
 	sqlDB.SetConnMaxIdleTime(poolConfig.ConnMaxIdleTime)
-
-	return nil
 }
 
 // DB 获取GORM数据库实例
@@ -124,11 +194,15 @@ func (c *Client) DB() *gorm.DB {
 
 // Close 关闭数据库连接
 func (c *Client) Close() error {
+	var replicaErr error
+	if c.replicas != nil {
+		replicaErr = c.replicas.close()
+	}
 	sqlDB, err := c.db.DB()
 	if err != nil {
 		return fmt.Errorf("failed to get sql.DB: %w", err)
 	}
-	return sqlDB.Close()
+	return errors.Join(sqlDB.Close(), replicaErr)
 }
 
 // Ping 测试数据库连接
@@ -171,7 +245,7 @@ func (c *Client) WithContext(ctx context.Context) *gorm.DB {
 	return c.db.WithContext(ctx)
 }
 
-// Master 强制使用主库
+// Master 强制使用主库，需对整个上下文生效时使用 database.Master(ctx)
 func (c *Client) Master() *gorm.DB {
 	return c.db.Clauses(dbresolver.Write)
 }
@@ -181,6 +255,11 @@ func (c *Client) Slave() *gorm.DB {
 	return c.db.Clauses(dbresolver.Read)
 }
 
+// Dialect 获取数据库方言
+func (c *Client) Dialect() Dialect {
+	return c.dialect
+}
+
 // GetConfig 获取配置
 func (c *Client) GetConfig() *Config {
 	return c.config
@@ -213,6 +292,12 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 		return fmt.Errorf("master database health check failed: %w", err)
 	}
 
+	// 启用从库健康检查时探测全部从库并更新状态，异常的从库已被摘除，读请求由其他从库或主库承担
+	if c.replicas != nil && c.config.Replica.Enabled {
+		c.replicas.Check(ctx)
+		return nil
+	}
+
 	// 检查从库连接（如果有配置）
 	if len(c.config.Slaves) > 0 {
 		// 尝试执行一个简单的读操作来验证从库连接
@@ -225,29 +310,36 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
-// GetSlowQueries 获取慢查询统计（需要数据库支持）
+// ReplicaStats 获取各从库状态，未配置从库时返回空
+func (c *Client) ReplicaStats() []ReplicaStat {
+	if c.replicas == nil {
+		return nil
+	}
+	return c.replicas.Stats()
+}
+
+// SlowQueryStats 获取进程内的慢查询统计，按 sortBy（total、count、max、p99，默认 total）降序返回前 n 个SQL指纹
+// 统计来自本进程执行的语句，不依赖数据库的慢查询日志；未启用慢查询监控时返回空
+func (c *Client) SlowQueryStats(n int, sortBy string) []SlowQueryStat {
+	if c.slowQueries == nil {
+		return nil
+	}
+	return c.slowQueries.Top(n, sortBy)
+}
+
+// ResetSlowQueryStats 清空进程内的慢查询统计
+func (c *Client) ResetSlowQueryStats() {
+	if c.slowQueries != nil {
+		c.slowQueries.Reset()
+	}
+}
+
+// GetSlowQueries 获取数据库记录的慢查询（需要数据库支持），来源由方言决定：
+// MySQL 读取 mysql.slow_log，PostgreSQL 读取 pg_stat_statements，SQLite 返回 ErrNotSupported
 func (c *Client) GetSlowQueries(ctx context.Context, limit int) ([]map[string]interface{}, error) {
-	var results []map[string]interface{}
-	
-	// 查询MySQL慢查询日志表（需要开启慢查询日志）
-	query := `
-		SELECT 
-			start_time,
-			user_host,
-			query_time,
-			lock_time,
-			rows_sent,
-			rows_examined,
-			db,
-			sql_text
-		FROM mysql.slow_log 
-		ORDER BY start_time DESC 
-		LIMIT ?
-	`
-	
-	if err := c.db.WithContext(ctx).Raw(query, limit).Scan(&results).Error; err != nil {
+	results, err := c.dialect.SlowQueries(ctx, c.db, limit)
+	if err != nil {
 		return nil, fmt.Errorf("failed to get slow queries: %w", err)
 	}
-	
 	return results, nil
-}
\ No newline at end of file
+}
@@ -8,6 +8,8 @@ import (
 
 // Config 数据库配置
 type Config struct {
+	// 数据库驱动：mysql、postgres、sqlite，为空时使用 mysql
+	Driver string `json:"driver" yaml:"driver"`
 	// 主库配置
 	Master string `json:"master" yaml:"master"`
 	// 从库配置
@@ -20,6 +22,12 @@ type Config struct {
 	SlowQuery SlowQueryConfig `json:"slow_query" yaml:"slow_query"`
 	// OpenTelemetry配置
 	Tracing TracingConfig `json:"tracing" yaml:"tracing"`
+	// 数据变更审计配置
+	Audit AuditConfig `json:"audit" yaml:"audit"`
+	// 读己之写配置
+	Consistency ConsistencyConfig `json:"consistency" yaml:"consistency"`
+	// 从库健康检查配置
+	Replica ReplicaConfig `json:"replica" yaml:"replica"`
 }
 
 // MasterConfig 主库配置
@@ -62,6 +70,10 @@ type SlowQueryConfig struct {
 	Enabled bool `json:"enabled" yaml:"enabled"`
 	// 慢查询阈值
 	Threshold time.Duration `json:"threshold" yaml:"threshold"`
+	// 进程内统计最多保留的SQL指纹数，超出时淘汰最久未出现的指纹
+	MaxFingerprints int `json:"max_fingerprints" yaml:"max_fingerprints"`
+	// 每个指纹保留的最近耗时样本数，用于计算P50/P99
+	Samples int `json:"samples" yaml:"samples"`
 }
 
 // TracingConfig OpenTelemetry配置
@@ -76,9 +88,46 @@ type TracingConfig struct {
 	RecordParams bool `json:"record_params" yaml:"record_params"`
 }
 
+// AuditConfig 数据变更审计配置
+type AuditConfig struct {
+	// 是否启用审计
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// 需要审计的表名
+	Tables []string `json:"tables" yaml:"tables"`
+	// 不记录的列，如密码、更新时间
+	IgnoreColumns []string `json:"ignore_columns" yaml:"ignore_columns"`
+	// 单条语句最多审计的行数，影响行数超出时语句返回 ErrAuditTooManyRows 且不执行，需分批处理
+	MaxRows int `json:"max_rows" yaml:"max_rows"`
+}
+
+// ConsistencyConfig 读己之写配置，仅在配置了从库时生效
+type ConsistencyConfig struct {
+	// 是否启用读己之写
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// 写入后读主库的时间窗口，应覆盖主从复制延迟
+	Window time.Duration `json:"window" yaml:"window"`
+}
+
+// ReplicaConfig 从库健康检查配置，定时探测从库连接与复制延迟，自动摘除与恢复
+type ReplicaConfig struct {
+	// 是否启用健康检查，未启用时所有从库始终参与读请求
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// 探测间隔
+	Interval time.Duration `json:"interval" yaml:"interval"`
+	// 单次探测超时
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+	// 允许的最大复制延迟，超出视为探测失败，为0时不检查复制延迟
+	MaxLag time.Duration `json:"max_lag" yaml:"max_lag"`
+	// 连续失败多少次后摘除
+	FailThreshold int `json:"fail_threshold" yaml:"fail_threshold"`
+	// 摘除后连续成功多少次恢复
+	RecoverThreshold int `json:"recover_threshold" yaml:"recover_threshold"`
+}
+
 // DefaultConfig 返回默认配置
 func DefaultConfig() *Config {
 	return &Config{
+		Driver: DefaultDriver,
 		Master: "",
 		Slaves: []string{},
 		Pool: PoolConfig{
@@ -94,8 +143,10 @@ func DefaultConfig() *Config {
 			ParameterizedQueries:      false,
 		},
 		SlowQuery: SlowQueryConfig{
-			Enabled:   true,
-			Threshold: time.Millisecond * 200,
+			Enabled:         true,
+			Threshold:       time.Millisecond * 200,
+			MaxFingerprints: 200,
+			Samples:         128,
 		},
 		Tracing: TracingConfig{
 			Enabled:      true,
@@ -103,5 +154,23 @@ func DefaultConfig() *Config {
 			RecordSQL:    true,
 			RecordParams: false,
 		},
+		Audit: AuditConfig{
+			Enabled:       false,
+			Tables:        []string{},
+			IgnoreColumns: []string{"password", "salt", "secret_hash", "updated_at"},
+			MaxRows:       1000,
+		},
+		Consistency: ConsistencyConfig{
+			Enabled: true,
+			Window:  time.Second * 3,
+		},
+		Replica: ReplicaConfig{
+			Enabled:          true,
+			Interval:         time.Second * 5,
+			Timeout:          time.Second * 2,
+			MaxLag:           time.Second * 10,
+			FailThreshold:    3,
+			RecoverThreshold: 2,
+		},
 	}
 }
@@ -0,0 +1,205 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+const (
+	// 读己之写插件名称
+	consistencyPluginName = "consistency"
+	// LastWriteCache 用户最近一次写入时间缓存
+	LastWriteCache = "db::last_write::%d" // 用户ID
+)
+
+// masterKey 上下文中强制读主库的键
+type masterKey struct{}
+
+// sessionKey 上下文中读己之写会话的键
+type sessionKey struct{}
+
+// Master 后续读操作强制走主库，可直接用于生成的 DAO：dao.WithContext(database.Master(ctx))
+func Master(ctx context.Context) context.Context {
+	return context.WithValue(ctx, masterKey{}, true)
+}
+
+// IsMaster 上下文是否强制读主库
+func IsMaster(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	pinned, _ := ctx.Value(masterKey{}).(bool)
+	return pinned
+}
+
+// Session 读己之写会话，记录会话内最近一次写入的时间
+// 一次请求对应一个会话，跨请求的写入时间由 WriteStore 保存
+type Session struct {
+	lastWrite atomic.Int64 // 最近一次写入时间，UnixNano
+	written   atomic.Bool  // 会话内是否发生过写入
+}
+
+// NewSession 创建会话，lastWrite 为此前记录的最近写入时间，没有时传零值
+func NewSession(lastWrite time.Time) *Session {
+	s := &Session{}
+	if !lastWrite.IsZero() {
+		s.lastWrite.Store(lastWrite.UnixNano())
+	}
+	return s
+}
+
+// MarkWrite 记录一次写入
+func (s *Session) MarkWrite(at time.Time) {
+	s.lastWrite.Store(at.UnixNano())
+	s.written.Store(true)
+}
+
+// LastWrite 最近一次写入时间，没有写入时为零值
+func (s *Session) LastWrite() time.Time {
+	if ns := s.lastWrite.Load(); ns != 0 {
+		return time.Unix(0, ns)
+	}
+	return time.Time{}
+}
+
+// Written 会话内是否发生过写入
+func (s *Session) Written() bool {
+	return s.written.Load()
+}
+
+// WithSession 将读己之写会话放入上下文
+func WithSession(ctx context.Context, s *Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, s)
+}
+
+// SessionFrom 从上下文获取读己之写会话
+func SessionFrom(ctx context.Context) (*Session, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	s, ok := ctx.Value(sessionKey{}).(*Session)
+	return s, ok && s != nil
+}
+
+// WriteStore 跨请求保存会话最近一次写入时间
+type WriteStore interface {
+	// LastWrite 获取最近一次写入时间，没有记录时返回零值
+	LastWrite(ctx context.Context, key string) (time.Time, error)
+	// MarkWrite 记录写入时间，ttl 后自动失效
+	MarkWrite(ctx context.Context, key string, at time.Time, ttl time.Duration) error
+}
+
+// redisWriteStore 基于Redis的写入时间存储
+type redisWriteStore struct {
+	client *redis.Client
+}
+
+// NewRedisWriteStore 创建基于Redis的写入时间存储
+func NewRedisWriteStore(client *redis.Client) WriteStore {
+	return &redisWriteStore{client: client}
+}
+
+func (s *redisWriteStore) LastWrite(ctx context.Context, key string) (time.Time, error) {
+	ms, err := s.client.Get(ctx, key).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	return time.UnixMilli(ms), nil
+}
+
+func (s *redisWriteStore) MarkWrite(ctx context.Context, key string, at time.Time, ttl time.Duration) error {
+	return s.client.Set(ctx, key, at.UnixMilli(), ttl).Err()
+}
+
+// ConsistencyPlugin 读己之写插件
+// 在会话内发生写入后的窗口期内，以及上下文通过 Master 强制时，读操作改走主库，避免读到从库的旧数据；
+// 需在 dbresolver 之后安装，未配置从库时无需安装
+type ConsistencyPlugin struct {
+	window time.Duration
+	now    func() time.Time
+}
+
+// NewConsistencyPlugin 创建读己之写插件，window 为写入后读主库的时间窗口，应覆盖主从复制延迟
+func NewConsistencyPlugin(window time.Duration) *ConsistencyPlugin {
+	return &ConsistencyPlugin{window: window, now: time.Now}
+}
+
+// Name 返回插件名称
+func (p *ConsistencyPlugin) Name() string {
+	return consistencyPluginName
+}
+
+// Initialize 初始化插件
+func (p *ConsistencyPlugin) Initialize(db *gorm.DB) error {
+	// 注册回调函数
+	if err := p.registerCallbacks(db); err != nil {
+		return fmt.Errorf("failed to register consistency callbacks: %w", err)
+	}
+	return nil
+}
+
+// registerCallbacks 注册回调函数
+func (p *ConsistencyPlugin) registerCallbacks(db *gorm.DB) error {
+	// dbresolver 已选定连接，切换主库时会重新选择
+	if err := db.Callback().Query().Before("gorm:query").Register("consistency:before_query", p.pin); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("consistency:before_row", p.pin); err != nil {
+		return err
+	}
+	if err := db.Callback().Create().After("gorm:create").Register("consistency:after_create", p.markWrite); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("consistency:after_update", p.markWrite); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().After("gorm:delete").Register("consistency:after_delete", p.markWrite); err != nil {
+		return err
+	}
+	// Exec 执行的原生 SQL 一律视为写入
+	if err := db.Callback().Raw().After("gorm:raw").Register("consistency:after_raw", p.markWrite); err != nil {
+		return err
+	}
+	return nil
+}
+
+// pin 需要读己之写时将读操作切换到主库，事务内的语句由 dbresolver 保持原连接
+func (p *ConsistencyPlugin) pin(db *gorm.DB) {
+	if db.Error != nil || !p.pinned(db.Statement.Context) {
+		return
+	}
+	dbresolver.Write.ModifyStatement(db.Statement)
+}
+
+// pinned 上下文的读操作是否需要走主库
+func (p *ConsistencyPlugin) pinned(ctx context.Context) bool {
+	if IsMaster(ctx) {
+		return true
+	}
+	s, ok := SessionFrom(ctx)
+	if !ok {
+		return false
+	}
+	lastWrite := s.LastWrite()
+	return !lastWrite.IsZero() && p.now().Sub(lastWrite) < p.window
+}
+
+// markWrite 记录会话内的写入
+func (p *ConsistencyPlugin) markWrite(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	if s, ok := SessionFrom(db.Statement.Context); ok {
+		s.MarkWrite(p.now())
+	}
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
+)
+
+type consistencyPost struct {
+	ID    int64 `gorm:"primaryKey"`
+	Title string
+}
+
+func (consistencyPost) TableName() string { return "t_post" }
+
+// newConsistencyDB 主库与从库为两个独立的内存库，从库不复制主库的写入，用于模拟复制延迟
+func newConsistencyDB(t *testing.T) (*gorm.DB, *ConsistencyPlugin) {
+	dsn := func(name string) string {
+		return fmt.Sprintf("file:%s_%s?mode=memory&cache=shared", t.Name(), name)
+	}
+	replica, err := gorm.Open(sqlite.Open(dsn("replica")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, replica.AutoMigrate(&consistencyPost{}))
+
+	db, err := gorm.Open(sqlite.Open(dsn("master")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&consistencyPost{}))
+	require.NoError(t, db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{sqlite.Open(dsn("replica"))},
+	})))
+	plugin := NewConsistencyPlugin(time.Second)
+	require.NoError(t, db.Use(plugin))
+	t.Cleanup(func() {
+		for _, conn := range []*gorm.DB{db, replica} {
+			if sqlDB, err := conn.DB(); err == nil {
+				sqlDB.Close()
+			}
+		}
+	})
+	return db, plugin
+}
+
+func TestConsistencyPlugin_Master(t *testing.T) {
+	db, _ := newConsistencyDB(t)
+	require.NoError(t, db.Create(&consistencyPost{ID: 1, Title: "a"}).Error)
+
+	var count int64
+	require.NoError(t, db.Model(&consistencyPost{}).Count(&count).Error)
+	assert.Zero(t, count, "默认读从库")
+
+	ctx := Master(context.Background())
+	require.NoError(t, db.WithContext(ctx).Model(&consistencyPost{}).Count(&count).Error)
+	assert.EqualValues(t, 1, count)
+	var post consistencyPost
+	require.NoError(t, db.WithContext(ctx).First(&post, 1).Error)
+	assert.Equal(t, "a", post.Title)
+}
+
+func TestConsistencyPlugin_Session(t *testing.T) {
+	db, plugin := newConsistencyDB(t)
+	session := NewSession(time.Time{})
+	ctx := WithSession(context.Background(), session)
+
+	var count int64
+	require.NoError(t, db.WithContext(ctx).Model(&consistencyPost{}).Count(&count).Error)
+	assert.Zero(t, count)
+	assert.False(t, session.Written())
+
+	// 会话内写入后读主库
+	require.NoError(t, db.WithContext(ctx).Create(&consistencyPost{ID: 1, Title: "a"}).Error)
+	assert.True(t, session.Written())
+	require.NoError(t, db.WithContext(ctx).Model(&consistencyPost{}).Count(&count).Error)
+	assert.EqualValues(t, 1, count)
+
+	// 超出窗口后恢复读从库
+	plugin.now = func() time.Time { return time.Now().Add(2 * time.Second) }
+	require.NoError(t, db.WithContext(ctx).Model(&consistencyPost{}).Count(&count).Error)
+	assert.Zero(t, count)
+
+	// 之前请求记录的写入时间在窗口期内同样读主库
+	plugin.now = time.Now
+	restored := WithSession(context.Background(), NewSession(session.LastWrite()))
+	require.NoError(t, db.WithContext(restored).Model(&consistencyPost{}).Count(&count).Error)
+	assert.EqualValues(t, 1, count)
+}
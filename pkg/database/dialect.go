@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotSupported 当前数据库不支持该操作
+var ErrNotSupported = errors.New("not supported by dialect")
+
+// DefaultDriver 未配置驱动时使用的数据库
+const DefaultDriver = "mysql"
+
+// Dialect 数据库方言，封装连接方式与各数据库特有的功能
+type Dialect interface {
+	// Name 方言名称，与 gorm.Dialector.Name() 一致
+	Name() string
+	// Open 按DSN创建GORM方言
+	Open(dsn string) gorm.Dialector
+	// OpenDB 按DSN打开连接池，用于从库
+	OpenDB(dsn string) (*sql.DB, error)
+	// New 基于已有连接池创建GORM方言
+	New(conn gorm.ConnPool) gorm.Dialector
+	// System OpenTelemetry 语义约定中的 db.system
+	System() string
+	// IdentifierQuote 标识符引号，SQL指纹据此区分标识符与字符串常量
+	IdentifierQuote() byte
+	// ReplicationLag 查询从库的复制延迟，未配置复制时返回0
+	ReplicationLag(ctx context.Context, db *sql.DB) (time.Duration, error)
+	// SlowQueries 查询最近的慢查询，不支持时返回 ErrNotSupported
+	SlowQueries(ctx context.Context, db *gorm.DB, limit int) ([]map[string]interface{}, error)
+}
+
+var (
+	dialectsMu sync.RWMutex
+	dialects   = map[string]Dialect{}
+)
+
+func init() {
+	RegisterDialect(mysqlDialect{})
+	RegisterDialect(postgresDialect{})
+	RegisterDialect(sqliteDialect{})
+}
+
+// RegisterDialect 注册数据库方言，同名方言会被覆盖
+func RegisterDialect(d Dialect) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+	dialects[d.Name()] = d
+}
+
+// GetDialect 按驱动名称获取方言，名称为空时使用 MySQL
+func GetDialect(name string) (Dialect, error) {
+	if name == "" {
+		name = DefaultDriver
+	}
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+	if d, ok := dialects[name]; ok {
+		return d, nil
+	}
+	names := make([]string, 0, len(dialects))
+	for n := range dialects {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("unsupported database driver %q, available: %v", name, names)
+}
+
+// dbSystem 返回 GORM 方言对应的 db.system，未注册的方言直接使用其名称
+func dbSystem(db *gorm.DB) string {
+	name := db.Dialector.Name()
+	if d, err := GetDialect(name); err == nil {
+		return d.System()
+	}
+	return name
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// mysqlDialect MySQL 方言
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string { return "mysql" }
+
+func (mysqlDialect) Open(dsn string) gorm.Dialector {
+	return mysql.Open(dsn)
+}
+
+func (mysqlDialect) OpenDB(dsn string) (*sql.DB, error) {
+	return sql.Open("mysql", dsn)
+}
+
+func (mysqlDialect) New(conn gorm.ConnPool) gorm.Dialector {
+	return mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true})
+}
+
+func (mysqlDialect) System() string { return "mysql" }
+
+func (mysqlDialect) IdentifierQuote() byte { return '`' }
+
+// ReplicationLag 查询复制延迟，8.0.22 之前的版本使用 SHOW SLAVE STATUS
+// 未配置复制时按无延迟处理，复制线程停止时返回错误
+func (mysqlDialect) ReplicationLag(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	rows, err := db.QueryContext(ctx, "SHOW REPLICA STATUS")
+	if err != nil {
+		if rows, err = db.QueryContext(ctx, "SHOW SLAVE STATUS"); err != nil {
+			return 0, err
+		}
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	if !rows.Next() {
+		return 0, rows.Err()
+	}
+	values := make([]sql.RawBytes, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return 0, err
+	}
+
+	for i, column := range columns {
+		if column != "Seconds_Behind_Source" && column != "Seconds_Behind_Master" {
+			continue
+		}
+		if values[i] == nil {
+			return 0, errReplicationStopped
+		}
+		seconds, err := strconv.ParseInt(string(values[i]), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q: %w", column, values[i], err)
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return 0, nil
+}
+
+// SlowQueries 查询慢查询日志表，需开启 slow_query_log 且 log_output 包含 TABLE
+func (mysqlDialect) SlowQueries(ctx context.Context, db *gorm.DB, limit int) ([]map[string]interface{}, error) {
+	var results []map[string]interface{}
+	query := `
+		SELECT
+			start_time,
+			user_host,
+			query_time,
+			lock_time,
+			rows_sent,
+			rows_examined,
+			db,
+			sql_text
+		FROM mysql.slow_log
+		ORDER BY start_time DESC
+		LIMIT ?
+	`
+	err := db.WithContext(ctx).Raw(query, limit).Scan(&results).Error
+	return results, err
+}
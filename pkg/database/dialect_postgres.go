@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// postgresDialect PostgreSQL 方言
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string { return "postgres" }
+
+func (postgresDialect) Open(dsn string) gorm.Dialector {
+	return postgres.Open(dsn)
+}
+
+// OpenDB 使用 pgx 的 database/sql 驱动，与 GORM 主库一致
+func (postgresDialect) OpenDB(dsn string) (*sql.DB, error) {
+	return sql.Open("pgx", dsn)
+}
+
+func (postgresDialect) New(conn gorm.ConnPool) gorm.Dialector {
+	return postgres.New(postgres.Config{Conn: conn})
+}
+
+func (postgresDialect) System() string { return "postgresql" }
+
+func (postgresDialect) IdentifierQuote() byte { return '"' }
+
+// ReplicationLag 查询备库回放延迟，主库返回0
+// 已回放到接收位置时视为无延迟，避免主库空闲时最后回放时间不变导致误判
+func (postgresDialect) ReplicationLag(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	var seconds float64
+	err := db.QueryRowContext(ctx, `
+		SELECT CASE
+			WHEN NOT pg_is_in_recovery() THEN 0
+			WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+			ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+		END`).Scan(&seconds)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// SlowQueries 按平均耗时查询 pg_stat_statements，需安装该扩展（PostgreSQL 13+）
+func (postgresDialect) SlowQueries(ctx context.Context, db *gorm.DB, limit int) ([]map[string]interface{}, error) {
+	var results []map[string]interface{}
+	query := `
+		SELECT
+			query,
+			calls,
+			total_exec_time,
+			mean_exec_time,
+			max_exec_time,
+			rows
+		FROM pg_stat_statements
+		ORDER BY mean_exec_time DESC
+		LIMIT ?
+	`
+	err := db.WithContext(ctx).Raw(query, limit).Scan(&results).Error
+	return results, err
+}
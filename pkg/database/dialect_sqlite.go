@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// sqliteDialect SQLite 方言（纯Go实现，无需CGO），主要用于测试与本地开发
+// 内存库的每个连接相互独立，多连接共享数据需使用 file:name?mode=memory&cache=shared
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string { return "sqlite" }
+
+func (sqliteDialect) Open(dsn string) gorm.Dialector {
+	return sqlite.Open(dsn)
+}
+
+func (sqliteDialect) OpenDB(dsn string) (*sql.DB, error) {
+	return sql.Open("sqlite", dsn)
+}
+
+func (sqliteDialect) New(conn gorm.ConnPool) gorm.Dialector {
+	return sqlite.Dialector{Conn: conn}
+}
+
+func (sqliteDialect) System() string { return "sqlite" }
+
+func (sqliteDialect) IdentifierQuote() byte { return '`' }
+
+// ReplicationLag SQLite 没有复制
+func (sqliteDialect) ReplicationLag(context.Context, *sql.DB) (time.Duration, error) {
+	return 0, nil
+}
+
+// SlowQueries SQLite 不记录慢查询
+func (sqliteDialect) SlowQueries(context.Context, *gorm.DB, int) ([]map[string]interface{}, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/logger"
+)
+
+func TestGetDialect(t *testing.T) {
+	for name, system := range map[string]string{"": "mysql", "mysql": "mysql", "postgres": "postgresql", "sqlite": "sqlite"} {
+		d, err := GetDialect(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, system, d.System())
+	}
+	_, err := GetDialect("oracle")
+	assert.ErrorContains(t, err, "unsupported database driver")
+}
+
+func TestNewClient_SQLite(t *testing.T) {
+	dsn := func(name string) string {
+		return fmt.Sprintf("file:%s_%s?mode=memory&cache=shared", t.Name(), name)
+	}
+	config := DefaultConfig()
+	config.Driver = "sqlite"
+	config.Master = dsn("master")
+	config.Slaves = []string{dsn("replica")}
+	config.Log.Level = logger.Silent
+	config.Tracing.Enabled = false
+	config.Replica.Enabled = false
+
+	client, err := NewClient(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	assert.Equal(t, "sqlite", client.Dialect().Name())
+	assert.Equal(t, "sqlite", dbSystem(client.DB()))
+
+	ctx := context.Background()
+	require.NoError(t, client.Master().Exec("CREATE TABLE t_post (id INTEGER PRIMARY KEY, title TEXT)").Error)
+	require.NoError(t, client.Slave().Exec("CREATE TABLE t_post (id INTEGER PRIMARY KEY, title TEXT)").Error)
+	require.NoError(t, client.WithContext(ctx).Create(&consistencyPost{ID: 1, Title: "a"}).Error)
+
+	var count int64
+	require.NoError(t, client.WithContext(Master(ctx)).Model(&consistencyPost{}).Count(&count).Error)
+	assert.EqualValues(t, 1, count)
+	require.NoError(t, client.WithContext(ctx).Model(&consistencyPost{}).Count(&count).Error)
+	assert.Zero(t, count, "从库连接由方言创建")
+
+	require.NoError(t, client.HealthCheck(ctx))
+	assert.Len(t, client.ReplicaStats(), 1)
+	_, err = client.GetSlowQueries(ctx, 10)
+	assert.ErrorIs(t, err, ErrNotSupported)
+}
+
+func TestNewClient_UnknownDriver(t *testing.T) {
+	_, err := NewClient(&Config{Driver: "oracle", Master: "dsn"})
+	assert.ErrorContains(t, err, "unsupported database driver")
+}
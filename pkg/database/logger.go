@@ -8,6 +8,7 @@ import (
 	"os"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"gorm.io/gorm/utils"
@@ -17,6 +18,7 @@ import (
 type CustomLogger struct {
 	logger.Config
 	slowQueryConfig SlowQueryConfig
+	recorder        *SlowQueryRecorder // 慢查询统计，为空时不统计
 	logger          *log.Logger
 	// 日志格式字符串
 	infoStr, warnStr, errStr            string
@@ -24,7 +26,7 @@ type CustomLogger struct {
 }
 
 // NewCustomLogger 创建自定义日志记录器
-func NewCustomLogger(config LogConfig, slowQueryConfig SlowQueryConfig) logger.Interface {
+func NewCustomLogger(config LogConfig, slowQueryConfig SlowQueryConfig) *CustomLogger {
 	customLogger := &CustomLogger{
 		Config: logger.Config{
 			SlowThreshold:             slowQueryConfig.Threshold,
@@ -42,6 +44,12 @@ func NewCustomLogger(config LogConfig, slowQueryConfig SlowQueryConfig) logger.I
 	return customLogger
 }
 
+// WithRecorder 设置慢查询统计，慢查询在任何日志级别下都会计入统计
+func (l *CustomLogger) WithRecorder(recorder *SlowQueryRecorder) *CustomLogger {
+	l.recorder = recorder
+	return l
+}
+
 // LogMode 设置日志模式
 func (l *CustomLogger) LogMode(level logger.LogLevel) logger.Interface {
 	newLogger := *l
@@ -72,15 +80,31 @@ func (l *CustomLogger) Error(ctx context.Context, msg string, data ...interface{
 
 // Trace 输出SQL追踪日志
 func (l *CustomLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+	slow := l.slowQueryConfig.Enabled && elapsed >= l.slowQueryConfig.Threshold
+
+	// 慢查询统计不受日志级别影响
+	if slow && l.recorder != nil {
+		sql, rows := fc()
+		l.recorder.Record(sql, elapsed, rows, err)
+		if l.LogLevel <= logger.Silent {
+			return
+		}
+		fc = func() (string, int64) { return sql, rows }
+	}
+
 	if l.LogLevel <= logger.Silent {
 		return
 	}
 
-	elapsed := time.Since(begin)
 	sql, rows := fc()
+	// 请求链路中的SQL附带 trace_id，便于与 Span 关联
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		sql = "[trace_id:" + sc.TraceID().String() + "] " + sql
+	}
 
 	// 处理慢查询
-	if l.slowQueryConfig.Enabled && elapsed >= l.slowQueryConfig.Threshold {
+	if slow {
 		l.logSlowQuery(elapsed, sql, rows, err)
 	}
 
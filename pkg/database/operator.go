@@ -0,0 +1,130 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"sweet/pkg/auth"
+)
+
+const (
+	// 操作人插件名称
+	operatorPluginName = "operator"
+	// ColumnCreateBy 创建者列
+	ColumnCreateBy = "create_by"
+	// ColumnUpdateBy 更新者列
+	ColumnUpdateBy = "update_by"
+)
+
+// OperatorPlugin 操作人插件
+// 创建、更新含 create_by / update_by 列的模型时，从上下文的认证主体（auth.Principal）中自动填充，
+// 上下文中没有用户时不做处理
+type OperatorPlugin struct{}
+
+// NewOperatorPlugin 创建操作人插件
+func NewOperatorPlugin() *OperatorPlugin {
+	return &OperatorPlugin{}
+}
+
+// Name 返回插件名称
+func (p *OperatorPlugin) Name() string {
+	return operatorPluginName
+}
+
+// Initialize 初始化插件
+func (p *OperatorPlugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("operator:before_create", p.beforeCreate); err != nil {
+		return fmt.Errorf("failed to register operator callbacks: %w", err)
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("operator:before_update", p.beforeUpdate); err != nil {
+		return fmt.Errorf("failed to register operator callbacks: %w", err)
+	}
+	return nil
+}
+
+// beforeCreate 填充创建者与更新者，已显式赋值的字段保持不变
+func (p *OperatorPlugin) beforeCreate(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil {
+		return
+	}
+	uid, ok := auth.UidFrom(stmt.Context)
+	if !ok {
+		return
+	}
+
+	for _, column := range []string{ColumnCreateBy, ColumnUpdateBy} {
+		field := stmt.Schema.LookUpField(column)
+		if field == nil {
+			continue
+		}
+		switch dest := stmt.Dest.(type) {
+		case map[string]any:
+			if _, ok := dest[column]; !ok {
+				dest[column] = uid
+			}
+			continue
+		case []map[string]any:
+			for _, row := range dest {
+				if _, ok := row[column]; !ok {
+					row[column] = uid
+				}
+			}
+			continue
+		}
+
+		switch stmt.ReflectValue.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < stmt.ReflectValue.Len(); i++ {
+				setIfZero(db, field, reflect.Indirect(stmt.ReflectValue.Index(i)), uid)
+			}
+		case reflect.Struct:
+			setIfZero(db, field, stmt.ReflectValue, uid)
+		}
+	}
+}
+
+// beforeUpdate 将更新者设置为当前用户，UpdateColumn(s) 与 updated_at 一致不做处理
+func (p *OperatorPlugin) beforeUpdate(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || stmt.SkipHooks {
+		return
+	}
+	if stmt.Schema.LookUpField(ColumnUpdateBy) == nil {
+		return
+	}
+	uid, ok := auth.UidFrom(stmt.Context)
+	if !ok {
+		return
+	}
+
+	// Select 指定更新列时需要把更新者一并选中，否则不会写入
+	if len(stmt.Selects) > 0 {
+		selected := false
+		for _, column := range stmt.Selects {
+			if column == "*" || column == ColumnUpdateBy || column == "UpdateBy" {
+				selected = true
+				break
+			}
+		}
+		if !selected {
+			stmt.Selects = append(stmt.Selects, ColumnUpdateBy)
+		}
+	}
+	stmt.SetColumn(ColumnUpdateBy, uid, true)
+}
+
+// setIfZero 字段为零值时赋值
+func setIfZero(db *gorm.DB, field *schema.Field, rv reflect.Value, uid int64) {
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+	if _, zero := field.ValueOf(db.Statement.Context, rv); zero {
+		if err := field.Set(db.Statement.Context, rv, uid); err != nil {
+			db.AddError(err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"sweet/pkg/auth"
+)
+
+type operatorMenu struct {
+	ID       int64 `gorm:"primaryKey"`
+	Title    string
+	CreateBy *int64
+	UpdateBy *int64
+}
+
+func (operatorMenu) TableName() string { return "t_menu" }
+
+func newOperatorDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	require.NoError(t, db.AutoMigrate(&operatorMenu{}))
+	require.NoError(t, db.Use(NewOperatorPlugin()))
+	return db
+}
+
+func TestOperatorPlugin_Create(t *testing.T) {
+	db := newOperatorDB(t)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Uid: 7})
+
+	menu := operatorMenu{ID: 1, Title: "a"}
+	require.NoError(t, db.WithContext(ctx).Create(&menu).Error)
+	require.NotNil(t, menu.CreateBy)
+	assert.EqualValues(t, 7, *menu.CreateBy)
+	assert.EqualValues(t, 7, *menu.UpdateBy)
+
+	// 批量创建，已显式赋值的保持不变
+	other := int64(3)
+	menus := []*operatorMenu{{ID: 2}, {ID: 3, CreateBy: &other}}
+	require.NoError(t, db.WithContext(ctx).Create(&menus).Error)
+	assert.EqualValues(t, 7, *menus[0].CreateBy)
+	assert.EqualValues(t, 3, *menus[1].CreateBy)
+
+	// 上下文中没有用户时不填充
+	require.NoError(t, db.Create(&operatorMenu{ID: 4}).Error)
+	var got operatorMenu
+	require.NoError(t, db.First(&got, 4).Error)
+	assert.Nil(t, got.CreateBy)
+}
+
+func TestOperatorPlugin_Update(t *testing.T) {
+	db := newOperatorDB(t)
+	require.NoError(t, db.Create(&operatorMenu{ID: 1, Title: "a"}).Error)
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Uid: 8})
+
+	cases := map[string]func(tx *gorm.DB) error{
+		"Update": func(tx *gorm.DB) error {
+			return tx.Model(&operatorMenu{}).Where("id = ?", 1).Update("title", "b").Error
+		},
+		"Updates map": func(tx *gorm.DB) error {
+			return tx.Model(&operatorMenu{ID: 1}).Updates(map[string]any{"title": "c"}).Error
+		},
+		"Select Updates": func(tx *gorm.DB) error {
+			return tx.Model(&operatorMenu{ID: 1}).Select("title").Updates(&operatorMenu{Title: "d"}).Error
+		},
+		"Save": func(tx *gorm.DB) error {
+			return tx.Save(&operatorMenu{ID: 1, Title: "e"}).Error
+		},
+	}
+	for name, update := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, db.Model(&operatorMenu{}).Where("id = ?", 1).UpdateColumn("update_by", nil).Error)
+			require.NoError(t, update(db.WithContext(ctx)))
+
+			var got operatorMenu
+			require.NoError(t, db.First(&got, 1).Error)
+			require.NotNil(t, got.UpdateBy)
+			assert.EqualValues(t, 8, *got.UpdateBy)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// errReplicationStopped 复制线程未运行，复制延迟为 NULL
+var errReplicationStopped = errors.New("replication is not running")
+
+// ReplicaState 从库状态
+type ReplicaState string
+
+const (
+	// ReplicaHealthy 正常，参与读请求
+	ReplicaHealthy ReplicaState = "healthy"
+	// ReplicaEjected 已摘除，连续探测成功后恢复
+	ReplicaEjected ReplicaState = "ejected"
+)
+
+// ReplicaStat 从库状态统计
+type ReplicaStat struct {
+	Name            string       `json:"name"`                 // 从库名称，按配置顺序为 replica-0、replica-1…
+	State           ReplicaState `json:"state"`                // 状态
+	Lag             string       `json:"lag"`                  // 最近一次探测到的复制延迟
+	Failures        int          `json:"failures"`             // 连续探测失败次数
+	LastError       string       `json:"last_error,omitempty"` // 最近一次探测失败原因
+	LastCheckAt     *time.Time   `json:"last_check_at"`        // 最近一次探测时间，未探测时为空
+	EjectedAt       *time.Time   `json:"ejected_at,omitempty"` // 摘除时间
+	OpenConnections int          `json:"open_connections"`     // 打开的连接数
+	InUse           int          `json:"in_use"`               // 使用中的连接数
+	Idle            int          `json:"idle"`                 // 空闲连接数
+}
+
+// replica 单个从库及其健康状态
+type replica struct {
+	name string
+	db   *sql.DB
+
+	mu        sync.RWMutex
+	healthy   bool
+	lag       time.Duration
+	failures  int // 连续失败次数
+	successes int // 连续成功次数
+	lastError string
+	lastCheck time.Time
+	ejectedAt time.Time
+}
+
+// isHealthy 是否参与读请求
+func (r *replica) isHealthy() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.healthy
+}
+
+// replicaSet 从库集合，作为 dbresolver 的负载均衡策略只在正常的从库中选择，全部摘除时读主库
+type replicaSet struct {
+	config   ReplicaConfig
+	replicas []*replica
+	dialect  Dialect
+	master   *sql.DB
+	logger   logger.Interface
+	probe    func(ctx context.Context, db *sql.DB) (time.Duration, error)
+	now      func() time.Time
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// newReplicaSet 创建从库集合，初始状态均为正常
+func newReplicaSet(config ReplicaConfig, dialect Dialect, master *sql.DB, dbs []*sql.DB, log logger.Interface) *replicaSet {
+	defaults := DefaultConfig().Replica
+	if config.Interval <= 0 {
+		config.Interval = defaults.Interval
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaults.Timeout
+	}
+	if config.FailThreshold <= 0 {
+		config.FailThreshold = defaults.FailThreshold
+	}
+	if config.RecoverThreshold <= 0 {
+		config.RecoverThreshold = defaults.RecoverThreshold
+	}
+	s := &replicaSet{
+		config:  config,
+		dialect: dialect,
+		master:  master,
+		logger:  log,
+		now:     time.Now,
+	}
+	s.probe = s.probeReplica
+	for i, db := range dbs {
+		s.replicas = append(s.replicas, &replica{
+			name:    fmt.Sprintf("replica-%d", i),
+			db:      db,
+			healthy: true,
+		})
+	}
+	return s
+}
+
+// Resolve 实现 dbresolver.Policy，在正常的从库中随机选择
+func (s *replicaSet) Resolve(connPools []gorm.ConnPool) gorm.ConnPool {
+	healthy := make([]gorm.ConnPool, 0, len(connPools))
+	for _, pool := range connPools {
+		for _, r := range s.replicas {
+			if pool == gorm.ConnPool(r.db) && r.isHealthy() {
+				healthy = append(healthy, pool)
+				break
+			}
+		}
+	}
+	if len(healthy) == 0 {
+		return s.master
+	}
+	return healthy[rand.Intn(len(healthy))]
+}
+
+// start 启动定时探测
+func (s *replicaSet) start() {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.Check(context.Background())
+			}
+		}
+	}()
+}
+
+// close 停止探测并关闭从库连接
+func (s *replicaSet) close() error {
+	s.stopOnce.Do(func() {
+		if s.stop != nil {
+			close(s.stop)
+			<-s.done
+		}
+	})
+	var errs []error
+	for _, r := range s.replicas {
+		if err := r.db.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Check 探测全部从库并更新状态
+func (s *replicaSet) Check(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, r := range s.replicas {
+		wg.Add(1)
+		go func(r *replica) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+			defer cancel()
+			lag, err := s.probe(probeCtx, r.db)
+			s.record(ctx, r, lag, err)
+		}(r)
+	}
+	wg.Wait()
+}
+
+// record 记录探测结果，连续失败达到阈值时摘除，连续成功达到阈值时恢复
+func (s *replicaSet) record(ctx context.Context, r *replica, lag time.Duration, err error) {
+	if err == nil && s.config.MaxLag > 0 && lag > s.config.MaxLag {
+		err = fmt.Errorf("replication lag %s exceeds %s", lag, s.config.MaxLag)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := s.now()
+	r.lastCheck = now
+	r.lag = lag
+	if err != nil {
+		r.lastError = err.Error()
+		r.failures++
+		r.successes = 0
+		if r.healthy && r.failures >= s.config.FailThreshold {
+			r.healthy = false
+			r.ejectedAt = now
+			s.logger.Warn(ctx, "replica %s ejected after %d failed checks: %v", r.name, r.failures, err)
+		}
+		return
+	}
+
+	r.lastError = ""
+	r.failures = 0
+	r.successes++
+	if !r.healthy && r.successes >= s.config.RecoverThreshold {
+		r.healthy = true
+		r.ejectedAt = time.Time{}
+		s.logger.Info(ctx, "replica %s re-admitted after %d successful checks", r.name, r.successes)
+	}
+}
+
+// probeReplica 探测连接，配置了最大延迟时同时检查复制延迟
+func (s *replicaSet) probeReplica(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	if err := db.PingContext(ctx); err != nil {
+		return 0, err
+	}
+	if s.config.MaxLag <= 0 {
+		return 0, nil
+	}
+	return s.dialect.ReplicationLag(ctx, db)
+}
+
+// Stats 各从库状态
+func (s *replicaSet) Stats() []ReplicaStat {
+	stats := make([]ReplicaStat, 0, len(s.replicas))
+	for _, r := range s.replicas {
+		pool := r.db.Stats()
+		r.mu.RLock()
+		stat := ReplicaStat{
+			Name:            r.name,
+			State:           ReplicaHealthy,
+			Lag:             r.lag.String(),
+			Failures:        r.failures,
+			LastError:       r.lastError,
+			OpenConnections: pool.OpenConnections,
+			InUse:           pool.InUse,
+			Idle:            pool.Idle,
+		}
+		if !r.healthy {
+			stat.State = ReplicaEjected
+			ejectedAt := r.ejectedAt
+			stat.EjectedAt = &ejectedAt
+		}
+		if !r.lastCheck.IsZero() {
+			lastCheck := r.lastCheck
+			stat.LastCheckAt = &lastCheck
+		}
+		r.mu.RUnlock()
+		stats = append(stats, stat)
+	}
+	return stats
+}
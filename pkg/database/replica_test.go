@@ -0,0 +1,136 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
+)
+
+// fakeProbe 按从库返回预设的探测结果
+type fakeProbe struct {
+	mu      sync.Mutex
+	lags    map[*sql.DB]time.Duration
+	failing map[*sql.DB]bool
+}
+
+func (p *fakeProbe) set(db *sql.DB, lag time.Duration, failing bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lags[db] = lag
+	p.failing[db] = failing
+}
+
+func (p *fakeProbe) probe(_ context.Context, db *sql.DB) (time.Duration, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failing[db] {
+		return 0, errors.New("connection refused")
+	}
+	return p.lags[db], nil
+}
+
+func openSQLite(t *testing.T, name string) *sql.DB {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s_%s?mode=memory&cache=shared", t.Name(), name))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func newTestReplicaSet(t *testing.T, n int) (*replicaSet, *fakeProbe) {
+	dbs := make([]*sql.DB, 0, n)
+	for i := 0; i < n; i++ {
+		dbs = append(dbs, openSQLite(t, fmt.Sprintf("replica%d", i)))
+	}
+	set := newReplicaSet(ReplicaConfig{MaxLag: 10 * time.Second, FailThreshold: 2, RecoverThreshold: 2}, sqliteDialect{},
+		openSQLite(t, "master"), dbs, logger.Discard)
+	probe := &fakeProbe{lags: map[*sql.DB]time.Duration{}, failing: map[*sql.DB]bool{}}
+	set.probe = probe.probe
+	return set, probe
+}
+
+func TestReplicaSet_EjectAndReadmit(t *testing.T) {
+	set, probe := newTestReplicaSet(t, 2)
+	bad := set.replicas[0]
+	probe.set(bad.db, 0, true)
+
+	set.Check(context.Background())
+	assert.True(t, bad.isHealthy(), "未达到失败阈值前不摘除")
+
+	set.Check(context.Background())
+	stats := set.Stats()
+	assert.Equal(t, ReplicaEjected, stats[0].State)
+	assert.Equal(t, 2, stats[0].Failures)
+	assert.Equal(t, "connection refused", stats[0].LastError)
+	assert.NotNil(t, stats[0].EjectedAt)
+	assert.Equal(t, ReplicaHealthy, stats[1].State)
+
+	// 摘除后只选择正常的从库
+	pools := []gorm.ConnPool{set.replicas[0].db, set.replicas[1].db, set.master}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, gorm.ConnPool(set.replicas[1].db), set.Resolve(pools))
+	}
+
+	// 恢复后需连续成功达到阈值才重新加入
+	probe.set(bad.db, 0, false)
+	set.Check(context.Background())
+	assert.False(t, bad.isHealthy())
+	set.Check(context.Background())
+	assert.True(t, bad.isHealthy())
+	assert.Nil(t, set.Stats()[0].EjectedAt)
+}
+
+func TestReplicaSet_Lag(t *testing.T) {
+	set, probe := newTestReplicaSet(t, 1)
+	probe.set(set.replicas[0].db, 30*time.Second, false)
+
+	set.Check(context.Background())
+	set.Check(context.Background())
+	stats := set.Stats()
+	assert.Equal(t, ReplicaEjected, stats[0].State, "复制延迟过大时摘除")
+	assert.Equal(t, "30s", stats[0].Lag)
+	assert.Contains(t, stats[0].LastError, "exceeds 10s")
+
+	// 全部摘除时读主库
+	pools := []gorm.ConnPool{set.replicas[0].db, set.master}
+	assert.Equal(t, gorm.ConnPool(set.master), set.Resolve(pools))
+}
+
+func TestReplicaSet_Failover(t *testing.T) {
+	masterDB := openSQLite(t, "master")
+	replicaDB := openSQLite(t, "replica")
+	db, err := gorm.Open(sqlite.Dialector{Conn: masterDB}, &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&consistencyPost{}))
+	replica, err := gorm.Open(sqlite.Dialector{Conn: replicaDB}, &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, replica.AutoMigrate(&consistencyPost{}))
+
+	set := newReplicaSet(ReplicaConfig{FailThreshold: 1, RecoverThreshold: 1}, sqliteDialect{}, masterDB, []*sql.DB{replicaDB}, logger.Discard)
+	probe := &fakeProbe{lags: map[*sql.DB]time.Duration{}, failing: map[*sql.DB]bool{}}
+	set.probe = probe.probe
+	require.NoError(t, db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{sqlite.Dialector{Conn: replicaDB}, sqlite.Dialector{Conn: masterDB}},
+		Policy:   set,
+	})))
+	require.NoError(t, db.Create(&consistencyPost{ID: 1, Title: "a"}).Error)
+
+	var count int64
+	require.NoError(t, db.Model(&consistencyPost{}).Count(&count).Error)
+	assert.Zero(t, count, "从库正常时读从库")
+
+	probe.set(replicaDB, 0, true)
+	set.Check(context.Background())
+	require.NoError(t, db.Model(&consistencyPost{}).Count(&count).Error)
+	assert.EqualValues(t, 1, count, "从库摘除后读主库")
+}
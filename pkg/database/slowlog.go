@@ -0,0 +1,330 @@
+package database
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 慢查询统计的排序方式
+const (
+	SlowQuerySortTotal = "total" // 累计耗时
+	SlowQuerySortCount = "count" // 次数
+	SlowQuerySortMax   = "max"   // 最大耗时
+	SlowQuerySortP99   = "p99"   // P99耗时
+)
+
+// maxExampleLength 示例SQL的最大长度
+const maxExampleLength = 2048
+
+// SlowQueryStat 单个SQL指纹的慢查询统计
+type SlowQueryStat struct {
+	Fingerprint string    `json:"fingerprint"` // 归一化后的SQL，常量替换为 ?
+	Example     string    `json:"example"`     // 最近一次的原始SQL
+	Count       int64     `json:"count"`       // 次数
+	Errors      int64     `json:"errors"`      // 执行出错的次数
+	Rows        int64     `json:"rows"`        // 累计影响行数
+	TotalMs     float64   `json:"total_ms"`    // 累计耗时
+	AvgMs       float64   `json:"avg_ms"`      // 平均耗时
+	P50Ms       float64   `json:"p50_ms"`      // 最近样本的P50耗时
+	P99Ms       float64   `json:"p99_ms"`      // 最近样本的P99耗时
+	MaxMs       float64   `json:"max_ms"`      // 最大耗时
+	FirstSeen   time.Time `json:"first_seen"`  // 首次出现时间
+	LastSeen    time.Time `json:"last_seen"`   // 最近出现时间
+}
+
+// slowQueryEntry 指纹的累计数据，最近的耗时保存在环形缓冲区中用于计算分位数
+type slowQueryEntry struct {
+	fingerprint string
+	example     string
+	count       int64
+	errors      int64
+	rows        int64
+	total       time.Duration
+	max         time.Duration
+	samples     []time.Duration
+	next        int // 环形缓冲区下一个写入位置
+	firstSeen   time.Time
+	lastSeen    time.Time
+}
+
+// SlowQueryRecorder 进程内慢查询统计，按SQL指纹聚合，不依赖数据库的慢查询日志
+type SlowQueryRecorder struct {
+	mu              sync.Mutex
+	identQuote      byte
+	maxFingerprints int
+	samples         int
+	entries         map[string]*slowQueryEntry
+	now             func() time.Time
+}
+
+// NewSlowQueryRecorder 创建慢查询统计，identQuote 为数据库的标识符引号，用于区分标识符与字符串常量
+func NewSlowQueryRecorder(config SlowQueryConfig, identQuote byte) *SlowQueryRecorder {
+	defaults := DefaultConfig().SlowQuery
+	if config.MaxFingerprints <= 0 {
+		config.MaxFingerprints = defaults.MaxFingerprints
+	}
+	if config.Samples <= 0 {
+		config.Samples = defaults.Samples
+	}
+	return &SlowQueryRecorder{
+		identQuote:      identQuote,
+		maxFingerprints: config.MaxFingerprints,
+		samples:         config.Samples,
+		entries:         make(map[string]*slowQueryEntry),
+		now:             time.Now,
+	}
+}
+
+// Record 记录一次慢查询
+func (r *SlowQueryRecorder) Record(sql string, elapsed time.Duration, rows int64, err error) {
+	fp := fingerprint(sql, r.identQuote)
+	if fp == "" {
+		return
+	}
+	if len(sql) > maxExampleLength {
+		sql = sql[:maxExampleLength]
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	e, ok := r.entries[fp]
+	if !ok {
+		if len(r.entries) >= r.maxFingerprints {
+			r.evict()
+		}
+		e = &slowQueryEntry{
+			fingerprint: fp,
+			samples:     make([]time.Duration, 0, r.samples),
+			firstSeen:   now,
+		}
+		r.entries[fp] = e
+	}
+
+	e.example = sql
+	e.count++
+	if err != nil {
+		e.errors++
+	}
+	if rows > 0 {
+		e.rows += rows
+	}
+	e.total += elapsed
+	e.max = max(e.max, elapsed)
+	e.lastSeen = now
+	if len(e.samples) < r.samples {
+		e.samples = append(e.samples, elapsed)
+	} else {
+		e.samples[e.next] = elapsed
+	}
+	e.next = (e.next + 1) % r.samples
+}
+
+// evict 淘汰最久未出现的指纹
+func (r *SlowQueryRecorder) evict() {
+	var oldest *slowQueryEntry
+	for _, e := range r.entries {
+		if oldest == nil || e.lastSeen.Before(oldest.lastSeen) {
+			oldest = e
+		}
+	}
+	if oldest != nil {
+		delete(r.entries, oldest.fingerprint)
+	}
+}
+
+// Top 按指定方式降序返回前 n 个指纹的统计，n 小于等于0时返回全部
+func (r *SlowQueryRecorder) Top(n int, sortBy string) []SlowQueryStat {
+	r.mu.Lock()
+	stats := make([]SlowQueryStat, 0, len(r.entries))
+	for _, e := range r.entries {
+		stats = append(stats, e.stat())
+	}
+	r.mu.Unlock()
+
+	key := func(s SlowQueryStat) float64 {
+		switch sortBy {
+		case SlowQuerySortCount:
+			return float64(s.Count)
+		case SlowQuerySortMax:
+			return s.MaxMs
+		case SlowQuerySortP99:
+			return s.P99Ms
+		default:
+			return s.TotalMs
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if ki, kj := key(stats[i]), key(stats[j]); ki != kj {
+			return ki > kj
+		}
+		return stats[i].Fingerprint < stats[j].Fingerprint
+	})
+	if n > 0 && len(stats) > n {
+		stats = stats[:n]
+	}
+	return stats
+}
+
+// Reset 清空统计
+func (r *SlowQueryRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = make(map[string]*slowQueryEntry)
+}
+
+// stat 生成统计结果
+func (e *slowQueryEntry) stat() SlowQueryStat {
+	sorted := append([]time.Duration(nil), e.samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return SlowQueryStat{
+		Fingerprint: e.fingerprint,
+		Example:     e.example,
+		Count:       e.count,
+		Errors:      e.errors,
+		Rows:        e.rows,
+		TotalMs:     milliseconds(e.total),
+		AvgMs:       milliseconds(e.total / time.Duration(e.count)),
+		P50Ms:       milliseconds(percentile(sorted, 0.5)),
+		P99Ms:       milliseconds(percentile(sorted, 0.99)),
+		MaxMs:       milliseconds(e.max),
+		FirstSeen:   e.firstSeen,
+		LastSeen:    e.lastSeen,
+	}
+}
+
+// percentile 最近排名法计算分位数，sorted 需升序
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(idx, 0)]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Nanoseconds()) / 1e6
+}
+
+var (
+	// listRegexp 连续的占位符列表，如 IN (?,?,?)
+	listRegexp = regexp.MustCompile(`\(\?(?:\s?,\s?\?)*\)`)
+	// rowsRegexp 批量写入的多组值，如 VALUES (?+),(?+)
+	rowsRegexp = regexp.MustCompile(`\(\?\+\)(?:\s?,\s?\(\?\+\))+`)
+)
+
+// Fingerprint 将SQL归一化为指纹：去除注释、合并空白、转为小写，字符串与数字常量替换为 ?，
+// 占位符列表合并为 (?+)；反引号内为标识符，不做替换。PostgreSQL 的标识符引号为双引号，需通过 NewSlowQueryRecorder 指定
+func Fingerprint(sql string) string {
+	return fingerprint(sql, '`')
+}
+
+// fingerprint 按标识符引号归一化SQL，引号不是标识符引号时视为字符串常量
+func fingerprint(sql string, identQuote byte) string {
+	var b strings.Builder
+	b.Grow(len(sql))
+	space := false
+	write := func(s string) {
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteString(s)
+	}
+	writeByte := func(c byte) {
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteByte(c)
+	}
+
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ';':
+			space = true
+			i++
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			for i < len(sql) && sql[i] != '\n' {
+				i++
+			}
+			space = true
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 4
+			}
+			space = true
+		case c == identQuote:
+			end := quoteEnd(sql, i, false)
+			write(strings.ToLower(sql[i:end]))
+			i = end
+		case c == '\'' || c == '"':
+			i = quoteEnd(sql, i, true)
+			write("?")
+		case c == '$' && i+1 < len(sql) && isDigit(sql[i+1]):
+			// PostgreSQL 占位符 $1
+			for i++; i < len(sql) && isDigit(sql[i]); i++ {
+			}
+			write("?")
+		case isDigit(c) && !endsWithWord(&b, space):
+			for i < len(sql) && (isWordChar(sql[i]) || sql[i] == '.') {
+				i++
+			}
+			write("?")
+		default:
+			if c >= 'A' && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			writeByte(c)
+			i++
+		}
+	}
+
+	fp := listRegexp.ReplaceAllString(b.String(), "(?+)")
+	return rowsRegexp.ReplaceAllString(fp, "(?+)")
+}
+
+// quoteEnd 返回从 start 开始的引号内容结束后的位置，支持连续两个引号转义，字符串常量还支持反斜杠转义
+func quoteEnd(sql string, start int, backslash bool) int {
+	quote := sql[start]
+	for i := start + 1; i < len(sql); i++ {
+		switch sql[i] {
+		case '\\':
+			if backslash {
+				i++
+			}
+		case quote:
+			if i+1 < len(sql) && sql[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(sql)
+}
+
+// endsWithWord 已输出内容是否以标识符字符结尾，此时数字属于标识符的一部分，如 t1
+func endsWithWord(b *strings.Builder, space bool) bool {
+	if space || b.Len() == 0 {
+		return false
+	}
+	s := b.String()
+	return isWordChar(s[len(s)-1])
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordChar(c byte) bool {
+	return isDigit(c) || c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
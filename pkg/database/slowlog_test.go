@@ -0,0 +1,102 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/logger"
+)
+
+func TestFingerprint(t *testing.T) {
+	cases := map[string]string{
+		"SELECT * FROM `sw_sys_user` WHERE `id` = 12 AND `deleted_at` IS NULL LIMIT 1": "select * from `sw_sys_user` where `id` = ? and `deleted_at` is null limit ?",
+		"select *\n  from t1   where name = 'it''s; ok' and remark = \"a\\\"b\"":       "select * from t1 where name = ? and remark = ?",
+		"SELECT * FROM t WHERE id IN (1,2,3) /* hint */ -- tail":                       "select * from t where id in (?+)",
+		"SELECT * FROM t WHERE id IN (7)":                                              "select * from t where id in (?+)",
+		"INSERT INTO t (`a`,`b`) VALUES (1,'x'),(2,'y'),(3,'z');":                      "insert into t (`a`,`b`) values (?+)",
+		"UPDATE t SET score = -1.5e3, v2 = 0x1F WHERE id = 3":                          "update t set score = -?, v2 = ? where id = ?",
+		"SELECT * FROM t WHERE name = '名称' AND title = 'Ünïcode' AND `标题` = 1":         "select * from t where name = ? and title = ? and `标题` = ?",
+	}
+	for sql, want := range cases {
+		assert.Equal(t, want, Fingerprint(sql), sql)
+	}
+
+	// PostgreSQL 的双引号为标识符，$n 为占位符
+	assert.Equal(t, `select * from "sw_sys_user" where "id" = ? and "name" = ?`,
+		fingerprint(`SELECT * FROM "sw_sys_user" WHERE "id" = $1 AND "name" = 'a'`, '"'))
+	assert.Empty(t, Fingerprint(" -- 只有注释\n"))
+}
+
+func TestSlowQueryRecorder(t *testing.T) {
+	r := NewSlowQueryRecorder(SlowQueryConfig{MaxFingerprints: 2, Samples: 4}, '`')
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+
+	for i := 1; i <= 6; i++ {
+		r.Record(fmt.Sprintf("SELECT * FROM a WHERE id = %d", i), time.Duration(i)*time.Millisecond, 1, nil)
+	}
+	r.Record("SELECT * FROM a WHERE id = 7", 100*time.Millisecond, 0, errors.New("timeout"))
+
+	stats := r.Top(0, "")
+	require.Len(t, stats, 1)
+	stat := stats[0]
+	assert.Equal(t, "select * from a where id = ?", stat.Fingerprint)
+	assert.Equal(t, "SELECT * FROM a WHERE id = 7", stat.Example)
+	assert.EqualValues(t, 7, stat.Count)
+	assert.EqualValues(t, 1, stat.Errors)
+	assert.EqualValues(t, 6, stat.Rows)
+	assert.Equal(t, 121.0, stat.TotalMs)
+	assert.Equal(t, 100.0, stat.MaxMs)
+	// 环形缓冲区只保留最近4个样本：4、5、6、100
+	assert.Equal(t, 5.0, stat.P50Ms)
+	assert.Equal(t, 100.0, stat.P99Ms)
+
+	// 超出指纹数时淘汰最久未出现的
+	now = now.Add(time.Second)
+	r.Record("SELECT * FROM b", 50*time.Millisecond, 0, nil)
+	r.Record("SELECT * FROM b", 50*time.Millisecond, 0, nil)
+	now = now.Add(time.Second)
+	r.Record("SELECT * FROM c", 300*time.Millisecond, 0, nil)
+	stats = r.Top(0, SlowQuerySortCount)
+	require.Len(t, stats, 2)
+	assert.Equal(t, "select * from b", stats[0].Fingerprint)
+	assert.Equal(t, "select * from c", stats[1].Fingerprint)
+
+	stats = r.Top(1, SlowQuerySortMax)
+	require.Len(t, stats, 1)
+	assert.Equal(t, "select * from c", stats[0].Fingerprint)
+
+	r.Reset()
+	assert.Empty(t, r.Top(0, ""))
+}
+
+func TestClient_SlowQueryStats(t *testing.T) {
+	config := DefaultConfig()
+	config.Driver = "sqlite"
+	config.Master = fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	config.Log.Level = logger.Silent
+	config.SlowQuery.Threshold = 0
+	config.Tracing.Enabled = false
+
+	client, err := NewClient(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	require.NoError(t, client.DB().AutoMigrate(&consistencyPost{}))
+	client.ResetSlowQueryStats()
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, client.DB().Create(&consistencyPost{ID: int64(i), Title: "a"}).Error)
+	}
+	var post consistencyPost
+	require.NoError(t, client.DB().First(&post, 2).Error)
+
+	stats := client.SlowQueryStats(10, SlowQuerySortCount)
+	require.Len(t, stats, 2, "日志级别为 Silent 时同样统计")
+	assert.EqualValues(t, 3, stats[0].Count)
+	assert.Contains(t, stats[0].Fingerprint, "insert into `t_post`")
+	assert.EqualValues(t, 1, stats[1].Count)
+}
@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"sweet/pkg/auth"
+)
+
+const (
+	// 租户插件名称
+	tenantPluginName = "tenant"
+	// ColumnTenantID 租户列
+	ColumnTenantID = "tenant_id"
+)
+
+// tenantScopeKey 上下文中显式租户范围的键
+type tenantScopeKey struct{}
+
+// tenantScope 显式指定的租户范围，all 为 true 时不限租户
+type tenantScope struct {
+	id  int64
+	all bool
+}
+
+// WithTenant 将后续数据库操作限定在指定租户，优先于认证主体中的租户
+// 用于登录等尚未认证的场景，以及超级租户切换到某个租户操作
+func WithTenant(ctx context.Context, tenantID int64) context.Context {
+	return context.WithValue(ctx, tenantScopeKey{}, tenantScope{id: tenantID})
+}
+
+// WithAllTenants 后续数据库操作不限租户，仅供系统任务及已校验为超级租户的调用方使用
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantScopeKey{}, tenantScope{all: true})
+}
+
+// TenantFrom 解析上下文的租户范围，返回 false 表示不限租户
+// 依次取显式范围、认证主体；超级租户与未认证的系统调用不限租户
+func TenantFrom(ctx context.Context) (int64, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	if scope, ok := ctx.Value(tenantScopeKey{}).(tenantScope); ok {
+		return scope.id, !scope.all
+	}
+	if p, ok := auth.PrincipalFrom(ctx); ok && !p.IsSuperTenant() {
+		return p.TenantID, true
+	}
+	return 0, false
+}
+
+// hasTenantSource 上下文中是否有显式租户范围或认证主体，两者都没有时 TenantFrom 按系统调用不限租户
+func hasTenantSource(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	if _, ok := ctx.Value(tenantScopeKey{}).(tenantScope); ok {
+		return true
+	}
+	_, ok := auth.PrincipalFrom(ctx)
+	return ok
+}
+
+// CurrentTenant 新数据归属的租户：有租户范围时为该租户，否则为认证主体的租户，系统调用为超级租户
+// 用于在插入前按租户校验唯一性等场景
+func CurrentTenant(ctx context.Context) int64 {
+	if tenantID, ok := TenantFrom(ctx); ok {
+		return tenantID
+	}
+	if p, ok := auth.PrincipalFrom(ctx); ok && p.TenantID != 0 {
+		return p.TenantID
+	}
+	return auth.SuperTenantID
+}
+
+// TenantPlugin 多租户插件
+// 含 tenant_id 列的模型在查询、更新、删除时自动追加租户条件，创建时写入当前租户；
+// 原生 SQL（Raw/Exec）不做处理，需自行带上租户条件
+//
+// 注意：上下文既无租户范围也无认证主体时按系统调用处理，不限租户（fail-open），
+// 查询会返回全部租户的数据，创建时不写入租户而落入列默认值（超级租户）。
+// 脱离请求上下文的异步任务（如 context.Background() 启动的协程）需先用 WithTenant 固定租户，
+// 插件在这种情况下写入含租户列的表时会输出警告
+type TenantPlugin struct{}
+
+// NewTenantPlugin 创建多租户插件
+func NewTenantPlugin() *TenantPlugin {
+	return &TenantPlugin{}
+}
+
+// Name 返回插件名称
+func (p *TenantPlugin) Name() string {
+	return tenantPluginName
+}
+
+// Initialize 初始化插件
+func (p *TenantPlugin) Initialize(db *gorm.DB) error {
+	// 注册回调函数
+	if err := p.registerCallbacks(db); err != nil {
+		return fmt.Errorf("failed to register tenant callbacks: %w", err)
+	}
+	return nil
+}
+
+// registerCallbacks 注册回调函数
+func (p *TenantPlugin) registerCallbacks(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("tenant:before_create", p.beforeCreate); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Before("gorm:query").Register("tenant:before_query", p.scope); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("tenant:before_row", p.scope); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("tenant:before_update", p.scope); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("gorm:delete").Register("tenant:before_delete", p.scope); err != nil {
+		return err
+	}
+	return nil
+}
+
+// scope 追加当前租户条件
+func (p *TenantPlugin) scope(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || stmt.Schema.LookUpField(ColumnTenantID) == nil {
+		return
+	}
+	tenantID, ok := TenantFrom(stmt.Context)
+	if !ok {
+		return
+	}
+
+	// 与软删除相同：已有条件中含 Or 时先整体加括号，避免 a OR b AND tenant_id = ? 的优先级问题
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) >= 1 {
+			for _, expr := range where.Exprs {
+				if orCond, ok := expr.(clause.OrConditions); ok && len(orCond.Exprs) == 1 {
+					where.Exprs = []clause.Expression{clause.And(where.Exprs...)}
+					c.Expression = where
+					stmt.Clauses["WHERE"] = c
+					break
+				}
+			}
+		}
+	}
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: ColumnTenantID}, Value: tenantID},
+	}})
+}
+
+// beforeCreate 写入当前租户，覆盖请求中传入的值，避免跨租户写入
+func (p *TenantPlugin) beforeCreate(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil {
+		return
+	}
+	field := stmt.Schema.LookUpField(ColumnTenantID)
+	if field == nil {
+		return
+	}
+	tenantID, ok := TenantFrom(stmt.Context)
+	if !ok {
+		if !hasTenantSource(stmt.Context) {
+			db.Logger.Warn(stmt.Context, "写入 %s 时上下文中没有租户，数据将归属列默认租户", stmt.Table)
+		}
+		return
+	}
+
+	switch dest := stmt.Dest.(type) {
+	case map[string]any:
+		dest[ColumnTenantID] = tenantID
+		return
+	case []map[string]any:
+		for _, row := range dest {
+			row[ColumnTenantID] = tenantID
+		}
+		return
+	}
+
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			if rv := reflect.Indirect(stmt.ReflectValue.Index(i)); rv.Kind() == reflect.Struct {
+				if err := field.Set(stmt.Context, rv, tenantID); err != nil {
+					db.AddError(err)
+					return
+				}
+			}
+		}
+	case reflect.Struct:
+		if err := field.Set(stmt.Context, stmt.ReflectValue, tenantID); err != nil {
+			db.AddError(err)
+		}
+	}
+}
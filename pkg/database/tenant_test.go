@@ -0,0 +1,161 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"sweet/pkg/auth"
+)
+
+type tenantRole struct {
+	ID       int64 `gorm:"primaryKey"`
+	TenantID int64 `gorm:"not null;default:1"`
+	Code     string
+}
+
+func (tenantRole) TableName() string { return "t_role" }
+
+func newTenantDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	require.NoError(t, db.AutoMigrate(&tenantRole{}))
+	require.NoError(t, db.Use(NewTenantPlugin()))
+	require.NoError(t, db.Create(&[]tenantRole{
+		{ID: 1, TenantID: 1, Code: "admin"},
+		{ID: 2, TenantID: 2, Code: "admin"},
+		{ID: 3, TenantID: 2, Code: "user"},
+		{ID: 4, TenantID: 3, Code: "user"},
+	}).Error)
+	return db
+}
+
+func tenantCtx(tenantID int64) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{Uid: 1, TenantID: tenantID})
+}
+
+func TestTenantFrom(t *testing.T) {
+	_, ok := TenantFrom(context.Background())
+	assert.False(t, ok, "系统调用不限租户")
+	_, ok = TenantFrom(tenantCtx(auth.SuperTenantID))
+	assert.False(t, ok, "超级租户不限租户")
+
+	id, ok := TenantFrom(tenantCtx(2))
+	assert.True(t, ok)
+	assert.EqualValues(t, 2, id)
+
+	id, ok = TenantFrom(WithTenant(tenantCtx(auth.SuperTenantID), 3))
+	assert.True(t, ok, "显式范围优先")
+	assert.EqualValues(t, 3, id)
+
+	_, ok = TenantFrom(WithAllTenants(tenantCtx(2)))
+	assert.False(t, ok)
+
+	assert.EqualValues(t, auth.SuperTenantID, CurrentTenant(context.Background()))
+	assert.EqualValues(t, auth.SuperTenantID, CurrentTenant(tenantCtx(auth.SuperTenantID)))
+	assert.EqualValues(t, 2, CurrentTenant(tenantCtx(2)))
+}
+
+func TestTenantPlugin_Query(t *testing.T) {
+	db := newTenantDB(t)
+
+	var roles []tenantRole
+	require.NoError(t, db.WithContext(tenantCtx(2)).Order("id").Find(&roles).Error)
+	assert.Len(t, roles, 2)
+
+	// Or 条件需整体加括号，不能越过租户条件
+	roles = nil
+	require.NoError(t, db.WithContext(tenantCtx(2)).Where("code = ?", "admin").Or("code = ?", "user").Find(&roles).Error)
+	assert.Len(t, roles, 2)
+	for _, role := range roles {
+		assert.EqualValues(t, 2, role.TenantID)
+	}
+
+	var count int64
+	require.NoError(t, db.WithContext(tenantCtx(3)).Model(&tenantRole{}).Count(&count).Error)
+	assert.EqualValues(t, 1, count)
+
+	require.NoError(t, db.WithContext(tenantCtx(auth.SuperTenantID)).Model(&tenantRole{}).Count(&count).Error)
+	assert.EqualValues(t, 4, count, "超级租户可查看全部")
+
+	err := db.WithContext(tenantCtx(3)).First(&tenantRole{}, 1).Error
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestTenantPlugin_Create(t *testing.T) {
+	db := newTenantDB(t)
+
+	role := tenantRole{ID: 10, TenantID: 3, Code: "guest"}
+	require.NoError(t, db.WithContext(tenantCtx(2)).Create(&role).Error)
+	assert.EqualValues(t, 2, role.TenantID, "不能写入其他租户")
+
+	require.NoError(t, db.WithContext(WithTenant(context.Background(), 3)).Model(&tenantRole{}).
+		Create(map[string]any{"id": 11, "code": "guest"}).Error)
+	var got tenantRole
+	require.NoError(t, db.First(&got, 11).Error)
+	assert.EqualValues(t, 3, got.TenantID)
+
+	// 不限租户时保持传入值
+	require.NoError(t, db.Create(&tenantRole{ID: 12, TenantID: 5, Code: "guest"}).Error)
+	var other tenantRole
+	require.NoError(t, db.First(&other, 12).Error)
+	assert.EqualValues(t, 5, other.TenantID)
+}
+
+func TestTenantPlugin_UpdateDelete(t *testing.T) {
+	db := newTenantDB(t)
+	ctx := tenantCtx(2)
+
+	result := db.WithContext(ctx).Model(&tenantRole{}).Where("code = ?", "user").Update("code", "member")
+	require.NoError(t, result.Error)
+	assert.EqualValues(t, 1, result.RowsAffected)
+
+	var got tenantRole
+	require.NoError(t, db.First(&got, 4).Error)
+	assert.Equal(t, "user", got.Code, "其他租户的数据不受影响")
+
+	result = db.WithContext(ctx).Delete(&tenantRole{}, 4)
+	require.NoError(t, result.Error)
+	assert.Zero(t, result.RowsAffected)
+
+	result = db.WithContext(ctx).Where("code = ?", "admin").Delete(&tenantRole{})
+	require.NoError(t, result.Error)
+	assert.EqualValues(t, 1, result.RowsAffected)
+	require.NoError(t, db.First(&tenantRole{}, 1).Error, "仅删除本租户数据")
+}
+
+// warnRecorder 记录插件输出的警告
+type warnRecorder struct {
+	logger.Interface
+	warns []string
+}
+
+func (r *warnRecorder) Warn(_ context.Context, msg string, args ...interface{}) {
+	r.warns = append(r.warns, fmt.Sprintf(msg, args...))
+}
+
+func TestTenantPlugin_CreateWithoutTenant(t *testing.T) {
+	db := newTenantDB(t)
+	rec := &warnRecorder{Interface: logger.Discard}
+	db = db.Session(&gorm.Session{Logger: rec})
+
+	require.NoError(t, db.WithContext(WithTenant(context.Background(), 2)).Create(&tenantRole{ID: 20, Code: "a"}).Error)
+	require.NoError(t, db.WithContext(tenantCtx(auth.SuperTenantID)).Create(&tenantRole{ID: 21, Code: "b"}).Error)
+	assert.Empty(t, rec.warns, "有租户范围或认证主体时不警告")
+
+	// 脱离请求上下文写入时落入默认租户并警告
+	require.NoError(t, db.WithContext(context.Background()).Create(&tenantRole{ID: 22, Code: "c"}).Error)
+	require.Len(t, rec.warns, 1)
+	assert.Contains(t, rec.warns[0], "t_role")
+}
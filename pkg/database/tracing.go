@@ -178,7 +178,7 @@ func (p *TracingPlugin) before(db *gorm.DB, operation string) {
 
 	// 设置基本属性
 	span.SetAttributes(
-		attribute.String("db.system", "mysql"),
+		attribute.String("db.system", dbSystem(db)),
 		attribute.String("db.operation", operation),
 	)
 
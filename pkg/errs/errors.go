@@ -21,6 +21,7 @@ var (
 	ErrEmailNotFound  = NewError(1012, "邮箱不存在")
 	ErrEmailExists    = NewError(1013, "该邮箱已被绑定")
 	ErrPassword       = NewError(1014, "密码错误")
+	ErrUserDisabled   = NewError(1015, "账号已被禁用")
 )
 
 // system role error
@@ -32,3 +33,64 @@ var (
 	ErrSystemRoleCannotModify = NewError(1024, "系统内置角色不允许修改")
 	ErrRoleMenuIdsEmpty       = NewError(1025, "角色菜单ID列表不能为空")
 )
+
+// system auth error
+var (
+	ErrOAuthProvider  = NewError(1030, "第三方登录提供方不存在")
+	ErrOAuthLogin     = NewError(1031, "第三方登录失败")
+	ErrOAuthNotBound  = NewError(1032, "第三方账号未绑定系统账号")
+	ErrOAuthBound     = NewError(1033, "第三方账号已被绑定")
+	ErrApiKeyInvalid  = NewError(1034, "API密钥无效")
+	ErrApiKeyExpired  = NewError(1035, "API密钥已过期")
+	ErrApiKeyRevoked  = NewError(1036, "API密钥已吊销")
+	ErrApiKeyScope    = NewError(1037, "API密钥无权访问该接口")
+	ErrApiKeyNotFound = NewError(1038, "API密钥不存在")
+
+	ErrImpersonateForbidden    = NewError(1039, "仅超级管理员可以模拟登录")
+	ErrImpersonateTarget       = NewError(1040, "不允许模拟该用户")
+	ErrImpersonationRestricted = NewError(1041, "模拟登录状态下禁止该操作")
+
+	ErrSignatureInvalid = NewError(1042, "请求签名无效")
+	ErrSignatureExpired = NewError(1043, "请求签名已过期")
+	ErrSignatureReplay  = NewError(1044, "重复的请求签名")
+
+	ErrPasswordCipher  = NewError(1045, "密码密文无效")
+	ErrPasswordExpired = NewError(1046, "登录请求已过期，请重新获取公钥后重试")
+	ErrPasswordLength  = NewError(1047, "密码长度需为6-15位")
+	ErrPasswordSame    = NewError(1048, "新密码不能与原密码相同")
+)
+
+// listing error
+var (
+	ErrCursorInvalid = NewError(1049, "分页游标无效，请刷新后重试")
+)
+
+// tenant error
+var (
+	ErrTenantNotFound   = NewError(1050, "租户不存在")
+	ErrTenantDisabled   = NewError(1051, "租户已被禁用")
+	ErrTenantForbidden  = NewError(1052, "仅超级租户可执行该操作")
+	ErrTenantCodeExists = NewError(1053, "租户编码已存在")
+)
+
+// version error
+var (
+	// 乐观锁冲突，属于 ErrConflict 的一种：数据在读取后已被他人修改
+	ErrVersionConflict = NewSubError(ErrConflict, 1054, "数据已被他人修改，请刷新后重试")
+)
+
+// api key error
+var (
+	ErrApiKeyOwner = NewError(1055, "仅超级管理员可以为其他用户或服务创建API密钥")
+	ErrApiKeyGrant = NewError(1056, "API密钥授权范围超出归属用户的角色权限")
+)
+
+// signature error
+var (
+	ErrSignatureBodyTooLarge = NewError(1057, "请求体超出大小上限")
+)
+
+// password reset error
+var (
+	ErrPasswordResetRequired = NewError(1058, "请先修改初始密码")
+)
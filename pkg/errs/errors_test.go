@@ -0,0 +1,21 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionConflict_IsConflict(t *testing.T) {
+	assert.ErrorIs(t, ErrVersionConflict, ErrConflict)
+	assert.ErrorIs(t, fmt.Errorf("更新角色: %w", ErrVersionConflict), ErrConflict)
+	assert.NotErrorIs(t, ErrConflict, ErrVersionConflict)
+	assert.Equal(t, 1054, ErrVersionConflict.Code, "响应仍使用自身的错误码")
+
+	var e *Error
+	assert.True(t, errors.As(ErrVersionConflict, &e))
+	assert.Same(t, ErrVersionConflict, e)
+	assert.Nil(t, ErrNotFound.Unwrap())
+}
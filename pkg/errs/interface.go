@@ -3,6 +3,8 @@ package errs
 type Error struct {
 	Code int    `json:"code"`
 	Msg  string `json:"msg"`
+
+	parent *Error
 }
 
 func NewError(code int, msg string) *Error {
@@ -12,6 +14,23 @@ func NewError(code int, msg string) *Error {
 	}
 }
 
+// NewSubError 创建从属于 parent 的错误，errors.Is(err, parent) 成立，响应仍使用自身的错误码
+func NewSubError(parent *Error, code int, msg string) *Error {
+	return &Error{
+		Code:   code,
+		Msg:    msg,
+		parent: parent,
+	}
+}
+
 func (e *Error) Error() string {
 	return e.Msg
 }
+
+// Unwrap 返回上级错误，没有时返回 nil
+func (e *Error) Unwrap() error {
+	if e.parent == nil {
+		return nil
+	}
+	return e.parent
+}
@@ -1,175 +1,220 @@
 package logger
 
 import (
+	"fmt"
+	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap/zapcore"
 )
 
-// asyncCore 异步日志核心
-type asyncCore struct {
-	zapcore.Core
-	buffer        chan zapcore.Entry
-	bufferSize    int
-	flushInterval time.Duration
-	wg            sync.WaitGroup
-	mu            sync.RWMutex
-	closed        bool
-	stopCh        chan struct{}
-}
-
-// newAsyncCore 创建异步核心
-func newAsyncCore(core zapcore.Core, bufferSize int, flushInterval time.Duration) *asyncCore {
-	async := &asyncCore{
-		Core:          core,
-		buffer:        make(chan zapcore.Entry, bufferSize),
-		bufferSize:    bufferSize,
-		flushInterval: flushInterval,
-		stopCh:        make(chan struct{}),
-	}
-
-	// 启动后台写入goroutine
-	async.wg.Add(1)
-	go async.writeLoop()
+const (
+	// defaultBufferSize 未配置缓冲区大小时使用
+	defaultBufferSize = 256 * 1024
+	// maxBatchSize 单次写入的最大字节数，避免一次写入超过日志文件的分割大小
+	maxBatchSize = 32 * 1024
+)
 
-	return async
+// AsyncStats 异步写入统计，进程内所有异步日志共享
+type AsyncStats struct {
+	Enqueued uint64 // 写入缓冲区的条目数
+	Overflow uint64 // 写入时缓冲区已满的次数
+	Dropped  uint64 // 缓冲区已满被丢弃的条目数（drop_newest）
 }
 
-// writeLoop 写入循环
-func (a *asyncCore) writeLoop() {
-	defer a.wg.Done()
-
-	ticker := time.NewTicker(a.flushInterval)
-	defer ticker.Stop()
+var asyncEnqueued, asyncOverflow, asyncDropped atomic.Uint64
 
-	var entries []zapcore.Entry
-	batchSize := 100 // 批量写入大小
-
-	for {
-		select {
-		case entry := <-a.buffer:
-			entries = append(entries, entry)
-			// 如果达到批量大小，立即写入
-			if len(entries) >= batchSize {
-				a.flushEntries(entries)
-				entries = entries[:0] // 重置切片
-			}
+// GetAsyncStats 获取异步写入统计，用于监控缓冲区是否过小
+func GetAsyncStats() AsyncStats {
+	return AsyncStats{
+		Enqueued: asyncEnqueued.Load(),
+		Overflow: asyncOverflow.Load(),
+		Dropped:  asyncDropped.Load(),
+	}
+}
 
-		case <-ticker.C:
-			// 定时刷新
-			if len(entries) > 0 {
-				a.flushEntries(entries)
-				entries = entries[:0]
-			}
+// asyncWriter 异步写入器
+// zap 在调用方协程中编码日志（包括 With 与调用时传入的字段），这里只缓冲编码后的字节，
+// 由后台协程批量写入，因此字段不会丢失，也不会在编码前被调用方修改
+type asyncWriter struct {
+	out    zapcore.WriteSyncer
+	limit  int
+	policy OverflowPolicy
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond // 有待写入的日志、Sync 请求或已关闭
+	notFull  *sync.Cond // 缓冲区有空间或已关闭
+	pending  [][]byte
+	size     int
+	waiters  []chan struct{} // 等待缓冲区写完的 Sync 调用
+	closed   bool
+
+	outMu sync.Mutex // 串行写入底层输出
+	done  chan struct{}
+	stop  chan struct{}
+}
 
-		case <-a.stopCh:
-			// 关闭时刷新剩余条目
-			if len(entries) > 0 {
-				a.flushEntries(entries)
-			}
-			// 处理缓冲区中剩余的条目
-			for {
-				select {
-				case entry := <-a.buffer:
-					a.flushEntry(entry)
-				default:
-					return
-				}
-			}
-		}
+// newAsyncWriter 创建异步写入器，flushInterval 大于0时定时同步底层输出
+func newAsyncWriter(out zapcore.WriteSyncer, bufferSize int, flushInterval time.Duration, policy OverflowPolicy) *asyncWriter {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
 	}
-}
+	if policy == "" {
+		policy = OverflowSync
+	}
+	w := &asyncWriter{
+		out:    out,
+		limit:  bufferSize,
+		policy: policy,
+		done:   make(chan struct{}),
+		stop:   make(chan struct{}),
+	}
+	w.notEmpty = sync.NewCond(&w.mu)
+	w.notFull = sync.NewCond(&w.mu)
 
-// flushEntries 批量刷新条目
-func (a *asyncCore) flushEntries(entries []zapcore.Entry) {
-	for _, entry := range entries {
-		a.flushEntry(entry)
+	go w.writeLoop()
+	if flushInterval > 0 {
+		go w.syncLoop(flushInterval)
 	}
+	return w
 }
 
-// flushEntry 刷新单个条目
-func (a *asyncCore) flushEntry(entry zapcore.Entry) {
-	// 这里需要重新构造CheckedEntry来写入
-	if ce := a.Core.Check(entry, nil); ce != nil {
-		ce.Write()
+// Write 写入缓冲区，缓冲区已满时按 policy 处理；单条日志超过缓冲区大小时在缓冲区为空后写入
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	// zap 在 Write 返回后复用 p
+	record := append([]byte(nil), p...)
+
+	w.mu.Lock()
+	overflow := false
+	for !w.closed && w.size > 0 && w.size+len(record) > w.limit {
+		if !overflow {
+			overflow = true
+			asyncOverflow.Add(1)
+		}
+		switch w.policy {
+		case OverflowBlock:
+			w.notFull.Wait()
+		case OverflowDropNewest:
+			w.mu.Unlock()
+			asyncDropped.Add(1)
+			return len(p), nil
+		default:
+			w.mu.Unlock()
+			return w.writeOut(record)
+		}
+	}
+	if w.closed {
+		// 关闭后直接同步写入
+		w.mu.Unlock()
+		return w.writeOut(record)
 	}
-}
 
-// Write 写入日志条目（异步）
-func (a *asyncCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	w.pending = append(w.pending, record)
+	w.size += len(record)
+	asyncEnqueued.Add(1)
+	w.notEmpty.Signal()
+	w.mu.Unlock()
+	return len(p), nil
+}
 
-	if a.closed {
-		// 如果已关闭，直接同步写入
-		return a.Core.Write(entry, fields)
+// Sync 等待调用前写入的日志全部写出后同步底层输出，Panic、Fatal 级别的日志由 zap 调用
+func (w *asyncWriter) Sync() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return w.syncOut()
 	}
+	ch := make(chan struct{})
+	w.waiters = append(w.waiters, ch)
+	w.notEmpty.Signal()
+	w.mu.Unlock()
 
-	// 创建条目副本（包含字段）
-	entryCopy := entry
-	entryCopy.Stack = entry.Stack // 复制堆栈信息
+	<-ch
+	return w.syncOut()
+}
 
-	// 尝试异步写入
-	select {
-	case a.buffer <- entryCopy:
+// Close 写出缓冲区中的全部日志后停止后台协程，之后的写入改为同步写入
+func (w *asyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
 		return nil
-	default:
-		// 缓冲区满时，直接同步写入
-		return a.Core.Write(entry, fields)
 	}
+	w.closed = true
+	w.notEmpty.Signal()
+	w.notFull.Broadcast()
+	w.mu.Unlock()
+
+	<-w.done
+	close(w.stop)
+	return w.syncOut()
 }
 
-// Sync 同步刷新
-func (a *asyncCore) Sync() error {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
-
-	if a.closed {
-		return a.Core.Sync()
+// writeLoop 取出缓冲区中的全部日志批量写入，关闭且缓冲区为空时退出
+func (w *asyncWriter) writeLoop() {
+	defer close(w.done)
+	var batch []byte
+	for {
+		w.mu.Lock()
+		for len(w.pending) == 0 && len(w.waiters) == 0 && !w.closed {
+			w.notEmpty.Wait()
+		}
+		records, waiters, closed := w.pending, w.waiters, w.closed
+		w.pending, w.waiters, w.size = nil, nil, 0
+		w.notFull.Broadcast()
+		w.mu.Unlock()
+
+		batch = batch[:0]
+		for _, record := range records {
+			if len(batch) > 0 && len(batch)+len(record) > maxBatchSize {
+				w.writeBatch(batch)
+				batch = batch[:0]
+			}
+			batch = append(batch, record...)
+		}
+		if len(batch) > 0 {
+			w.writeBatch(batch)
+		}
+		for _, ch := range waiters {
+			close(ch)
+		}
+		if closed && len(records) == 0 {
+			return
+		}
 	}
+}
 
-	// 等待缓冲区清空
-	for len(a.buffer) > 0 {
-		time.Sleep(10 * time.Millisecond)
+// syncLoop 定时同步底层输出
+func (w *asyncWriter) syncLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_ = w.syncOut()
+		case <-w.stop:
+			return
+		}
 	}
-
-	return a.Core.Sync()
 }
 
-// Close 关闭异步核心
-func (a *asyncCore) Close() error {
-	a.mu.Lock()
-	defer a.mu.Unlock()
-
-	if a.closed {
-		return nil
+// writeBatch 后台写入失败时输出到标准错误，与 zap 内部错误的处理方式一致
+func (w *asyncWriter) writeBatch(batch []byte) {
+	if _, err := w.writeOut(batch); err != nil {
+		fmt.Fprintf(os.Stderr, "%v async logger write error: %v\n", time.Now(), err)
 	}
-
-	a.closed = true
-	close(a.stopCh)
-	a.wg.Wait()
-
-	return a.Core.Sync()
 }
 
-// Check 检查日志级别
-func (a *asyncCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
-	return a.Core.Check(entry, ce)
+func (w *asyncWriter) writeOut(p []byte) (int, error) {
+	w.outMu.Lock()
+	defer w.outMu.Unlock()
+	return w.out.Write(p)
 }
 
-// With 添加字段
-func (a *asyncCore) With(fields []zapcore.Field) zapcore.Core {
-	return &asyncCore{
-		Core:          a.Core.With(fields),
-		buffer:        a.buffer,
-		bufferSize:    a.bufferSize,
-		flushInterval: a.flushInterval,
-		stopCh:        a.stopCh,
-	}
+func (w *asyncWriter) syncOut() error {
+	w.outMu.Lock()
+	defer w.outMu.Unlock()
+	return w.out.Sync()
 }
-
-// Enabled 检查是否启用
-func (a *asyncCore) Enabled(level zapcore.Level) bool {
-	return a.Core.Enabled(level)
-}
\ No newline at end of file
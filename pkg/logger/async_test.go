@@ -0,0 +1,181 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// gatedWriter 可阻塞的输出，gate 未关闭时写入阻塞，entered 通知已进入写入
+type gatedWriter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	gate    chan struct{}
+	entered chan struct{}
+}
+
+func newGatedWriter(blocked bool) *gatedWriter {
+	w := &gatedWriter{gate: make(chan struct{}), entered: make(chan struct{}, 16)}
+	if !blocked {
+		close(w.gate)
+	}
+	return w
+}
+
+func (w *gatedWriter) Write(p []byte) (int, error) {
+	select {
+	case w.entered <- struct{}{}:
+	default:
+	}
+	<-w.gate
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *gatedWriter) Sync() error {
+	return nil
+}
+
+func (w *gatedWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestAsyncWriter_KeepsFields(t *testing.T) {
+	out := newGatedWriter(false)
+	w := newAsyncWriter(out, 1024, 0, OverflowSync)
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	log := zap.New(zapcore.NewCore(encoder, w, zapcore.InfoLevel)).With(zap.String("service", "sweet"))
+
+	fields := []zap.Field{zap.Int64("file_id", 7), zap.Error(errors.New("disk full"))}
+	log.Info("上传失败", fields...)
+	fields[0] = zap.Int64("file_id", 8) // 调用返回后修改不影响已写入的日志
+	require.NoError(t, w.Close())
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(out.String())), &record))
+	assert.Equal(t, "上传失败", record["msg"])
+	assert.Equal(t, "sweet", record["service"])
+	assert.EqualValues(t, 7, record["file_id"])
+	assert.Equal(t, "disk full", record["error"])
+}
+
+// fillBuffer 等待后台协程阻塞在第一条日志的写入上，再写满 limit 为25字节的缓冲区
+func fillBuffer(t *testing.T, w *asyncWriter, out *gatedWriter) {
+	t.Helper()
+	_, err := w.Write([]byte("first\n"))
+	require.NoError(t, err)
+	select {
+	case <-out.entered:
+	case <-time.After(time.Second):
+		t.Fatal("后台协程未开始写入")
+	}
+	for _, line := range []string{"line-0001\n", "line-0002\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+}
+
+func TestAsyncWriter_DropNewest(t *testing.T) {
+	out := newGatedWriter(true)
+	w := newAsyncWriter(out, 25, 0, OverflowDropNewest)
+	fillBuffer(t, w, out)
+
+	before := GetAsyncStats()
+	_, err := w.Write([]byte("line-0003\n"))
+	require.NoError(t, err)
+	after := GetAsyncStats()
+	assert.Equal(t, before.Overflow+1, after.Overflow)
+	assert.Equal(t, before.Dropped+1, after.Dropped)
+
+	close(out.gate)
+	require.NoError(t, w.Close())
+	assert.Equal(t, "first\nline-0001\nline-0002\n", out.String())
+}
+
+func TestAsyncWriter_Block(t *testing.T) {
+	out := newGatedWriter(true)
+	w := newAsyncWriter(out, 25, 0, OverflowBlock)
+	fillBuffer(t, w, out)
+
+	written := make(chan struct{})
+	go func() {
+		_, _ = w.Write([]byte("line-0003\n"))
+		close(written)
+	}()
+	select {
+	case <-written:
+		t.Fatal("缓冲区已满时应阻塞")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(out.gate)
+	<-written
+	require.NoError(t, w.Close())
+	assert.Equal(t, "first\nline-0001\nline-0002\nline-0003\n", out.String(), "阻塞策略不丢日志且保持顺序")
+}
+
+func TestAsyncWriter_SyncFallback(t *testing.T) {
+	out := newGatedWriter(true)
+	w := newAsyncWriter(out, 25, 0, OverflowSync)
+	fillBuffer(t, w, out)
+
+	before := GetAsyncStats()
+	written := make(chan struct{})
+	go func() {
+		_, _ = w.Write([]byte("line-0003\n"))
+		close(written)
+	}()
+	// 缓冲区已满，写入改为同步写入，等待后台协程释放输出
+	require.Eventually(t, func() bool {
+		return GetAsyncStats().Overflow == before.Overflow+1
+	}, time.Second, time.Millisecond)
+	close(out.gate)
+	<-written
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, before.Dropped, GetAsyncStats().Dropped)
+	for _, line := range []string{"first", "line-0001", "line-0002", "line-0003"} {
+		assert.Contains(t, out.String(), line+"\n")
+	}
+}
+
+func TestAsyncWriter_SyncAndClose(t *testing.T) {
+	out := newGatedWriter(false)
+	w := newAsyncWriter(out, 1<<20, 0, OverflowBlock)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				_, _ = w.Write([]byte("0123456789\n"))
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, w.Sync())
+	assert.Equal(t, 8*500, strings.Count(out.String(), "\n"), "Sync 返回前写出全部日志")
+
+	_, _ = w.Write([]byte("before close\n"))
+	require.NoError(t, w.Close())
+	assert.True(t, strings.HasSuffix(out.String(), "before close\n"), "Close 写出缓冲区")
+
+	// 关闭后同步写入
+	_, err := w.Write([]byte("after close\n"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(out.String(), "after close\n"))
+	assert.NoError(t, w.Close())
+}
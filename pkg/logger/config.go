@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -33,6 +34,15 @@ const (
 	TextFormat Format = "text" // 文本格式
 )
 
+// OverflowPolicy 异步缓冲区已满时的处理方式
+type OverflowPolicy string
+
+const (
+	OverflowBlock      OverflowPolicy = "block"       // 阻塞等待缓冲区有空间，不丢日志
+	OverflowDropNewest OverflowPolicy = "drop_newest" // 丢弃当前日志，不阻塞业务
+	OverflowSync       OverflowPolicy = "sync"        // 改为同步写入，可能与缓冲区中的日志乱序
+)
+
 // RotateConfig 日志分割配置
 type RotateConfig struct {
 	// MaxSize 单个日志文件最大大小（MB）
@@ -67,10 +77,13 @@ type Config struct {
 	Development bool `json:"development" yaml:"development"`
 	// Async 是否启用异步写入
 	Async bool `json:"async" yaml:"async"`
-	// BufferSize 异步缓冲区大小
+	// BufferSize 异步缓冲区大小（字节），按编码后的日志计算；早期版本为条目数，
+	// 为 0 时使用默认的 256KB，小于 64KB 时视为沿用旧配置，创建日志记录器时返回错误
 	BufferSize int `json:"buffer_size" yaml:"buffer_size"`
-	// FlushInterval 刷新间隔
+	// FlushInterval 刷新间隔，定时将已写入的日志同步到磁盘
 	FlushInterval time.Duration `json:"flush_interval" yaml:"flush_interval"`
+	// OverflowPolicy 异步缓冲区已满时的处理方式：block、drop_newest、sync，默认 sync，其他值返回错误
+	OverflowPolicy OverflowPolicy `json:"overflow_policy" yaml:"overflow_policy"`
 	// Rotate 日志分割配置
 	Rotate RotateConfig `json:"rotate" yaml:"rotate"`
 	// ServiceName 服务名称
@@ -79,6 +92,22 @@ type Config struct {
 	ServiceVersion string `json:"service_version" yaml:"service_version"`
 }
 
+// minBufferSize 异步缓冲区的最小字节数
+const minBufferSize = 64 * 1024
+
+// validate 校验异步写入配置，拼写错误的溢出策略不能静默回退为 sync
+func (c *Config) validate() error {
+	switch c.OverflowPolicy {
+	case "", OverflowBlock, OverflowDropNewest, OverflowSync:
+	default:
+		return fmt.Errorf("unknown overflow_policy %q, expected block, drop_newest or sync", c.OverflowPolicy)
+	}
+	if c.Async && c.BufferSize != 0 && c.BufferSize < minBufferSize {
+		return fmt.Errorf("buffer_size %d is below %d bytes (buffer_size is in bytes, not entries)", c.BufferSize, minBufferSize)
+	}
+	return nil
+}
+
 // DefaultConfig 返回默认配置
 func DefaultConfig() *Config {
 	return &Config{
@@ -92,6 +121,7 @@ func DefaultConfig() *Config {
 		Async:            true,
 		BufferSize:       256 * 1024, // 256KB
 		FlushInterval:    time.Second,
+		OverflowPolicy:   OverflowSync,
 		Rotate: RotateConfig{
 			MaxSize:    100, // 100MB
 			MaxAge:     30,  // 30天
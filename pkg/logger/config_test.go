@@ -0,0 +1,25 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogger_Validate(t *testing.T) {
+	config := DevelopmentConfig()
+	config.OverflowPolicy = "drop-newest"
+	_, err := NewLogger(config)
+	assert.ErrorContains(t, err, "overflow_policy", "拼写错误的溢出策略不能静默回退")
+
+	config = DefaultConfig()
+	config.BufferSize = 10000 // 旧版本按条目数配置
+	_, err = NewLogger(config)
+	assert.ErrorContains(t, err, "buffer_size")
+
+	config = DefaultConfig()
+	config.Async = false
+	config.BufferSize = 10000
+	_, err = NewLogger(config)
+	assert.NoError(t, err, "未启用异步时不校验缓冲区大小")
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"sync"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -51,6 +52,7 @@ type ZapLogger struct {
 	logger *zap.Logger
 	sugar  *zap.SugaredLogger
 	config *Config
+	async  *asyncWriter // 异步写入器，仅根记录器持有，关闭时写出缓冲区
 	mu     sync.RWMutex
 	closed bool
 }
@@ -67,12 +69,15 @@ func NewLogger(config *Config) (Logger, error) {
 	if config == nil {
 		config = DefaultConfig()
 	}
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid logger config: %w", err)
+	}
 
 	// 创建编码器配置
 	encoderConfig := getEncoderConfig(config)
 
 	// 创建核心
-	core, err := createCore(config, encoderConfig)
+	core, async, err := createCore(config, encoderConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create logger core: %w", err)
 	}
@@ -90,6 +95,7 @@ func NewLogger(config *Config) (Logger, error) {
 		logger: zapLogger,
 		sugar:  sugar,
 		config: config,
+		async:  async,
 	}, nil
 }
 
@@ -130,8 +136,8 @@ func getEncoderConfig(config *Config) zapcore.EncoderConfig {
 	return encoderConfig
 }
 
-// createCore 创建日志核心
-func createCore(config *Config, encoderConfig zapcore.EncoderConfig) (zapcore.Core, error) {
+// createCore 创建日志核心，启用异步时同时返回异步写入器
+func createCore(config *Config, encoderConfig zapcore.EncoderConfig) (zapcore.Core, *asyncWriter, error) {
 	// 创建编码器
 	var encoder zapcore.Encoder
 	switch config.Format {
@@ -146,7 +152,14 @@ func createCore(config *Config, encoderConfig zapcore.EncoderConfig) (zapcore.Co
 	// 创建写入器
 	writeSyncer, err := createWriteSyncer(config)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// 如果启用异步，日志在调用方编码后写入缓冲区，由后台协程写出
+	var async *asyncWriter
+	if config.Async {
+		async = newAsyncWriter(writeSyncer, config.BufferSize, config.FlushInterval, config.OverflowPolicy)
+		writeSyncer = async
 	}
 
 	// 创建级别
@@ -155,12 +168,7 @@ func createCore(config *Config, encoderConfig zapcore.EncoderConfig) (zapcore.Co
 	// 创建核心
 	core := zapcore.NewCore(encoder, writeSyncer, level)
 
-	// 如果启用异步，包装为异步核心
-	if config.Async {
-		core = newAsyncCore(core, config.BufferSize, config.FlushInterval)
-	}
-
-	return core, nil
+	return core, async, nil
 }
 
 // createWriteSyncer 创建写入器
@@ -428,13 +436,27 @@ func (l *ZapLogger) Close() error {
 	}
 
 	l.closed = true
-	return l.logger.Sync()
+	err := l.logger.Sync()
+	if l.async != nil {
+		if closeErr := l.async.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
 }
 
 // extractFieldsFromContext 从上下文中提取字段
 func extractFieldsFromContext(ctx context.Context) []zap.Field {
 	var fields []zap.Field
 
+	// 提取链路追踪ID，与 OpenTelemetry 的 Span 关联
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", sc.TraceID().String()),
+			zap.String("span_id", sc.SpanID().String()),
+		)
+	}
+
 	// 提取请求ID（如果存在）
 	if requestID := ctx.Value("request_id"); requestID != nil {
 		if id, ok := requestID.(string); ok {
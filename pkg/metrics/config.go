@@ -0,0 +1,23 @@
+package metrics
+
+// Config 指标配置
+type Config struct {
+	// 是否启用指标
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// 指标暴露路径
+	Path string `json:"path" yaml:"path"`
+	// 指标名称前缀
+	Namespace string `json:"namespace" yaml:"namespace"`
+	// 耗时直方图的桶（秒），HTTP、数据库、Redis共用
+	Buckets []float64 `json:"buckets" yaml:"buckets"`
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled:   true,
+		Path:      "/metrics",
+		Namespace: "sweet",
+		Buckets:   []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}
+}
@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DBStatsSource 连接池统计来源，database.Client 实现了该接口
+type DBStatsSource interface {
+	Stats() (map[string]interface{}, error)
+}
+
+// dbStatsMetric 连接池统计项与指标的对应关系
+type dbStatsMetric struct {
+	key       string
+	name      string
+	help      string
+	valueType prometheus.ValueType
+}
+
+var dbStatsMetrics = []dbStatsMetric{
+	{"max_open_connections", "max_open_connections", "最大连接数", prometheus.GaugeValue},
+	{"open_connections", "open_connections", "已建立的连接数", prometheus.GaugeValue},
+	{"in_use", "in_use_connections", "使用中的连接数", prometheus.GaugeValue},
+	{"idle", "idle_connections", "空闲连接数", prometheus.GaugeValue},
+	{"wait_count", "wait_count_total", "等待连接的次数", prometheus.CounterValue},
+	{"wait_duration", "wait_duration_seconds_total", "等待连接的累计耗时", prometheus.CounterValue},
+	{"max_idle_closed", "max_idle_closed_total", "因超过最大空闲数关闭的连接数", prometheus.CounterValue},
+	{"max_idle_time_closed", "max_idle_time_closed_total", "因超过最大空闲时间关闭的连接数", prometheus.CounterValue},
+	{"max_lifetime_closed", "max_lifetime_closed_total", "因超过最大存活时间关闭的连接数", prometheus.CounterValue},
+}
+
+// dbStatsCollector 采集时读取连接池统计
+type dbStatsCollector struct {
+	source DBStatsSource
+	descs  []*prometheus.Desc
+}
+
+// RegisterDBStats 注册连接池指标，name 区分多个数据库，作为 db 标签
+func (r *Registry) RegisterDBStats(name string, source DBStatsSource) error {
+	c := &dbStatsCollector{source: source}
+	for _, m := range dbStatsMetrics {
+		c.descs = append(c.descs, prometheus.NewDesc(
+			prometheus.BuildFQName(r.config.Namespace, "db_pool", m.name),
+			"数据库连接池"+m.help, nil, prometheus.Labels{"db": name},
+		))
+	}
+	return r.registry.Register(c)
+}
+
+// Describe 实现 prometheus.Collector
+func (c *dbStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range c.descs {
+		ch <- desc
+	}
+}
+
+// Collect 实现 prometheus.Collector，获取统计失败时不输出
+func (c *dbStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats, err := c.source.Stats()
+	if err != nil {
+		return
+	}
+	for i, m := range dbStatsMetrics {
+		if value, ok := toFloat(stats[m.key]); ok {
+			ch <- prometheus.MustNewConstMetric(c.descs[i], m.valueType, value)
+		}
+	}
+}
+
+// toFloat 将统计值转换为指标值，时长转换为秒
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case time.Duration:
+		return v.Seconds(), true
+	default:
+		return 0, false
+	}
+}
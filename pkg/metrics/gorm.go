@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// gormPluginName 插件名称
+	gormPluginName = "prometheus:metrics"
+	// gormStartKey 操作开始时间
+	gormStartKey = "metrics:start_time"
+)
+
+// GormPlugin 数据库操作指标插件，按表名与操作类型统计次数与耗时
+type GormPlugin struct {
+	registry *Registry
+}
+
+// NewGormPlugin 创建数据库操作指标插件
+func NewGormPlugin(registry *Registry) *GormPlugin {
+	return &GormPlugin{registry: registry}
+}
+
+// Name 返回插件名称
+func (p *GormPlugin) Name() string {
+	return gormPluginName
+}
+
+// Initialize 注册各类操作的前后回调
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	for _, err := range []error{
+		cb.Create().Before("gorm:create").Register("metrics:before_create", p.before),
+		cb.Create().After("gorm:create").Register("metrics:after_create", p.after("create")),
+		cb.Query().Before("gorm:query").Register("metrics:before_query", p.before),
+		cb.Query().After("gorm:query").Register("metrics:after_query", p.after("query")),
+		cb.Update().Before("gorm:update").Register("metrics:before_update", p.before),
+		cb.Update().After("gorm:update").Register("metrics:after_update", p.after("update")),
+		cb.Delete().Before("gorm:delete").Register("metrics:before_delete", p.before),
+		cb.Delete().After("gorm:delete").Register("metrics:after_delete", p.after("delete")),
+		cb.Row().Before("gorm:row").Register("metrics:before_row", p.before),
+		cb.Row().After("gorm:row").Register("metrics:after_row", p.after("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:before_raw", p.before),
+		cb.Raw().After("gorm:raw").Register("metrics:after_raw", p.after("raw")),
+	} {
+		if err != nil {
+			return fmt.Errorf("failed to register metrics callbacks: %w", err)
+		}
+	}
+	return nil
+}
+
+// before 记录开始时间
+func (p *GormPlugin) before(db *gorm.DB) {
+	db.InstanceSet(gormStartKey, time.Now())
+}
+
+// after 记录次数与耗时，记录不存在不视为错误
+func (p *GormPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(gormStartKey)
+		if !ok {
+			return
+		}
+		start, _ := value.(time.Time)
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		err := db.Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = nil
+		}
+		p.registry.dbQueries.WithLabelValues(table, operation, statusLabel(err)).Inc()
+		p.registry.dbDuration.WithLabelValues(table, operation).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"sweet/pkg/logger"
+)
+
+// Registry 指标注册表，持有 HTTP、数据库、Redis 与日志的指标
+// 使用独立的 prometheus.Registry，不污染全局默认注册表，便于测试时创建多个实例
+type Registry struct {
+	config   Config
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	httpInFlight prometheus.Gauge
+
+	dbQueries  *prometheus.CounterVec
+	dbDuration *prometheus.HistogramVec
+
+	redisCommands *prometheus.CounterVec
+	redisDuration *prometheus.HistogramVec
+}
+
+// NewRegistry 创建指标注册表，同时注册 Go 运行时、进程与异步日志指标
+func NewRegistry(config *Config) *Registry {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	cfg := *config
+	if len(cfg.Buckets) == 0 {
+		cfg.Buckets = DefaultConfig().Buckets
+	}
+	ns := cfg.Namespace
+
+	r := &Registry{
+		config:   cfg,
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: "http", Name: "requests_total",
+			Help: "HTTP 请求数，route 为路由模板",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns, Subsystem: "http", Name: "request_duration_seconds",
+			Help: "HTTP 请求耗时", Buckets: cfg.Buckets,
+		}, []string{"method", "route"}),
+		httpInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns, Subsystem: "http", Name: "requests_in_flight",
+			Help: "处理中的 HTTP 请求数",
+		}),
+		dbQueries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: "db", Name: "queries_total",
+			Help: "数据库操作次数，status 为 ok 或 error",
+		}, []string{"table", "operation", "status"}),
+		dbDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns, Subsystem: "db", Name: "query_duration_seconds",
+			Help: "数据库操作耗时", Buckets: cfg.Buckets,
+		}, []string{"table", "operation"}),
+		redisCommands: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: "redis", Name: "commands_total",
+			Help: "Redis 命令数，管道按 pipeline 计一次",
+		}, []string{"command", "status"}),
+		redisDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns, Subsystem: "redis", Name: "command_duration_seconds",
+			Help: "Redis 命令耗时", Buckets: cfg.Buckets,
+		}, []string{"command"}),
+	}
+
+	r.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		r.httpRequests, r.httpDuration, r.httpInFlight,
+		r.dbQueries, r.dbDuration,
+		r.redisCommands, r.redisDuration,
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: "logger", Name: "async_enqueued_total",
+			Help: "写入异步日志缓冲区的条目数",
+		}, func() float64 { return float64(logger.GetAsyncStats().Enqueued) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: "logger", Name: "async_overflow_total",
+			Help: "写入时异步日志缓冲区已满的次数，持续增长说明缓冲区过小",
+		}, func() float64 { return float64(logger.GetAsyncStats().Overflow) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: ns, Subsystem: "logger", Name: "async_dropped_total",
+			Help: "异步日志缓冲区已满被丢弃的条目数（overflow_policy 为 drop_newest）",
+		}, func() float64 { return float64(logger.GetAsyncStats().Dropped) }),
+	)
+	return r
+}
+
+// Config 返回配置
+func (r *Registry) Config() Config {
+	return r.config
+}
+
+// Register 注册自定义指标
+func (r *Registry) Register(c prometheus.Collector) error {
+	return r.registry.Register(c)
+}
+
+// Gatherer 返回底层注册表，用于测试或自定义导出
+func (r *Registry) Gatherer() prometheus.Gatherer {
+	return r.registry
+}
+
+// Handler 返回指标暴露的 HTTP 处理器
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{Registry: r.registry})
+}
+
+// RequestStarted 请求开始，返回请求结束时调用的函数
+// route 为路由模板（如 /api/v1/users/:id），避免按实际路径产生过多标签
+func (r *Registry) RequestStarted() func(method, route string, status int) {
+	start := time.Now()
+	r.httpInFlight.Inc()
+	return func(method, route string, status int) {
+		r.httpInFlight.Dec()
+		r.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+		r.httpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// statusLabel 按错误生成 status 标签
+func statusLabel(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
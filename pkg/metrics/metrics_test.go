@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type metricsPost struct {
+	ID    int64 `gorm:"primaryKey"`
+	Title string
+}
+
+func (metricsPost) TableName() string { return "t_post" }
+
+func TestRegistry_HTTP(t *testing.T) {
+	r := NewRegistry(DefaultConfig())
+
+	done := r.RequestStarted()
+	assert.Equal(t, 1.0, testutil.ToFloat64(r.httpInFlight))
+	done(http.MethodGet, "/api/v1/users/:id", http.StatusOK)
+	r.RequestStarted()(http.MethodGet, "/api/v1/users/:id", http.StatusNotFound)
+
+	assert.Equal(t, 0.0, testutil.ToFloat64(r.httpInFlight))
+	assert.Equal(t, 1.0, testutil.ToFloat64(r.httpRequests.WithLabelValues("GET", "/api/v1/users/:id", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(r.httpRequests.WithLabelValues("GET", "/api/v1/users/:id", "404")))
+	assert.Equal(t, 1, testutil.CollectAndCount(r.httpDuration))
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	for _, name := range []string{
+		"sweet_http_requests_total",
+		"sweet_http_request_duration_seconds_bucket",
+		"sweet_logger_async_overflow_total",
+		"go_goroutines",
+	} {
+		assert.Contains(t, body, name)
+	}
+}
+
+func TestGormPlugin(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&metricsPost{}))
+
+	r := NewRegistry(DefaultConfig())
+	require.NoError(t, db.Use(NewGormPlugin(r)))
+
+	require.NoError(t, db.Create(&metricsPost{ID: 1, Title: "a"}).Error)
+	var post metricsPost
+	require.NoError(t, db.First(&post, 1).Error)
+	assert.ErrorIs(t, db.First(&post, 2).Error, gorm.ErrRecordNotFound)
+	assert.Error(t, db.Create(&metricsPost{ID: 1}).Error)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(r.dbQueries.WithLabelValues("t_post", "create", "ok")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(r.dbQueries.WithLabelValues("t_post", "create", "error")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(r.dbQueries.WithLabelValues("t_post", "query", "ok")), "记录不存在不计为错误")
+}
+
+type fakeStats map[string]interface{}
+
+func (s fakeStats) Stats() (map[string]interface{}, error) { return s, nil }
+
+func TestRegisterDBStats(t *testing.T) {
+	r := NewRegistry(DefaultConfig())
+	require.NoError(t, r.RegisterDBStats("master", fakeStats{
+		"open_connections": 3,
+		"wait_count":       int64(5),
+		"wait_duration":    1500 * time.Millisecond,
+	}))
+
+	families, err := r.Gatherer().Gather()
+	require.NoError(t, err)
+	values := map[string]float64{}
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			if m.GetGauge() != nil {
+				values[f.GetName()] = m.GetGauge().GetValue()
+			}
+			if m.GetCounter() != nil {
+				values[f.GetName()] = m.GetCounter().GetValue()
+			}
+		}
+	}
+	assert.Equal(t, 3.0, values["sweet_db_pool_open_connections"])
+	assert.Equal(t, 5.0, values["sweet_db_pool_wait_count_total"])
+	assert.Equal(t, 1.5, values["sweet_db_pool_wait_duration_seconds_total"])
+	assert.NotContains(t, values, "sweet_db_pool_idle_connections", "缺少的统计项不输出")
+
+	assert.Error(t, r.RegisterDBStats("master", fakeStats{}), "同名数据库重复注册")
+}
+
+func TestRedisHook(t *testing.T) {
+	r := NewRegistry(DefaultConfig())
+	hook := NewRedisHook(r)
+	ctx := context.Background()
+
+	process := hook.ProcessHook(func(context.Context, redis.Cmder) error { return redis.Nil })
+	assert.ErrorIs(t, process(ctx, redis.NewStringCmd(ctx, "get", "k")), redis.Nil)
+	process = hook.ProcessHook(func(context.Context, redis.Cmder) error { return errors.New("timeout") })
+	assert.Error(t, process(ctx, redis.NewStatusCmd(ctx, "set", "k", "v")))
+	pipeline := hook.ProcessPipelineHook(func(context.Context, []redis.Cmder) error { return nil })
+	assert.NoError(t, pipeline(ctx, nil))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(r.redisCommands.WithLabelValues("get", "ok")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(r.redisCommands.WithLabelValues("set", "error")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(r.redisCommands.WithLabelValues("pipeline", "ok")))
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisHook Redis 命令指标钩子
+type redisHook struct {
+	registry *Registry
+}
+
+// NewRedisHook 创建 Redis 命令指标钩子，通过 client.AddHook 注册
+// 键不存在（redis.Nil）不视为错误
+func NewRedisHook(registry *Registry) redis.Hook {
+	return &redisHook{registry: registry}
+}
+
+// DialHook 实现 redis.Hook
+func (h *redisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook 按命令统计次数与耗时
+func (h *redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		h.observe(cmd.Name(), start, err)
+		return err
+	}
+}
+
+// ProcessPipelineHook 管道整体计为一次 pipeline 命令
+func (h *redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		h.observe("pipeline", start, err)
+		return err
+	}
+}
+
+func (h *redisHook) observe(command string, start time.Time, err error) {
+	if errors.Is(err, redis.Nil) {
+		err = nil
+	}
+	h.registry.redisCommands.WithLabelValues(command, statusLabel(err)).Inc()
+	h.registry.redisDuration.WithLabelValues(command).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,44 @@
+package migrate
+
+import "time"
+
+// Config 迁移配置
+type Config struct {
+	// 版本记录表名
+	Table string `json:"table" yaml:"table"`
+	// 咨询锁名称，同一数据库的多个实例使用同一把锁，避免并发执行迁移
+	LockName string `json:"lock_name" yaml:"lock_name"`
+	// 获取咨询锁的最长等待时间
+	LockTimeout time.Duration `json:"lock_timeout" yaml:"lock_timeout"`
+	// 服务启动时是否自动执行未应用的迁移
+	AutoMigrate bool `json:"auto_migrate" yaml:"auto_migrate"`
+}
+
+// DefaultConfig 默认迁移配置
+func DefaultConfig() *Config {
+	return &Config{
+		Table:       "schema_migrations",
+		LockName:    "sweet:schema_migrations",
+		LockTimeout: 30 * time.Second,
+		AutoMigrate: false,
+	}
+}
+
+// withDefaults 补全未设置的配置项
+func (c *Config) withDefaults() *Config {
+	def := DefaultConfig()
+	if c == nil {
+		return def
+	}
+	cfg := *c
+	if cfg.Table == "" {
+		cfg.Table = def.Table
+	}
+	if cfg.LockName == "" {
+		cfg.LockName = def.LockName
+	}
+	if cfg.LockTimeout <= 0 {
+		cfg.LockTimeout = def.LockTimeout
+	}
+	return &cfg
+}
@@ -0,0 +1,89 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Locker 迁移锁，按数据库实现
+// 咨询锁与会话绑定，加锁与解锁必须使用同一连接，这里直接使用主库的独占连接
+type Locker interface {
+	// Lock 获取锁，timeout 内未获取到时返回 ErrLocked
+	Lock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) (unlock func(), err error)
+}
+
+var (
+	lockersMu sync.RWMutex
+	lockers   = map[string]Locker{
+		"mysql":    mysqlLocker{},
+		"postgres": postgresLocker{},
+	}
+)
+
+// RegisterLocker 注册数据库的迁移锁，name 与 gorm.Dialector.Name() 一致
+func RegisterLocker(name string, locker Locker) {
+	lockersMu.Lock()
+	defer lockersMu.Unlock()
+	lockers[name] = locker
+}
+
+// lock 在独占连接上获取迁移锁，返回释放函数
+func (m *Migrator) lock(ctx context.Context, conn *sql.Conn) (func(), error) {
+	lockersMu.RLock()
+	locker, ok := lockers[m.db.Dialector.Name()]
+	lockersMu.RUnlock()
+	if !ok {
+		// 其他数据库（如 SQLite）暂不支持咨询锁，由调用方保证只有一个实例执行迁移
+		return func() {}, nil
+	}
+	return locker.Lock(ctx, conn, m.config.LockName, m.config.LockTimeout)
+}
+
+// mysqlLocker 使用 GET_LOCK 加锁
+type mysqlLocker struct{}
+
+func (mysqlLocker) Lock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) (func(), error) {
+	var got sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", name, int(timeout.Seconds())).Scan(&got); err != nil {
+		return nil, fmt.Errorf("获取迁移锁失败: %w", err)
+	}
+	if !got.Valid || got.Int64 != 1 {
+		return nil, ErrLocked
+	}
+	return func() {
+		// 迁移被取消时仍需解锁
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "SELECT RELEASE_LOCK(?)", name)
+	}, nil
+}
+
+// postgresLocker 使用会话级咨询锁，pg_advisory_lock 不支持超时，改为轮询 pg_try_advisory_lock
+type postgresLocker struct{}
+
+// postgresLockInterval 轮询间隔
+const postgresLockInterval = 500 * time.Millisecond
+
+func (postgresLocker) Lock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) (func(), error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		var got bool
+		if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", name).Scan(&got); err != nil {
+			return nil, fmt.Errorf("获取迁移锁失败: %w", err)
+		}
+		if got {
+			return func() {
+				_, _ = conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock(hashtext($1))", name)
+			}, nil
+		}
+		if !time.Now().Before(deadline) {
+			return nil, ErrLocked
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(postgresLockInterval):
+		}
+	}
+}
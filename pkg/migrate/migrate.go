@@ -0,0 +1,310 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"sweet/pkg/database"
+)
+
+var (
+	// ErrLocked 其他实例正在执行迁移
+	ErrLocked = errors.New("迁移锁被占用，其他实例正在执行迁移")
+	// ErrChecksumMismatch 已执行的迁移脚本被修改
+	ErrChecksumMismatch = errors.New("迁移脚本校验和不一致")
+	// ErrMissingMigration 数据库中已执行的版本在脚本中不存在
+	ErrMissingMigration = errors.New("已执行的迁移版本缺少脚本")
+	// ErrOutOfOrder 待执行版本低于已执行的最新版本
+	ErrOutOfOrder = errors.New("迁移版本顺序错误")
+	// ErrIrreversible 迁移没有回滚脚本
+	ErrIrreversible = errors.New("迁移不可回滚")
+)
+
+// record 版本记录表的一行
+type record struct {
+	Version     int64     `gorm:"column:version;primaryKey;autoIncrement:false"`
+	Name        string    `gorm:"column:name"`
+	Checksum    string    `gorm:"column:checksum"`
+	AppliedAt   time.Time `gorm:"column:applied_at"`
+	ExecutionMs int64     `gorm:"column:execution_ms"`
+}
+
+// Status 迁移状态
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`              // 是否已执行
+	AppliedAt *time.Time `json:"applied_at,omitempty"` // 执行时间
+	Modified  bool       `json:"modified"`             // 已执行后脚本被修改
+	Missing   bool       `json:"missing"`              // 已执行但脚本不存在
+}
+
+// Migrator 迁移执行器
+// MySQL 的 DDL 会隐式提交，迁移不在事务中执行；某条语句失败时之前的语句不会回滚，
+// 该版本也不会记录为已执行，修复脚本或手动清理后重新执行即可
+type Migrator struct {
+	db         *gorm.DB
+	migrations []*Migration
+	config     *Config
+}
+
+// New 创建迁移执行器，migrations 需按版本升序排列（Load 的返回值即可）
+func New(db *gorm.DB, migrations []*Migration, config *Config) *Migrator {
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		config:     config.withDefaults(),
+	}
+}
+
+// Up 执行未应用的迁移，steps 小于等于0时执行全部，返回本次执行的迁移
+func (m *Migrator) Up(ctx context.Context, steps int) ([]*Migration, error) {
+	var done []*Migration
+	err := m.withSession(ctx, true, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+
+		var latest int64
+		for version := range applied {
+			latest = max(latest, version)
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if steps > 0 && len(done) >= steps {
+				break
+			}
+			if migration.Version < latest {
+				return fmt.Errorf("%w: 版本 %d 低于已执行的版本 %d", ErrOutOfOrder, migration.Version, latest)
+			}
+			if err := m.apply(conn, migration); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down 按版本倒序回滚已执行的迁移，steps 小于1时回滚1个，返回本次回滚的迁移
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	steps = max(steps, 1)
+
+	var done []*Migration
+	err := m.withSession(ctx, true, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if err := m.revert(conn, migration); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status 查询所有迁移的执行状态，按版本升序返回
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	var applied map[int64]*record
+	err := m.withSession(ctx, false, func(conn *gorm.DB) (err error) {
+		applied, err = m.applied(conn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Status, 0, len(m.migrations))
+	known := make(map[int64]struct{}, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = struct{}{}
+		status := &Status{Version: migration.Version, Name: migration.Name}
+		if r, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &r.AppliedAt
+			status.Modified = r.Checksum != migration.Checksum
+		}
+		result = append(result, status)
+	}
+	for version, r := range applied {
+		if _, ok := known[version]; !ok {
+			result = append(result, &Status{Version: version, Name: r.Name, Applied: true, AppliedAt: &r.AppliedAt, Missing: true})
+		}
+	}
+	sortStatus(result)
+	return result, nil
+}
+
+// withSession 在主库的独占连接上执行，locked 为 true 时先获取迁移锁
+// 加锁、迁移与解锁均在同一连接上进行：db.Connection 固定的连接不是事务，
+// 注册了 dbresolver 时每条语句都会被换成连接池中的连接，锁无法起到互斥作用，脚本中的 SET 也不会生效
+func (m *Migrator) withSession(ctx context.Context, locked bool, fn func(conn *gorm.DB) error) error {
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return fmt.Errorf("获取数据库连接池失败: %w", err)
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("获取数据库连接失败: %w", err)
+	}
+	defer conn.Close()
+
+	session, err := m.session(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if locked {
+		unlock, err := m.lock(ctx, conn)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	if err := m.ensureTable(session); err != nil {
+		return err
+	}
+	return fn(session)
+}
+
+// session 基于独占连接创建 GORM 会话
+// 使用独立的 gorm.DB，不带读写分离、多租户、审计等插件，语句不会被路由到其他连接
+func (m *Migrator) session(ctx context.Context, conn *sql.Conn) (*gorm.DB, error) {
+	dialect, err := database.GetDialect(m.db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(dialect.New(conn), &gorm.Config{
+		Logger:                 m.db.Logger,
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("创建迁移会话失败: %w", err)
+	}
+	return db.WithContext(ctx), nil
+}
+
+// ensureTable 创建版本记录表
+func (m *Migrator) ensureTable(conn *gorm.DB) error {
+	sql := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+  version BIGINT NOT NULL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  checksum CHAR(64) NOT NULL,
+  applied_at TIMESTAMP NOT NULL,
+  execution_ms BIGINT NOT NULL DEFAULT 0
+)`, conn.Statement.Quote(m.config.Table))
+	if err := conn.Exec(sql).Error; err != nil {
+		return fmt.Errorf("创建迁移记录表失败: %w", err)
+	}
+	return nil
+}
+
+// applied 查询已执行的版本
+func (m *Migrator) applied(conn *gorm.DB) (map[int64]*record, error) {
+	var records []*record
+	if err := conn.Table(m.config.Table).Order("version").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("查询迁移记录失败: %w", err)
+	}
+	applied := make(map[int64]*record, len(records))
+	for _, r := range records {
+		applied[r.Version] = r
+	}
+	return applied, nil
+}
+
+// verify 校验已执行版本的脚本存在且未被修改
+func (m *Migrator) verify(applied map[int64]*record) error {
+	byVersion := make(map[int64]*Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		byVersion[migration.Version] = migration
+	}
+	for _, migration := range m.migrations {
+		r, ok := applied[migration.Version]
+		if ok && r.Checksum != migration.Checksum {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, migration.Version, migration.Name)
+		}
+	}
+	for version, r := range applied {
+		if _, ok := byVersion[version]; !ok {
+			return fmt.Errorf("%w: %d_%s", ErrMissingMigration, version, r.Name)
+		}
+	}
+	return nil
+}
+
+// apply 执行升级脚本并记录版本
+func (m *Migrator) apply(conn *gorm.DB, migration *Migration) error {
+	start := time.Now()
+	if err := m.exec(conn, migration, migration.Up); err != nil {
+		return err
+	}
+	r := &record{
+		Version:     migration.Version,
+		Name:        migration.Name,
+		Checksum:    migration.Checksum,
+		AppliedAt:   time.Now(),
+		ExecutionMs: time.Since(start).Milliseconds(),
+	}
+	if err := conn.Table(m.config.Table).Create(r).Error; err != nil {
+		return fmt.Errorf("记录迁移版本 %d 失败: %w", migration.Version, err)
+	}
+	return nil
+}
+
+// revert 执行回滚脚本并删除版本记录
+func (m *Migrator) revert(conn *gorm.DB, migration *Migration) error {
+	if migration.Down == "" {
+		return fmt.Errorf("%w: %d_%s", ErrIrreversible, migration.Version, migration.Name)
+	}
+	if err := m.exec(conn, migration, migration.Down); err != nil {
+		return err
+	}
+	if err := conn.Table(m.config.Table).Where("version = ?", migration.Version).Delete(&record{}).Error; err != nil {
+		return fmt.Errorf("删除迁移版本 %d 失败: %w", migration.Version, err)
+	}
+	return nil
+}
+
+// exec 逐条执行脚本中的语句
+func (m *Migrator) exec(conn *gorm.DB, migration *Migration, script string) error {
+	for i, stmt := range SplitStatements(script) {
+		if err := conn.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("执行迁移 %d_%s 第 %d 条语句失败: %w", migration.Version, migration.Name, i+1, err)
+		}
+	}
+	return nil
+}
+
+// sortStatus 按版本升序排列
+func sortStatus(list []*Status) {
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+}
@@ -0,0 +1,235 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
+
+	"sweet/internal/models/entity"
+	_ "sweet/pkg/crypto" // 注册实体使用的加密序列化器
+	"sweet/resource"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_add_post.up.sql":      {Data: []byte("CREATE TABLE post (id INT);")},
+		"000002_add_post.down.sql":    {Data: []byte("DROP TABLE post;")},
+		"000001_init_schema.up.sql":   {Data: []byte("CREATE TABLE user (id INT);")},
+		"000001_init_schema.down.sql": {Data: []byte("DROP TABLE user;")},
+		"000003_seed.up.sql":          {Data: []byte("INSERT INTO user VALUES (1);")},
+		"README.md":                   {Data: []byte("ignored")},
+	}
+
+	migrations, err := Load(fsys)
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "init_schema", migrations[0].Name)
+	assert.Equal(t, "DROP TABLE user;", migrations[0].Down)
+	assert.Equal(t, int64(2), migrations[1].Version)
+	assert.Equal(t, int64(3), migrations[2].Version)
+	assert.Empty(t, migrations[2].Down)
+	assert.Len(t, migrations[0].Checksum, 64)
+	assert.NotEqual(t, migrations[0].Checksum, migrations[1].Checksum)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"文件名不合法":  {"init.up.sql": {Data: []byte("SELECT 1;")}},
+		"版本重复":    {"000001_a.up.sql": {Data: []byte("SELECT 1;")}, "000001_b.up.sql": {Data: []byte("SELECT 1;")}},
+		"缺少升级脚本":  {"000001_a.down.sql": {Data: []byte("SELECT 1;")}},
+		"版本号不能为0": {"000000_a.up.sql": {Data: []byte("SELECT 1;")}},
+	}
+	for name, fsys := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(fsys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestChecksum_LineEndings(t *testing.T) {
+	assert.Equal(t, checksum("SELECT 1;\nSELECT 2;\n"), checksum("SELECT 1;\r\nSELECT 2;\r\n"))
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- 注释; 不拆分
+SET NAMES utf8mb4;
+/* 多行
+   注释; */
+CREATE TABLE t (
+  name varchar(64) COMMENT '名称;含分号',
+  ` + "`a;b`" + ` int COMMENT "双引号\";"
+);
+# 井号注释;
+INSERT INTO t VALUES ('it''s; ok');
+SELECT 1--1;
+`
+	stmts := SplitStatements(script)
+	require.Len(t, stmts, 4)
+	assert.Equal(t, "SET NAMES utf8mb4", stmts[0])
+	assert.Contains(t, stmts[1], "COMMENT '名称;含分号'")
+	assert.Contains(t, stmts[1], "`a;b` int")
+	assert.Contains(t, stmts[1], `"双引号\";"`)
+	assert.Equal(t, "INSERT INTO t VALUES ('it''s; ok')", stmts[2])
+	assert.Equal(t, "SELECT 1--1", stmts[3])
+
+	assert.Empty(t, SplitStatements("-- 只有注释\n/* 空 */;;\n"))
+}
+
+func TestLoad_Resource(t *testing.T) {
+	migrations, err := Load(resource.Migrations())
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	assert.Equal(t, int64(1), migrations[0].Version)
+
+	for _, m := range migrations {
+		assert.NotEmpty(t, SplitStatements(m.Up), "%d_%s", m.Version, m.Name)
+		assert.NotEmpty(t, m.Down, "%d_%s 缺少回滚脚本", m.Version, m.Name)
+	}
+}
+
+func TestLoad_ResourceDialects(t *testing.T) {
+	mysql, err := Load(resource.Migrations())
+	require.NoError(t, err)
+	latest := mysql[len(mysql)-1].Version
+
+	for _, dialect := range []string{"postgres", "sqlite"} {
+		fsys, err := resource.MigrationsFor(dialect)
+		require.NoError(t, err)
+		migrations, err := Load(fsys)
+		require.NoError(t, err)
+		require.NotEmpty(t, migrations, dialect)
+		// 各数据库的版本号保持一致，新增版本时需同时提供各数据库的脚本
+		assert.Equal(t, latest, migrations[len(migrations)-1].Version, dialect)
+		for _, m := range migrations {
+			assert.NotEmpty(t, SplitStatements(m.Up), "%s %d_%s", dialect, m.Version, m.Name)
+			assert.NotEmpty(t, m.Down, "%s %d_%s 缺少回滚脚本", dialect, m.Version, m.Name)
+		}
+	}
+
+	_, err = resource.MigrationsFor("oracle")
+	assert.Error(t, err)
+}
+
+func TestMigrator_SQLite(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	fsys, err := resource.MigrationsFor("sqlite")
+	require.NoError(t, err)
+	migrations, err := Load(fsys)
+	require.NoError(t, err)
+	m := New(db, migrations, DefaultConfig())
+	ctx := context.Background()
+
+	done, err := m.Up(ctx, 0)
+	require.NoError(t, err)
+	assert.Len(t, done, len(migrations))
+	done, err = m.Up(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, done)
+
+	// 表结构覆盖全部实体的字段
+	models := []interface{}{
+		&entity.SysTenant{}, &entity.SysDept{}, &entity.SysPost{}, &entity.SysRole{}, &entity.SysMenu{},
+		&entity.SysRoleMenu{}, &entity.SysApiGroup{}, &entity.SysApi{}, &entity.SysRoleApi{}, &entity.SysUser{},
+		&entity.SysOperationLog{}, &entity.SysLoginLog{}, &entity.SysFile{}, &entity.SysUserOauth{},
+		&entity.SysApiKey{}, &entity.SysApiKeyScope{},
+	}
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		require.NoError(t, stmt.Parse(model))
+		require.True(t, db.Migrator().HasTable(stmt.Schema.Table), stmt.Schema.Table)
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" {
+				assert.True(t, db.Migrator().HasColumn(model, field.DBName), "%s.%s", stmt.Schema.Table, field.DBName)
+			}
+		}
+	}
+
+	var tenant entity.SysTenant
+	require.NoError(t, db.First(&tenant, 1).Error)
+	assert.Equal(t, "platform", tenant.Code)
+
+	list, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, len(migrations))
+	assert.True(t, list[0].Applied)
+
+	done, err = m.Down(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, done, 1)
+	assert.False(t, db.Migrator().HasTable(entity.TableNameSysUser))
+}
+
+// connLocker 记录加锁与解锁使用的连接
+type connLocker struct {
+	locked, unlocked *sql.Conn
+}
+
+func (l *connLocker) Lock(_ context.Context, conn *sql.Conn, _ string, _ time.Duration) (func(), error) {
+	l.locked = conn
+	return func() { l.unlocked = conn }, nil
+}
+
+func TestMigrator_WithResolver(t *testing.T) {
+	// 主库只有一个连接：迁移语句若被 dbresolver 换到连接池，会因拿不到连接而超时
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{sqlite.Open("file::memory:")},
+	})))
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	locker := &connLocker{}
+	RegisterLocker("sqlite", locker)
+	t.Cleanup(func() {
+		lockersMu.Lock()
+		delete(lockers, "sqlite")
+		lockersMu.Unlock()
+	})
+
+	fsys, err := resource.MigrationsFor("sqlite")
+	require.NoError(t, err)
+	migrations, err := Load(fsys)
+	require.NoError(t, err)
+	m := New(db, migrations, DefaultConfig())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	done, err := m.Up(ctx, 0)
+	require.NoError(t, err)
+	assert.Len(t, done, len(migrations))
+	require.NotNil(t, locker.locked)
+	assert.Same(t, locker.locked, locker.unlocked, "加锁与解锁使用同一连接")
+
+	// 脚本与版本记录都写入主库
+	var count int64
+	require.NoError(t, sqlDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations").Scan(&count))
+	assert.EqualValues(t, len(migrations), count)
+	require.NoError(t, sqlDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE name = ?", entity.TableNameSysUser).Scan(&count))
+	assert.EqualValues(t, 1, count)
+
+	list, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, len(migrations))
+	assert.True(t, list[len(list)-1].Applied)
+}
@@ -0,0 +1,83 @@
+package migrate
+
+import "strings"
+
+// SplitStatements 将脚本按分号拆分为单条语句
+// 跳过引号、反引号和注释中的分号，去除纯注释与空语句
+// 不支持 DELIMITER 指令，存储过程等复杂语句请单独放在一个迁移中并避免使用
+func SplitStatements(script string) []string {
+	var (
+		stmts []string
+		buf   strings.Builder
+		// 当前所处的引号字符，0 表示不在引号内
+		quote byte
+		// 当前语句是否包含注释以外的内容
+		hasCode bool
+	)
+
+	flush := func() {
+		if hasCode {
+			stmts = append(stmts, strings.TrimSpace(buf.String()))
+		}
+		buf.Reset()
+		hasCode = false
+	}
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+
+		if quote != 0 {
+			buf.WriteByte(c)
+			if c == '\\' && quote != '`' && i+1 < len(script) {
+				i++
+				buf.WriteByte(script[i])
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			hasCode = true
+			buf.WriteByte(c)
+		case c == '-' && isLineComment(script[i:]), c == '#':
+			// 单行注释，跳到行尾
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end
+				buf.WriteByte('\n')
+			}
+		case c == '/' && strings.HasPrefix(script[i:], "/*"):
+			// 多行注释
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end + 3
+			}
+		case c == ';':
+			flush()
+		default:
+			if !isSpace(c) {
+				hasCode = true
+			}
+			buf.WriteByte(c)
+		}
+	}
+	flush()
+	return stmts
+}
+
+// isLineComment MySQL 要求 "--" 后跟空白字符才是注释
+func isLineComment(s string) bool {
+	return strings.HasPrefix(s, "--") && (len(s) == 2 || isSpace(s[2]))
+}
+
+// isSpace 是否为空白字符
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
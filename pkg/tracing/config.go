@@ -0,0 +1,45 @@
+package tracing
+
+import "time"
+
+// 导出器类型
+const (
+	ExporterOTLP   = "otlp"   // OTLP/HTTP，发送到 Collector、Jaeger、Tempo 等
+	ExporterStdout = "stdout" // 输出到标准输出，用于本地调试
+)
+
+// Config 链路追踪配置
+type Config struct {
+	// 是否启用，未启用时不设置全局 TracerProvider，所有 Span 为空操作
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// 导出器：otlp、stdout
+	Exporter string `json:"exporter" yaml:"exporter"`
+	// OTLP 地址，如 localhost:4318，为空时使用 OTEL_EXPORTER_OTLP_ENDPOINT 或默认地址
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+	// OTLP 是否使用 HTTP 明文
+	Insecure bool `json:"insecure" yaml:"insecure"`
+	// OTLP 请求头，如鉴权令牌
+	Headers map[string]string `json:"headers" yaml:"headers"`
+	// OTLP 导出超时
+	Timeout time.Duration `json:"timeout" yaml:"timeout"`
+	// 采样率，0~1；上游请求已采样时跟随上游
+	SampleRatio float64 `json:"sample_ratio" yaml:"sample_ratio"`
+	// 服务名称，为空时使用日志配置的 service_name，均为空时为 sweet
+	ServiceName string `json:"service_name" yaml:"service_name"`
+	// 服务版本，为空时使用日志配置的 service_version
+	ServiceVersion string `json:"service_version" yaml:"service_version"`
+	// 部署环境，写入 deployment.environment.name
+	Environment string `json:"environment" yaml:"environment"`
+	// 附加的资源属性
+	Attributes map[string]string `json:"attributes" yaml:"attributes"`
+}
+
+// DefaultConfig 返回默认配置
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled:     false,
+		Exporter:    ExporterOTLP,
+		Timeout:     10 * time.Second,
+		SampleRatio: 1,
+	}
+}
@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName Tracer 名称
+const instrumentationName = "sweet/pkg/tracing"
+
+// redisHook Redis 链路追踪钩子
+type redisHook struct {
+	attrs []attribute.KeyValue
+}
+
+// NewRedisHook 创建 Redis 链路追踪钩子，通过 client.AddHook 注册
+// 每个命令创建一个客户端 Span，不记录命令参数；键不存在（redis.Nil）不视为错误
+func NewRedisHook(addr string) redis.Hook {
+	attrs := []attribute.KeyValue{semconv.DBSystemNameRedis}
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		attrs = append(attrs, semconv.ServerAddress(host))
+	}
+	return &redisHook{attrs: attrs}
+}
+
+// DialHook 实现 redis.Hook
+func (h *redisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook 为单个命令创建 Span
+func (h *redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := h.start(ctx, cmd.Name(), semconv.DBOperationName(cmd.Name()))
+		defer span.End()
+		err := next(ctx, cmd)
+		h.end(span, err)
+		return err
+	}
+}
+
+// ProcessPipelineHook 管道整体创建一个 Span
+func (h *redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		ctx, span := h.start(ctx, "pipeline",
+			semconv.DBOperationName("pipeline"),
+			semconv.DBOperationBatchSize(len(cmds)),
+		)
+		defer span.End()
+		err := next(ctx, cmds)
+		h.end(span, err)
+		return err
+	}
+}
+
+func (h *redisHook) start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, "redis."+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(h.attrs...),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+func (h *redisHook) end(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, redis.Nil) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,141 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// defaultServiceName 未配置服务名称时使用
+const defaultServiceName = "sweet"
+
+// Provider 链路追踪，封装 TracerProvider 与导出器
+type Provider struct {
+	provider *sdktrace.TracerProvider
+}
+
+// Setup 按配置创建 TracerProvider 并设置为全局，database.TracingPlugin 等通过 otel.Tracer 获取
+// 无论是否启用都会设置 W3C TraceContext 与 Baggage 传播器，未启用时不产生 Span
+func Setup(ctx context.Context, config *Config) (*Provider, error) {
+	return setup(ctx, config, nil)
+}
+
+// setup 创建 TracerProvider，exporter 不为空时同步导出到该导出器并忽略配置的导出器，仅供测试注入内存导出器
+func setup(ctx context.Context, config *Config, exporter sdktrace.SpanExporter) (*Provider, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	if !config.Enabled {
+		return &Provider{}, nil
+	}
+
+	res, err := newResource(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	p := &Provider{}
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	}
+	switch {
+	case exporter != nil:
+		// 同步导出，Span 结束后立即可读
+		opts = append(opts, sdktrace.WithSyncer(exporter))
+	case config.Exporter == "" || config.Exporter == ExporterOTLP:
+		exporter, err := otlptracehttp.New(ctx, otlpOptions(config)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create otlp exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	case config.Exporter == ExporterStdout:
+		exporter, err := stdouttrace.New()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter %q", config.Exporter)
+	}
+
+	p.provider = sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(p.provider)
+	return p, nil
+}
+
+// otlpOptions OTLP/HTTP 导出器选项，未配置的项使用 OTEL_EXPORTER_OTLP_* 环境变量
+func otlpOptions(config *Config) []otlptracehttp.Option {
+	var opts []otlptracehttp.Option
+	if config.Endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpoint(config.Endpoint))
+	}
+	if config.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if len(config.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(config.Headers))
+	}
+	if config.Timeout > 0 {
+		opts = append(opts, otlptracehttp.WithTimeout(config.Timeout))
+	}
+	return opts
+}
+
+// newResource 资源属性：服务名称、版本、部署环境与附加属性，OTEL_RESOURCE_ATTRIBUTES 中的属性优先级更低
+func newResource(ctx context.Context, config *Config) (*resource.Resource, error) {
+	name := config.ServiceName
+	if name == "" {
+		name = defaultServiceName
+	}
+	attrs := []attribute.KeyValue{semconv.ServiceName(name)}
+	if config.ServiceVersion != "" {
+		attrs = append(attrs, semconv.ServiceVersion(config.ServiceVersion))
+	}
+	if config.Environment != "" {
+		attrs = append(attrs, semconv.DeploymentEnvironmentName(config.Environment))
+	}
+	for k, v := range config.Attributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	return resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+		resource.WithAttributes(attrs...),
+	)
+}
+
+// Enabled 是否启用了链路追踪
+func (p *Provider) Enabled() bool {
+	return p.provider != nil
+}
+
+// Tracer 获取 Tracer，未启用时返回空操作的 Tracer
+func (p *Provider) Tracer(name string) trace.Tracer {
+	if p.provider == nil {
+		return otel.Tracer(name)
+	}
+	return p.provider.Tracer(name)
+}
+
+// Shutdown 导出剩余的 Span 并关闭，服务停止时调用
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p.provider == nil {
+		return nil
+	}
+	return p.provider.Shutdown(ctx)
+}
@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// setupMemory 使用内存导出器创建 Provider，Span 结束后即可从导出器读取
+func setupMemory(t *testing.T) (*Provider, *tracetest.InMemoryExporter) {
+	t.Helper()
+	config := DefaultConfig()
+	config.Enabled = true
+	config.ServiceName = "sweet-test"
+	config.ServiceVersion = "1.2.3"
+	config.Environment = "test"
+	exporter := tracetest.NewInMemoryExporter()
+	p, err := setup(context.Background(), config, exporter)
+	require.NoError(t, err)
+	t.Cleanup(func() { p.Shutdown(context.Background()) })
+	return p, exporter
+}
+
+func attrValue(attrs []attribute.KeyValue, key string) (attribute.Value, bool) {
+	for _, kv := range attrs {
+		if string(kv.Key) == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestSetup_Memory(t *testing.T) {
+	p, exporter := setupMemory(t)
+	require.True(t, p.Enabled())
+
+	ctx, parent := p.Tracer("test").Start(context.Background(), "parent")
+	_, child := otel.Tracer("test").Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID(), "全局 Tracer 使用同一 Provider")
+
+	attrs := spans[1].Resource.Attributes()
+	for key, want := range map[string]string{
+		"service.name":                "sweet-test",
+		"service.version":             "1.2.3",
+		"deployment.environment.name": "test",
+	} {
+		v, ok := attrValue(attrs, key)
+		require.True(t, ok, key)
+		assert.Equal(t, want, v.AsString())
+	}
+}
+
+func TestSetup_Propagation(t *testing.T) {
+	p, err := Setup(context.Background(), DefaultConfig())
+	require.NoError(t, err)
+	assert.False(t, p.Enabled())
+	assert.NoError(t, p.Shutdown(context.Background()))
+
+	// 未启用时仍按 W3C 传播上游链路
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(header))
+	sc := trace.SpanContextFromContext(ctx)
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+
+	_, err = Setup(context.Background(), &Config{Enabled: true, Exporter: "zipkin"})
+	assert.Error(t, err)
+}
+
+func TestRedisHook(t *testing.T) {
+	_, exporter := setupMemory(t)
+	hook := NewRedisHook("localhost:6379")
+	ctx := context.Background()
+
+	process := hook.ProcessHook(func(context.Context, redis.Cmder) error { return redis.Nil })
+	assert.ErrorIs(t, process(ctx, redis.NewStringCmd(ctx, "get", "k")), redis.Nil)
+	process = hook.ProcessHook(func(context.Context, redis.Cmder) error { return errors.New("timeout") })
+	assert.Error(t, process(ctx, redis.NewStatusCmd(ctx, "set", "k", "v")))
+	pipeline := hook.ProcessPipelineHook(func(context.Context, []redis.Cmder) error { return nil })
+	assert.NoError(t, pipeline(ctx, []redis.Cmder{redis.NewStringCmd(ctx, "get", "a"), redis.NewStringCmd(ctx, "get", "b")}))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "redis.get", spans[0].Name)
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code, "redis.Nil 不视为错误")
+	v, _ := attrValue(spans[0].Attributes, "server.address")
+	assert.Equal(t, "localhost", v.AsString())
+
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, "redis.pipeline", spans[2].Name)
+	v, _ = attrValue(spans[2].Attributes, "db.operation.batch.size")
+	assert.EqualValues(t, 2, v.AsInt64())
+}
@@ -0,0 +1,46 @@
+// Package resource 嵌入项目运行所需的静态资源
+package resource
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+)
+
+//go:embed sql/migrations/*.sql sql/migrations/postgres/*.sql sql/migrations/sqlite/*.sql
+var migrations embed.FS
+
+//go:embed seed/*.yaml
+var seeds embed.FS
+
+// Migrations MySQL 数据库迁移脚本，文件位于根目录
+func Migrations() fs.FS {
+	sub, err := fs.Sub(migrations, "sql/migrations")
+	if err != nil {
+		// 嵌入路径在编译期已确定，不会出错
+		panic(err)
+	}
+	return sub
+}
+
+// MigrationsFor 按数据库方言获取迁移脚本，dialect 与 gorm.Dialector.Name() 一致
+// MySQL 的脚本位于 sql/migrations，其他数据库位于同名子目录
+func MigrationsFor(dialect string) (fs.FS, error) {
+	switch dialect {
+	case "", "mysql":
+		return Migrations(), nil
+	case "postgres", "sqlite":
+		return fs.Sub(migrations, "sql/migrations/"+dialect)
+	default:
+		return nil, fmt.Errorf("没有 %s 的迁移脚本", dialect)
+	}
+}
+
+// Seeds 初始化数据，文件位于根目录，默认数据为 default.yaml
+func Seeds() fs.FS {
+	sub, err := fs.Sub(seeds, "seed")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
@@ -0,0 +1,21 @@
+-- 回滚初始化表结构
+
+SET FOREIGN_KEY_CHECKS = 0;
+
+DROP TABLE IF EXISTS `sw_sys_api_key_scope`;
+DROP TABLE IF EXISTS `sw_sys_api_key`;
+DROP TABLE IF EXISTS `sw_sys_user_oauth`;
+DROP TABLE IF EXISTS `sw_sys_file`;
+DROP TABLE IF EXISTS `sw_sys_login_log`;
+DROP TABLE IF EXISTS `sw_sys_operation_log`;
+DROP TABLE IF EXISTS `sw_sys_user`;
+DROP TABLE IF EXISTS `sw_sys_role_api`;
+DROP TABLE IF EXISTS `sw_sys_api`;
+DROP TABLE IF EXISTS `sw_sys_api_group`;
+DROP TABLE IF EXISTS `sw_sys_role_menu`;
+DROP TABLE IF EXISTS `sw_sys_menu`;
+DROP TABLE IF EXISTS `sw_sys_role`;
+DROP TABLE IF EXISTS `sw_sys_post`;
+DROP TABLE IF EXISTS `sw_sys_dept`;
+
+SET FOREIGN_KEY_CHECKS = 1;
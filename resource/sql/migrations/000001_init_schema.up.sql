@@ -1,18 +1,4 @@
-/*
- Navicat Premium Dump SQL
-
- Source Server         : 本地
- Source Server Type    : MySQL
- Source Server Version : 80041 (8.0.41)
- Source Host           : localhost:3306
- Source Schema         : sweet
-
- Target Server Type    : MySQL
- Target Server Version : 80041 (8.0.41)
- File Encoding         : 65001
-
- Date: 30/07/2025 23:48:11
-*/
+-- 初始化表结构
 
 SET NAMES utf8mb4;
 SET FOREIGN_KEY_CHECKS = 0;
@@ -20,8 +6,7 @@ SET FOREIGN_KEY_CHECKS = 0;
 -- ----------------------------
 -- Table structure for sw_sys_dept
 -- ----------------------------
-DROP TABLE IF EXISTS `sw_sys_dept`;
-CREATE TABLE `sw_sys_dept` (
+CREATE TABLE IF NOT EXISTS `sw_sys_dept` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '部门ID',
   `parent_id` bigint unsigned DEFAULT '0' COMMENT '父部门ID',
   `name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '部门名称',
@@ -44,8 +29,7 @@ CREATE TABLE `sw_sys_dept` (
 -- ----------------------------
 -- Table structure for sw_sys_post
 -- ----------------------------
-DROP TABLE IF EXISTS `sw_sys_post`;
-CREATE TABLE `sw_sys_post` (
+CREATE TABLE IF NOT EXISTS `sw_sys_post` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '岗位ID',
   `dept_id` bigint unsigned NOT NULL COMMENT '所属部门',
   `name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '岗位名称',
@@ -69,8 +53,7 @@ CREATE TABLE `sw_sys_post` (
 -- ----------------------------
 -- Table structure for sw_sys_role
 -- ----------------------------
-DROP TABLE IF EXISTS `sw_sys_role`;
-CREATE TABLE `sw_sys_role` (
+CREATE TABLE IF NOT EXISTS `sw_sys_role` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '角色ID',
   `name` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '角色名称',
   `code` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '角色标识',
@@ -95,8 +78,7 @@ CREATE TABLE `sw_sys_role` (
 -- ----------------------------
 -- Table structure for sw_sys_menu
 -- ----------------------------
-DROP TABLE IF EXISTS `sw_sys_menu`;
-CREATE TABLE `sw_sys_menu` (
+CREATE TABLE IF NOT EXISTS `sw_sys_menu` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '菜单ID',
   `name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '组件名称/路由名称',
   `title` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '菜单名称',
@@ -142,8 +124,7 @@ CREATE TABLE `sw_sys_menu` (
 -- ----------------------------
 -- Table structure for sw_sys_role_menu
 -- ----------------------------
-DROP TABLE IF EXISTS `sw_sys_role_menu`;
-CREATE TABLE `sw_sys_role_menu` (
+CREATE TABLE IF NOT EXISTS `sw_sys_role_menu` (
   `role_id` bigint unsigned NOT NULL COMMENT '角色ID',
   `menu_id` bigint unsigned NOT NULL COMMENT '菜单ID',
   PRIMARY KEY (`role_id`,`menu_id`),
@@ -156,8 +137,7 @@ CREATE TABLE `sw_sys_role_menu` (
 -- ----------------------------
 -- Table structure for sw_sys_api_group
 -- ----------------------------
-DROP TABLE IF EXISTS `sw_sys_api_group`;
-CREATE TABLE `sw_sys_api_group` (
+CREATE TABLE IF NOT EXISTS `sw_sys_api_group` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '分组ID',
   `name` varchar(50) NOT NULL COMMENT '分组名称',
   `code` varchar(50) NOT NULL COMMENT '分组编码',
@@ -180,8 +160,7 @@ CREATE TABLE `sw_sys_api_group` (
 -- ----------------------------
 -- Table structure for sw_sys_api
 -- ----------------------------
-DROP TABLE IF EXISTS `sw_sys_api`;
-CREATE TABLE `sw_sys_api` (
+CREATE TABLE IF NOT EXISTS `sw_sys_api` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT 'API ID',
   `name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'API名称',
   `path` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'API路径',
@@ -208,8 +187,7 @@ CREATE TABLE `sw_sys_api` (
 -- 角色API关联表----------------------------
 -- Table structure for sw_sys_role_api
 -- ----------------------------
-DROP TABLE IF EXISTS `sw_sys_role_api`;
-CREATE TABLE `sw_sys_role_api` (
+CREATE TABLE IF NOT EXISTS `sw_sys_role_api` (
   `role_id` bigint unsigned NOT NULL COMMENT '角色ID',
   `api_id` bigint unsigned NOT NULL COMMENT 'API ID',
   PRIMARY KEY (`role_id`,`api_id`),
@@ -222,8 +200,7 @@ CREATE TABLE `sw_sys_role_api` (
 -- ----------------------------
 -- Table structure for sw_sys_user
 -- ----------------------------
-DROP TABLE IF EXISTS `sw_sys_user`;
-CREATE TABLE `sw_sys_user` (
+CREATE TABLE IF NOT EXISTS `sw_sys_user` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '管理员ID',
   `username` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '登录用户名',
   `password` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '登录密码',
@@ -231,8 +208,10 @@ CREATE TABLE `sw_sys_user` (
   `realname` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '真实姓名',
   `nickname` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '昵称',
   `avatar` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT '头像',
-  `email` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT '邮箱',
-  `phone` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT '手机号',
+  `email` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT '邮箱（加密存储）',
+  `phone` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT '' COMMENT '手机号（加密存储）',
+  `email_index` char(64) CHARACTER SET ascii COLLATE ascii_bin DEFAULT NULL COMMENT '邮箱盲索引',
+  `phone_index` char(64) CHARACTER SET ascii COLLATE ascii_bin DEFAULT NULL COMMENT '手机号盲索引',
   `status` tinyint unsigned NOT NULL DEFAULT '1' COMMENT '状态：1=正常，2=禁用',
   `role_id` bigint unsigned DEFAULT NULL COMMENT '角色ID',
   `dept_id` bigint unsigned DEFAULT NULL COMMENT '部门ID',
@@ -243,8 +222,8 @@ CREATE TABLE `sw_sys_user` (
   `deleted_at` datetime DEFAULT NULL COMMENT '删除时间',
   PRIMARY KEY (`id`),
   UNIQUE KEY `uk_username` (`username`),
-  UNIQUE KEY `uk_email` (`email`) COMMENT '邮箱唯一约束（非空时）',
-  UNIQUE KEY `uk_phone` (`phone`) COMMENT '手机号唯一约束（非空时）',
+  UNIQUE KEY `uk_email_index` (`email_index`) COMMENT '邮箱唯一约束（非空时）',
+  UNIQUE KEY `uk_phone_index` (`phone_index`) COMMENT '手机号唯一约束（非空时）',
   KEY `idx_status` (`status`),
   KEY `idx_role_id` (`role_id`),
   KEY `idx_dept` (`dept_id`),
@@ -260,18 +239,19 @@ CREATE TABLE `sw_sys_user` (
 -- ----------------------------
 -- Table structure for sw_sys_operation_log
 -- ----------------------------
-DROP TABLE IF EXISTS `sw_sys_operation_log`;
-CREATE TABLE `sw_sys_operation_log` (
+CREATE TABLE IF NOT EXISTS `sw_sys_operation_log` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '日志ID',
   `user_id` bigint unsigned DEFAULT NULL COMMENT '操作用户ID',
   `username` varchar(64) DEFAULT NULL COMMENT '操作用户名',
+  `impersonator_id` bigint unsigned DEFAULT NULL COMMENT '模拟登录的管理员ID（非模拟登录为空）',
   `module` varchar(50) NOT NULL COMMENT '操作模块',
   `operation` varchar(50) NOT NULL COMMENT '操作类型',
   `method` varchar(10) NOT NULL COMMENT 'HTTP方法',
   `url` varchar(255) NOT NULL COMMENT '请求URL',
-  `ip` varchar(45) NOT NULL COMMENT '操作IP',
+  `ip` varchar(255) NOT NULL COMMENT '操作IP（加密存储）',
+  `ip_index` char(64) CHARACTER SET ascii COLLATE ascii_bin DEFAULT NULL COMMENT '操作IP盲索引',
   `location` varchar(100) DEFAULT NULL COMMENT 'IP归属地',
-  `user_agent` varchar(500) DEFAULT NULL COMMENT '用户代理',
+  `user_agent` varchar(1024) DEFAULT NULL COMMENT '用户代理（加密存储）',
   `request_params` text COMMENT '请求参数',
   `response_data` text COMMENT '响应数据',
   `status` tinyint(1) NOT NULL DEFAULT '1' COMMENT '操作状态（1成功 2失败）',
@@ -281,10 +261,11 @@ CREATE TABLE `sw_sys_operation_log` (
   PRIMARY KEY (`id`),
   KEY `idx_user_id` (`user_id`),
   KEY `idx_username` (`username`),
+  KEY `idx_impersonator_id` (`impersonator_id`),
   KEY `idx_module` (`module`),
   KEY `idx_operation` (`operation`),
   KEY `idx_status` (`status`),
-  KEY `idx_ip` (`ip`),
+  KEY `idx_ip_index` (`ip_index`),
   KEY `idx_created_at` (`created_at`),
   KEY `idx_user_module` (`user_id`,`module`),
   KEY `idx_module_operation` (`module`,`operation`),
@@ -294,16 +275,16 @@ CREATE TABLE `sw_sys_operation_log` (
 -- ----------------------------
 -- Table structure for sw_sys_login_log
 -- ----------------------------
-DROP TABLE IF EXISTS `sw_sys_login_log`;
-CREATE TABLE `sw_sys_login_log` (
+CREATE TABLE IF NOT EXISTS `sw_sys_login_log` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '日志ID',
   `user_id` bigint unsigned DEFAULT NULL COMMENT '用户ID',
   `username` varchar(64) NOT NULL COMMENT '登录用户名',
   `login_type` tinyint(1) NOT NULL DEFAULT '1' COMMENT '登录类型（1账号密码 2手机验证码 3邮箱验证码 4第三方登录 5微信 6QQ 7支付宝）',
   `client_type` tinyint(1) NOT NULL DEFAULT '1' COMMENT '客户端类型（1Web 2移动端 3小程序 4API 5管理后台）',
-  `ip` varchar(45) NOT NULL COMMENT '登录IP',
+  `ip` varchar(255) NOT NULL COMMENT '登录IP（加密存储）',
+  `ip_index` char(64) CHARACTER SET ascii COLLATE ascii_bin DEFAULT NULL COMMENT '登录IP盲索引',
   `location` varchar(64) DEFAULT NULL COMMENT 'IP归属地',
-  `user_agent` text DEFAULT NULL COMMENT '用户代理',
+  `user_agent` text DEFAULT NULL COMMENT '用户代理（加密存储）',
   `device_info` varchar(64) DEFAULT NULL COMMENT '设备信息',
   `browser` varchar(100) DEFAULT NULL COMMENT '浏览器',
   `os` varchar(100) DEFAULT NULL COMMENT '操作系统',
@@ -319,7 +300,7 @@ CREATE TABLE `sw_sys_login_log` (
   PRIMARY KEY (`id`),
   KEY `idx_user_id` (`user_id`),
   KEY `idx_username` (`username`),
-  KEY `idx_ip` (`ip`),
+  KEY `idx_ip_index` (`ip_index`),
   KEY `idx_status` (`status`),
   KEY `idx_created_at` (`created_at`),
   KEY `idx_login_type` (`login_type`),
@@ -332,8 +313,7 @@ CREATE TABLE `sw_sys_login_log` (
 -- ----------------------------
 -- Table structure for sw_sys_file
 -- ----------------------------
-DROP TABLE IF EXISTS `sw_sys_file`;
-CREATE TABLE `sw_sys_file` (
+CREATE TABLE IF NOT EXISTS `sw_sys_file` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '文件ID',
   `name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '文件名称',
   `original_name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '原始文件名',
@@ -358,4 +338,65 @@ CREATE TABLE `sw_sys_file` (
   CONSTRAINT `fk_file_upload_user` FOREIGN KEY (`upload_user_id`) REFERENCES `sw_sys_user` (`id`) ON DELETE SET NULL ON UPDATE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='系统文件表';
 
-SET FOREIGN_KEY_CHECKS = 1;
\ No newline at end of file
+-- ----------------------------
+-- Table structure for sw_sys_user_oauth
+-- ----------------------------
+CREATE TABLE IF NOT EXISTS `sw_sys_user_oauth` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '绑定ID',
+  `user_id` bigint unsigned NOT NULL COMMENT '用户ID',
+  `provider` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '身份提供方名称',
+  `issuer` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '签发者',
+  `subject` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '外部用户标识(sub)',
+  `email` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT '外部邮箱',
+  `nickname` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT '外部昵称',
+  `last_login_at` datetime DEFAULT NULL COMMENT '最后登录时间',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_provider_subject` (`provider`,`subject`),
+  KEY `idx_user_id` (`user_id`),
+  CONSTRAINT `fk_user_oauth_user` FOREIGN KEY (`user_id`) REFERENCES `sw_sys_user` (`id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='第三方账号绑定表';
+
+-- ----------------------------
+-- Table structure for sw_sys_api_key
+-- ----------------------------
+CREATE TABLE IF NOT EXISTS `sw_sys_api_key` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '密钥ID',
+  `name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '密钥名称',
+  `prefix` varchar(16) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '密钥前缀（明文，用于查找）',
+  `secret_hash` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL COMMENT '密钥哈希（SHA256）',
+  `owner_type` tinyint unsigned NOT NULL DEFAULT '1' COMMENT '归属类型：1=用户，2=服务',
+  `user_id` bigint unsigned DEFAULT NULL COMMENT '归属用户ID（归属类型为用户时）',
+  `service_name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT '归属服务名称（归属类型为服务时）',
+  `status` tinyint unsigned NOT NULL DEFAULT '1' COMMENT '状态：1=正常，2=已吊销',
+  `expires_at` datetime DEFAULT NULL COMMENT '过期时间（为空表示永不过期）',
+  `last_used_at` datetime DEFAULT NULL COMMENT '最后使用时间',
+  `last_used_ip` varchar(45) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT '最后使用IP',
+  `revoked_at` datetime DEFAULT NULL COMMENT '吊销时间',
+  `remark` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci DEFAULT NULL COMMENT '备注',
+  `create_by` bigint unsigned DEFAULT NULL COMMENT '创建者',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_prefix` (`prefix`),
+  KEY `idx_user_id` (`user_id`),
+  KEY `idx_status` (`status`),
+  KEY `idx_deleted_at` (`deleted_at`),
+  CONSTRAINT `fk_api_key_user` FOREIGN KEY (`user_id`) REFERENCES `sw_sys_user` (`id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='API密钥表';
+
+-- ----------------------------
+-- Table structure for sw_sys_api_key_scope
+-- ----------------------------
+CREATE TABLE IF NOT EXISTS `sw_sys_api_key_scope` (
+  `key_id` bigint unsigned NOT NULL COMMENT '密钥ID',
+  `api_id` bigint unsigned NOT NULL COMMENT 'API ID',
+  PRIMARY KEY (`key_id`,`api_id`),
+  KEY `idx_api_id` (`api_id`),
+  CONSTRAINT `fk_api_key_scope_key` FOREIGN KEY (`key_id`) REFERENCES `sw_sys_api_key` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `fk_api_key_scope_api` FOREIGN KEY (`api_id`) REFERENCES `sw_sys_api` (`id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='API密钥授权范围表';
+
+SET FOREIGN_KEY_CHECKS = 1;
@@ -0,0 +1 @@
+ALTER TABLE `sw_sys_user` DROP COLUMN `password_reset`;
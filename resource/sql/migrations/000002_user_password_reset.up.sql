@@ -0,0 +1,4 @@
+-- 管理员首次登录或被重置密码后需修改密码
+
+ALTER TABLE `sw_sys_user`
+  ADD COLUMN `password_reset` tinyint unsigned NOT NULL DEFAULT '2' COMMENT '是否需要修改密码：1=是，2=否' AFTER `status`;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `sw_sys_audit_log`;
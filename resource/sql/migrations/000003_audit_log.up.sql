@@ -0,0 +1,17 @@
+-- 数据变更审计记录，由 pkg/database 的审计插件写入
+
+CREATE TABLE IF NOT EXISTS `sw_sys_audit_log` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '审计ID',
+  `entity` varchar(64) NOT NULL COMMENT '表名',
+  `entity_id` varchar(64) NOT NULL COMMENT '主键值',
+  `action` varchar(16) NOT NULL COMMENT '动作：update / delete',
+  `before` json DEFAULT NULL COMMENT '变更前快照',
+  `after` json DEFAULT NULL COMMENT '变更后快照',
+  `changes` json DEFAULT NULL COMMENT '字段差异',
+  `user_id` bigint unsigned DEFAULT NULL COMMENT '操作用户ID',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '变更时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_entity` (`entity`,`entity_id`,`created_at`),
+  KEY `idx_user_id` (`user_id`),
+  KEY `idx_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='数据变更审计表';
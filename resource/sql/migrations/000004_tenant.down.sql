@@ -0,0 +1,31 @@
+ALTER TABLE `sw_sys_audit_log` DROP INDEX `idx_tenant_id`, DROP COLUMN `tenant_id`;
+
+ALTER TABLE `sw_sys_operation_log` DROP INDEX `idx_tenant_created_at`, DROP COLUMN `tenant_id`;
+
+ALTER TABLE `sw_sys_login_log` DROP INDEX `idx_tenant_created_at`, DROP COLUMN `tenant_id`;
+
+ALTER TABLE `sw_sys_api_key` DROP INDEX `idx_tenant_id`, DROP COLUMN `tenant_id`;
+
+ALTER TABLE `sw_sys_user_oauth` DROP INDEX `idx_tenant_id`, DROP COLUMN `tenant_id`;
+
+ALTER TABLE `sw_sys_file`
+  DROP INDEX `uk_tenant_md5_size`,
+  ADD UNIQUE KEY `uk_md5_size` (`md5`,`file_size`),
+  DROP COLUMN `tenant_id`;
+
+ALTER TABLE `sw_sys_user`
+  DROP INDEX `uk_tenant_username`,
+  DROP INDEX `uk_tenant_email_index`,
+  DROP INDEX `uk_tenant_phone_index`,
+  ADD UNIQUE KEY `uk_username` (`username`),
+  ADD UNIQUE KEY `uk_email_index` (`email_index`) COMMENT '邮箱唯一约束（非空时）',
+  ADD UNIQUE KEY `uk_phone_index` (`phone_index`) COMMENT '手机号唯一约束（非空时）',
+  DROP COLUMN `tenant_id`;
+
+ALTER TABLE `sw_sys_role` DROP INDEX `uk_tenant_code`, ADD UNIQUE KEY `uk_code` (`code`), DROP COLUMN `tenant_id`;
+
+ALTER TABLE `sw_sys_post` DROP INDEX `uk_tenant_code`, ADD UNIQUE KEY `uk_code` (`code`), DROP COLUMN `tenant_id`;
+
+ALTER TABLE `sw_sys_dept` DROP INDEX `uk_tenant_code`, ADD UNIQUE KEY `uk_code` (`code`), DROP COLUMN `tenant_id`;
+
+DROP TABLE IF EXISTS `sw_sys_tenant`;
@@ -0,0 +1,68 @@
+-- 多租户：租户表，以及租户内数据表的 tenant_id 列
+-- 已有数据归属 1 号租户（超级租户），超级租户的用户可跨租户操作
+
+CREATE TABLE IF NOT EXISTS `sw_sys_tenant` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '租户ID',
+  `name` varchar(64) NOT NULL COMMENT '租户名称',
+  `code` varchar(64) NOT NULL COMMENT '租户编码，登录时使用',
+  `status` tinyint unsigned NOT NULL DEFAULT '1' COMMENT '状态：1=正常，2=禁用',
+  `remark` varchar(255) DEFAULT NULL COMMENT '备注',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+  `deleted_at` datetime DEFAULT NULL COMMENT '删除时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_code` (`code`),
+  KEY `idx_status` (`status`),
+  KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='租户表';
+
+INSERT INTO `sw_sys_tenant` (`id`, `name`, `code`, `status`, `remark`) VALUES (1, '平台', 'platform', 1, '超级租户，可跨租户操作');
+
+ALTER TABLE `sw_sys_dept`
+  ADD COLUMN `tenant_id` bigint unsigned NOT NULL DEFAULT '1' COMMENT '租户ID' AFTER `id`,
+  DROP INDEX `uk_code`,
+  ADD UNIQUE KEY `uk_tenant_code` (`tenant_id`,`code`);
+
+ALTER TABLE `sw_sys_post`
+  ADD COLUMN `tenant_id` bigint unsigned NOT NULL DEFAULT '1' COMMENT '租户ID' AFTER `id`,
+  DROP INDEX `uk_code`,
+  ADD UNIQUE KEY `uk_tenant_code` (`tenant_id`,`code`);
+
+ALTER TABLE `sw_sys_role`
+  ADD COLUMN `tenant_id` bigint unsigned NOT NULL DEFAULT '1' COMMENT '租户ID' AFTER `id`,
+  DROP INDEX `uk_code`,
+  ADD UNIQUE KEY `uk_tenant_code` (`tenant_id`,`code`);
+
+ALTER TABLE `sw_sys_user`
+  ADD COLUMN `tenant_id` bigint unsigned NOT NULL DEFAULT '1' COMMENT '租户ID' AFTER `id`,
+  DROP INDEX `uk_username`,
+  DROP INDEX `uk_email_index`,
+  DROP INDEX `uk_phone_index`,
+  ADD UNIQUE KEY `uk_tenant_username` (`tenant_id`,`username`),
+  ADD UNIQUE KEY `uk_tenant_email_index` (`tenant_id`,`email_index`) COMMENT '邮箱租户内唯一（非空时）',
+  ADD UNIQUE KEY `uk_tenant_phone_index` (`tenant_id`,`phone_index`) COMMENT '手机号租户内唯一（非空时）';
+
+ALTER TABLE `sw_sys_file`
+  ADD COLUMN `tenant_id` bigint unsigned NOT NULL DEFAULT '1' COMMENT '租户ID' AFTER `id`,
+  DROP INDEX `uk_md5_size`,
+  ADD UNIQUE KEY `uk_tenant_md5_size` (`tenant_id`,`md5`,`file_size`);
+
+ALTER TABLE `sw_sys_user_oauth`
+  ADD COLUMN `tenant_id` bigint unsigned NOT NULL DEFAULT '1' COMMENT '租户ID' AFTER `id`,
+  ADD KEY `idx_tenant_id` (`tenant_id`);
+
+ALTER TABLE `sw_sys_api_key`
+  ADD COLUMN `tenant_id` bigint unsigned NOT NULL DEFAULT '1' COMMENT '租户ID' AFTER `id`,
+  ADD KEY `idx_tenant_id` (`tenant_id`);
+
+ALTER TABLE `sw_sys_login_log`
+  ADD COLUMN `tenant_id` bigint unsigned NOT NULL DEFAULT '1' COMMENT '租户ID' AFTER `id`,
+  ADD KEY `idx_tenant_created_at` (`tenant_id`,`created_at`);
+
+ALTER TABLE `sw_sys_operation_log`
+  ADD COLUMN `tenant_id` bigint unsigned NOT NULL DEFAULT '1' COMMENT '租户ID' AFTER `id`,
+  ADD KEY `idx_tenant_created_at` (`tenant_id`,`created_at`);
+
+ALTER TABLE `sw_sys_audit_log`
+  ADD COLUMN `tenant_id` bigint unsigned NOT NULL DEFAULT '1' COMMENT '租户ID' AFTER `id`,
+  ADD KEY `idx_tenant_id` (`tenant_id`);
@@ -0,0 +1,5 @@
+ALTER TABLE `sw_sys_menu` DROP COLUMN `version`;
+
+ALTER TABLE `sw_sys_user` DROP COLUMN `version`;
+
+ALTER TABLE `sw_sys_role` DROP COLUMN `version`;
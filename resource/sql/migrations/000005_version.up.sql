@@ -0,0 +1,10 @@
+-- 乐观锁：可被多人同时编辑的表增加 version 列，每次更新加 1，更新时需带上读取到的版本号
+
+ALTER TABLE `sw_sys_role`
+  ADD COLUMN `version` int unsigned NOT NULL DEFAULT '1' COMMENT '版本号，乐观锁' AFTER `remark`;
+
+ALTER TABLE `sw_sys_user`
+  ADD COLUMN `version` int unsigned NOT NULL DEFAULT '1' COMMENT '版本号，乐观锁' AFTER `remark`;
+
+ALTER TABLE `sw_sys_menu`
+  ADD COLUMN `version` int unsigned NOT NULL DEFAULT '1' COMMENT '版本号，乐观锁' AFTER `update_by`;
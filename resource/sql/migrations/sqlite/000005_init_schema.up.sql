@@ -0,0 +1,358 @@
+-- SQLite 初始化表结构，用于测试与本地开发
+-- 以下为 MySQL 000001~000005 合并后的表结构，后续版本与 MySQL 使用相同的版本号
+-- 索引名在整个库内唯一，统一加表名前缀
+-- 外键仅在连接开启 PRAGMA foreign_keys 时生效
+
+CREATE TABLE IF NOT EXISTS sw_sys_tenant (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name VARCHAR(64) NOT NULL,
+  code VARCHAR(64) NOT NULL,
+  status SMALLINT NOT NULL DEFAULT 1,
+  remark VARCHAR(255) DEFAULT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMP DEFAULT NULL,
+  CONSTRAINT uk_sys_tenant_code UNIQUE (code)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_tenant_status ON sw_sys_tenant (status);
+CREATE INDEX IF NOT EXISTS idx_sys_tenant_deleted_at ON sw_sys_tenant (deleted_at);
+
+INSERT INTO sw_sys_tenant (id, name, code, status, remark) VALUES (1, '平台', 'platform', 1, '超级租户，可跨租户操作');
+
+CREATE TABLE IF NOT EXISTS sw_sys_dept (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  tenant_id BIGINT NOT NULL DEFAULT 1,
+  parent_id BIGINT DEFAULT 0,
+  name VARCHAR(64) NOT NULL,
+  code VARCHAR(64) DEFAULT NULL,
+  sort INTEGER NOT NULL DEFAULT 0,
+  status SMALLINT NOT NULL DEFAULT 1,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMP DEFAULT NULL,
+  CONSTRAINT uk_sys_dept_tenant_code UNIQUE (tenant_id, code)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_dept_parent_status ON sw_sys_dept (parent_id, status);
+CREATE INDEX IF NOT EXISTS idx_sys_dept_status_sort ON sw_sys_dept (status, sort);
+CREATE INDEX IF NOT EXISTS idx_sys_dept_sort ON sw_sys_dept (sort);
+CREATE INDEX IF NOT EXISTS idx_sys_dept_deleted_at ON sw_sys_dept (deleted_at);
+
+CREATE TABLE IF NOT EXISTS sw_sys_post (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  tenant_id BIGINT NOT NULL DEFAULT 1,
+  dept_id BIGINT NOT NULL REFERENCES sw_sys_dept (id) ON DELETE RESTRICT ON UPDATE CASCADE,
+  name VARCHAR(64) NOT NULL,
+  code VARCHAR(64) DEFAULT NULL,
+  sort INTEGER NOT NULL DEFAULT 0,
+  status SMALLINT NOT NULL DEFAULT 1,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMP DEFAULT NULL,
+  CONSTRAINT uk_sys_post_tenant_code UNIQUE (tenant_id, code)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_post_dept_status ON sw_sys_post (dept_id, status);
+CREATE INDEX IF NOT EXISTS idx_sys_post_status_sort ON sw_sys_post (status, sort);
+CREATE INDEX IF NOT EXISTS idx_sys_post_sort ON sw_sys_post (sort);
+CREATE INDEX IF NOT EXISTS idx_sys_post_deleted_at ON sw_sys_post (deleted_at);
+
+CREATE TABLE IF NOT EXISTS sw_sys_role (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  tenant_id BIGINT NOT NULL DEFAULT 1,
+  name VARCHAR(32) NOT NULL,
+  code VARCHAR(64) NOT NULL,
+  sort INTEGER NOT NULL DEFAULT 0,
+  is_system SMALLINT NOT NULL DEFAULT 2,
+  is_super SMALLINT NOT NULL DEFAULT 2,
+  status SMALLINT NOT NULL DEFAULT 1,
+  remark VARCHAR(255) DEFAULT '',
+  version INTEGER NOT NULL DEFAULT 1,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMP DEFAULT NULL,
+  CONSTRAINT uk_sys_role_tenant_code UNIQUE (tenant_id, code)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_role_status_sort ON sw_sys_role (status, sort);
+CREATE INDEX IF NOT EXISTS idx_sys_role_sort ON sw_sys_role (sort);
+CREATE INDEX IF NOT EXISTS idx_sys_role_is_system ON sw_sys_role (is_system);
+CREATE INDEX IF NOT EXISTS idx_sys_role_is_super ON sw_sys_role (is_super);
+CREATE INDEX IF NOT EXISTS idx_sys_role_deleted_at ON sw_sys_role (deleted_at);
+
+CREATE TABLE IF NOT EXISTS sw_sys_menu (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name VARCHAR(64) NOT NULL,
+  title VARCHAR(64) NOT NULL,
+  parent_id BIGINT DEFAULT NULL REFERENCES sw_sys_menu (id) ON DELETE SET NULL ON UPDATE CASCADE,
+  path VARCHAR(255) DEFAULT NULL,
+  component VARCHAR(255) DEFAULT NULL,
+  menu_type SMALLINT DEFAULT 1,
+  status SMALLINT NOT NULL DEFAULT 1,
+  perms VARCHAR(64) DEFAULT NULL,
+  icon VARCHAR(128) DEFAULT NULL,
+  "order" INTEGER DEFAULT 0,
+  remark VARCHAR(128) DEFAULT NULL,
+  "query" JSON DEFAULT NULL,
+  is_frame SMALLINT DEFAULT 2,
+  show_badge SMALLINT DEFAULT 2,
+  show_text_badge VARCHAR(24) DEFAULT NULL,
+  is_hide SMALLINT DEFAULT 2,
+  is_hide_tab SMALLINT DEFAULT 2,
+  link VARCHAR(255) DEFAULT NULL,
+  is_iframe SMALLINT DEFAULT 2,
+  keep_alive SMALLINT DEFAULT 2,
+  fixed_tab SMALLINT DEFAULT 2,
+  is_first_level SMALLINT DEFAULT 2,
+  active_path VARCHAR(255) DEFAULT NULL,
+  create_by BIGINT DEFAULT NULL,
+  update_by BIGINT DEFAULT NULL,
+  version INTEGER NOT NULL DEFAULT 1,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMP DEFAULT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_sys_menu_parent_status ON sw_sys_menu (parent_id, status);
+CREATE INDEX IF NOT EXISTS idx_sys_menu_type_status ON sw_sys_menu (menu_type, status);
+CREATE INDEX IF NOT EXISTS idx_sys_menu_status ON sw_sys_menu (status);
+CREATE INDEX IF NOT EXISTS idx_sys_menu_is_hide ON sw_sys_menu (is_hide);
+CREATE INDEX IF NOT EXISTS idx_sys_menu_keep_alive ON sw_sys_menu (keep_alive);
+CREATE INDEX IF NOT EXISTS idx_sys_menu_deleted_at ON sw_sys_menu (deleted_at);
+
+CREATE TABLE IF NOT EXISTS sw_sys_role_menu (
+  role_id BIGINT NOT NULL REFERENCES sw_sys_role (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  menu_id BIGINT NOT NULL REFERENCES sw_sys_menu (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  PRIMARY KEY (role_id, menu_id)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_role_menu_menu_id ON sw_sys_role_menu (menu_id);
+
+CREATE TABLE IF NOT EXISTS sw_sys_api_group (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name VARCHAR(50) NOT NULL,
+  code VARCHAR(50) NOT NULL,
+  description VARCHAR(200) DEFAULT NULL,
+  sort INTEGER NOT NULL DEFAULT 0,
+  status SMALLINT NOT NULL DEFAULT 1,
+  create_by BIGINT DEFAULT NULL,
+  update_by BIGINT DEFAULT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMP DEFAULT NULL,
+  CONSTRAINT uk_sys_api_group_code UNIQUE (code),
+  CONSTRAINT uk_sys_api_group_name UNIQUE (name)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_api_group_status ON sw_sys_api_group (status);
+CREATE INDEX IF NOT EXISTS idx_sys_api_group_sort ON sw_sys_api_group (sort);
+CREATE INDEX IF NOT EXISTS idx_sys_api_group_deleted_at ON sw_sys_api_group (deleted_at);
+
+CREATE TABLE IF NOT EXISTS sw_sys_api (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name VARCHAR(64) NOT NULL,
+  path VARCHAR(255) NOT NULL,
+  method VARCHAR(10) NOT NULL,
+  "group" VARCHAR(64) DEFAULT NULL REFERENCES sw_sys_api_group (code) ON DELETE RESTRICT ON UPDATE CASCADE,
+  description VARCHAR(255) DEFAULT NULL,
+  status SMALLINT NOT NULL DEFAULT 1,
+  is_auth SMALLINT NOT NULL DEFAULT 1,
+  create_by BIGINT DEFAULT NULL,
+  update_by BIGINT DEFAULT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMP DEFAULT NULL,
+  CONSTRAINT uk_sys_api_path_method UNIQUE (path, method)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_api_group_status ON sw_sys_api ("group", status);
+CREATE INDEX IF NOT EXISTS idx_sys_api_status ON sw_sys_api (status);
+CREATE INDEX IF NOT EXISTS idx_sys_api_is_auth ON sw_sys_api (is_auth);
+CREATE INDEX IF NOT EXISTS idx_sys_api_deleted_at ON sw_sys_api (deleted_at);
+
+CREATE TABLE IF NOT EXISTS sw_sys_role_api (
+  role_id BIGINT NOT NULL REFERENCES sw_sys_role (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  api_id BIGINT NOT NULL REFERENCES sw_sys_api (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  PRIMARY KEY (role_id, api_id)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_role_api_api_id ON sw_sys_role_api (api_id);
+
+CREATE TABLE IF NOT EXISTS sw_sys_user (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  tenant_id BIGINT NOT NULL DEFAULT 1,
+  username VARCHAR(32) NOT NULL,
+  password VARCHAR(128) NOT NULL,
+  salt VARCHAR(32) NOT NULL,
+  realname VARCHAR(32) NOT NULL,
+  nickname VARCHAR(32) NOT NULL,
+  avatar VARCHAR(255) DEFAULT '',
+  email VARCHAR(255) DEFAULT '',
+  phone VARCHAR(255) DEFAULT '',
+  email_index CHAR(64) DEFAULT NULL,
+  phone_index CHAR(64) DEFAULT NULL,
+  status SMALLINT NOT NULL DEFAULT 1,
+  password_reset SMALLINT NOT NULL DEFAULT 2,
+  role_id BIGINT DEFAULT NULL REFERENCES sw_sys_role (id) ON DELETE SET NULL ON UPDATE CASCADE,
+  dept_id BIGINT DEFAULT NULL REFERENCES sw_sys_dept (id) ON DELETE SET NULL ON UPDATE CASCADE,
+  post_id BIGINT DEFAULT NULL REFERENCES sw_sys_post (id) ON DELETE SET NULL ON UPDATE CASCADE,
+  remark VARCHAR(255) DEFAULT '',
+  version INTEGER NOT NULL DEFAULT 1,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMP DEFAULT NULL,
+  CONSTRAINT uk_sys_user_tenant_username UNIQUE (tenant_id, username),
+  CONSTRAINT uk_sys_user_tenant_email_index UNIQUE (tenant_id, email_index),
+  CONSTRAINT uk_sys_user_tenant_phone_index UNIQUE (tenant_id, phone_index)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_user_status_dept ON sw_sys_user (status, dept_id);
+CREATE INDEX IF NOT EXISTS idx_sys_user_status_role ON sw_sys_user (status, role_id);
+CREATE INDEX IF NOT EXISTS idx_sys_user_role_id ON sw_sys_user (role_id);
+CREATE INDEX IF NOT EXISTS idx_sys_user_dept_id ON sw_sys_user (dept_id);
+CREATE INDEX IF NOT EXISTS idx_sys_user_post_id ON sw_sys_user (post_id);
+CREATE INDEX IF NOT EXISTS idx_sys_user_deleted_at ON sw_sys_user (deleted_at);
+
+CREATE TABLE IF NOT EXISTS sw_sys_operation_log (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  tenant_id BIGINT NOT NULL DEFAULT 1,
+  user_id BIGINT DEFAULT NULL REFERENCES sw_sys_user (id) ON DELETE SET NULL ON UPDATE CASCADE,
+  username VARCHAR(64) DEFAULT NULL,
+  impersonator_id BIGINT DEFAULT NULL,
+  module VARCHAR(50) NOT NULL,
+  operation VARCHAR(50) NOT NULL,
+  method VARCHAR(10) NOT NULL,
+  url VARCHAR(255) NOT NULL,
+  ip VARCHAR(255) NOT NULL,
+  ip_index CHAR(64) DEFAULT NULL,
+  location VARCHAR(100) DEFAULT NULL,
+  user_agent VARCHAR(1024) DEFAULT NULL,
+  request_params TEXT,
+  response_data TEXT,
+  status SMALLINT NOT NULL DEFAULT 1,
+  error_msg VARCHAR(500) DEFAULT NULL,
+  cost_time INTEGER DEFAULT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_sys_operation_log_tenant_created_at ON sw_sys_operation_log (tenant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_sys_operation_log_user_module ON sw_sys_operation_log (user_id, module);
+CREATE INDEX IF NOT EXISTS idx_sys_operation_log_module_operation ON sw_sys_operation_log (module, operation);
+CREATE INDEX IF NOT EXISTS idx_sys_operation_log_username ON sw_sys_operation_log (username);
+CREATE INDEX IF NOT EXISTS idx_sys_operation_log_impersonator_id ON sw_sys_operation_log (impersonator_id);
+CREATE INDEX IF NOT EXISTS idx_sys_operation_log_status ON sw_sys_operation_log (status);
+CREATE INDEX IF NOT EXISTS idx_sys_operation_log_ip_index ON sw_sys_operation_log (ip_index);
+CREATE INDEX IF NOT EXISTS idx_sys_operation_log_created_at ON sw_sys_operation_log (created_at);
+
+CREATE TABLE IF NOT EXISTS sw_sys_login_log (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  tenant_id BIGINT NOT NULL DEFAULT 1,
+  user_id BIGINT DEFAULT NULL,
+  username VARCHAR(64) NOT NULL,
+  login_type SMALLINT NOT NULL DEFAULT 1,
+  client_type SMALLINT NOT NULL DEFAULT 1,
+  ip VARCHAR(255) NOT NULL,
+  ip_index CHAR(64) DEFAULT NULL,
+  location VARCHAR(64) DEFAULT NULL,
+  user_agent TEXT DEFAULT NULL,
+  device_info VARCHAR(64) DEFAULT NULL,
+  browser VARCHAR(100) DEFAULT NULL,
+  os VARCHAR(100) DEFAULT NULL,
+  status SMALLINT NOT NULL DEFAULT 1,
+  fail_reason VARCHAR(64) DEFAULT NULL,
+  session_id VARCHAR(128) DEFAULT NULL,
+  login_duration INTEGER DEFAULT NULL,
+  logout_type SMALLINT DEFAULT NULL,
+  risk_level SMALLINT NOT NULL DEFAULT 1,
+  is_deleted SMALLINT NOT NULL DEFAULT 0,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_sys_login_log_tenant_created_at ON sw_sys_login_log (tenant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_sys_login_log_composite_query ON sw_sys_login_log (user_id, status, created_at);
+CREATE INDEX IF NOT EXISTS idx_sys_login_log_username ON sw_sys_login_log (username);
+CREATE INDEX IF NOT EXISTS idx_sys_login_log_ip_index ON sw_sys_login_log (ip_index);
+CREATE INDEX IF NOT EXISTS idx_sys_login_log_status ON sw_sys_login_log (status);
+CREATE INDEX IF NOT EXISTS idx_sys_login_log_created_at ON sw_sys_login_log (created_at);
+CREATE INDEX IF NOT EXISTS idx_sys_login_log_session_id ON sw_sys_login_log (session_id);
+
+CREATE TABLE IF NOT EXISTS sw_sys_file (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  tenant_id BIGINT NOT NULL DEFAULT 1,
+  name VARCHAR(64) NOT NULL,
+  original_name VARCHAR(64) NOT NULL,
+  file_path VARCHAR(255) NOT NULL,
+  file_url VARCHAR(255) DEFAULT NULL,
+  file_size BIGINT NOT NULL,
+  file_type VARCHAR(32) NOT NULL,
+  file_ext VARCHAR(20) NOT NULL,
+  md5 VARCHAR(32) NOT NULL,
+  storage_type SMALLINT NOT NULL DEFAULT 1,
+  upload_user_id BIGINT DEFAULT NULL REFERENCES sw_sys_user (id) ON DELETE SET NULL ON UPDATE CASCADE,
+  status SMALLINT NOT NULL DEFAULT 1,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMP DEFAULT NULL,
+  CONSTRAINT uk_sys_file_tenant_md5_size UNIQUE (tenant_id, md5, file_size)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_file_file_type ON sw_sys_file (file_type);
+CREATE INDEX IF NOT EXISTS idx_sys_file_upload_user ON sw_sys_file (upload_user_id);
+CREATE INDEX IF NOT EXISTS idx_sys_file_status ON sw_sys_file (status);
+CREATE INDEX IF NOT EXISTS idx_sys_file_deleted_at ON sw_sys_file (deleted_at);
+
+CREATE TABLE IF NOT EXISTS sw_sys_user_oauth (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  tenant_id BIGINT NOT NULL DEFAULT 1,
+  user_id BIGINT NOT NULL REFERENCES sw_sys_user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  provider VARCHAR(32) NOT NULL,
+  issuer VARCHAR(255) NOT NULL,
+  subject VARCHAR(255) NOT NULL,
+  email VARCHAR(64) DEFAULT NULL,
+  nickname VARCHAR(64) DEFAULT NULL,
+  last_login_at TIMESTAMP DEFAULT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT uk_sys_user_oauth_provider_subject UNIQUE (provider, subject)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_user_oauth_tenant_id ON sw_sys_user_oauth (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_sys_user_oauth_user_id ON sw_sys_user_oauth (user_id);
+
+CREATE TABLE IF NOT EXISTS sw_sys_api_key (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  tenant_id BIGINT NOT NULL DEFAULT 1,
+  name VARCHAR(64) NOT NULL,
+  prefix VARCHAR(16) NOT NULL,
+  secret_hash VARCHAR(64) NOT NULL,
+  owner_type SMALLINT NOT NULL DEFAULT 1,
+  user_id BIGINT DEFAULT NULL REFERENCES sw_sys_user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  service_name VARCHAR(64) DEFAULT NULL,
+  status SMALLINT NOT NULL DEFAULT 1,
+  expires_at TIMESTAMP DEFAULT NULL,
+  last_used_at TIMESTAMP DEFAULT NULL,
+  last_used_ip VARCHAR(45) DEFAULT NULL,
+  revoked_at TIMESTAMP DEFAULT NULL,
+  remark VARCHAR(255) DEFAULT NULL,
+  create_by BIGINT DEFAULT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMP DEFAULT NULL,
+  CONSTRAINT uk_sys_api_key_prefix UNIQUE (prefix)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_api_key_tenant_id ON sw_sys_api_key (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_sys_api_key_user_id ON sw_sys_api_key (user_id);
+CREATE INDEX IF NOT EXISTS idx_sys_api_key_status ON sw_sys_api_key (status);
+CREATE INDEX IF NOT EXISTS idx_sys_api_key_deleted_at ON sw_sys_api_key (deleted_at);
+
+CREATE TABLE IF NOT EXISTS sw_sys_api_key_scope (
+  key_id BIGINT NOT NULL REFERENCES sw_sys_api_key (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  api_id BIGINT NOT NULL REFERENCES sw_sys_api (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  PRIMARY KEY (key_id, api_id)
+);
+CREATE INDEX IF NOT EXISTS idx_sys_api_key_scope_api_id ON sw_sys_api_key_scope (api_id);
+
+CREATE TABLE IF NOT EXISTS sw_sys_audit_log (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  tenant_id BIGINT NOT NULL DEFAULT 1,
+  entity VARCHAR(64) NOT NULL,
+  entity_id VARCHAR(64) NOT NULL,
+  action VARCHAR(16) NOT NULL,
+  "before" JSON DEFAULT NULL,
+  "after" JSON DEFAULT NULL,
+  changes JSON DEFAULT NULL,
+  user_id BIGINT DEFAULT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_sys_audit_log_tenant_id ON sw_sys_audit_log (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_sys_audit_log_entity ON sw_sys_audit_log (entity, entity_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_sys_audit_log_user_id ON sw_sys_audit_log (user_id);
+CREATE INDEX IF NOT EXISTS idx_sys_audit_log_created_at ON sw_sys_audit_log (created_at);
@@ -0,0 +1,212 @@
+package scripts
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"sweet/pkg/crypto"
+)
+
+//go:embed gen.yaml
+var defaultGenConfig []byte
+
+// 关联关系类型
+const (
+	RelationBelongsTo  = "belongs_to"
+	RelationHasOne     = "has_one"
+	RelationHasMany    = "has_many"
+	RelationManyToMany = "many_to_many"
+)
+
+// TableConfig 单表生成配置
+type TableConfig struct {
+	Name       string            `yaml:"name"`        // 表名
+	SoftDelete bool              `yaml:"soft_delete"` // deleted_at 使用 gorm.DeletedAt
+	Encrypted  []string          `yaml:"encrypted"`   // 使用 encrypted 序列化器透明加解密的字段
+	BlindIndex []string          `yaml:"blind_index"` // 盲索引字段，不输出到JSON
+	Types      map[string]string `yaml:"types"`       // 字段类型覆盖，列名到Go类型
+	Relations  []*RelationConfig `yaml:"relations"`   // 关联关系
+}
+
+// RelationConfig 关联关系配置
+type RelationConfig struct {
+	Name       string `yaml:"name"`        // 字段名，如 Role
+	Type       string `yaml:"type"`        // belongs_to、has_one、has_many、many_to_many
+	Table      string `yaml:"table"`       // 关联的表
+	ForeignKey string `yaml:"foreign_key"` // 外键字段
+	References string `yaml:"references"`  // 引用字段，默认 ID
+	JoinTable  string `yaml:"join_table"`  // many_to_many 的中间表
+	JSON       string `yaml:"json"`        // JSON 标签，默认为字段名的蛇形命名
+}
+
+// DefaultGenConfig 返回内置的生成配置（scripts/gen.yaml）
+func DefaultGenConfig() (*GenConfig, error) {
+	return ParseGenConfig(defaultGenConfig)
+}
+
+// LoadGenConfig 读取生成配置文件
+func LoadGenConfig(path string) (*GenConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取生成配置失败: %w", err)
+	}
+	config, err := ParseGenConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// ParseGenConfig 解析并校验 YAML 格式的生成配置
+func ParseGenConfig(data []byte) (*GenConfig, error) {
+	var config GenConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("解析生成配置失败: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate 校验表名唯一、关联目标存在且类型合法
+func (c *GenConfig) Validate() error {
+	if c.OutPath == "" || c.ModelPkgPath == "" {
+		return fmt.Errorf("out_path 和 model_pkg_path 不能为空")
+	}
+	if len(c.Tables) == 0 {
+		return fmt.Errorf("tables 不能为空")
+	}
+
+	tables := make(map[string]struct{}, len(c.Tables))
+	for _, t := range c.Tables {
+		if t.Name == "" {
+			return fmt.Errorf("表名不能为空")
+		}
+		if _, ok := tables[t.Name]; ok {
+			return fmt.Errorf("表 %s 重复", t.Name)
+		}
+		tables[t.Name] = struct{}{}
+	}
+
+	for _, t := range c.Tables {
+		names := make(map[string]struct{}, len(t.Relations))
+		for _, r := range t.Relations {
+			if r.Name == "" || r.ForeignKey == "" {
+				return fmt.Errorf("表 %s 的关联缺少 name 或 foreign_key", t.Name)
+			}
+			if _, ok := names[r.Name]; ok {
+				return fmt.Errorf("表 %s 的关联 %s 重复", t.Name, r.Name)
+			}
+			names[r.Name] = struct{}{}
+			if _, ok := tables[r.Table]; !ok {
+				return fmt.Errorf("表 %s 的关联 %s 指向的表 %q 不在 tables 中", t.Name, r.Name, r.Table)
+			}
+			switch r.Type {
+			case RelationBelongsTo, RelationHasOne, RelationHasMany:
+			case RelationManyToMany:
+				if r.JoinTable == "" {
+					return fmt.Errorf("表 %s 的关联 %s 缺少 join_table", t.Name, r.Name)
+				}
+			default:
+				return fmt.Errorf("表 %s 的关联 %s 类型不合法: %q", t.Name, r.Name, r.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// dataTypeMap 合并默认与配置中的类型映射
+func (c *GenConfig) dataTypeMap() map[string]func(columnType gorm.ColumnType) (dataType string) {
+	types := map[string]string{
+		"tinyint":   "int64",
+		"smallint":  "int64",
+		"mediumint": "int64",
+		"bigint":    "int64",
+		"int":       "int64",
+	}
+	for dbType, goType := range c.TypeMap {
+		types[dbType] = goType
+	}
+
+	dataMap := make(map[string]func(columnType gorm.ColumnType) (dataType string), len(types))
+	for dbType, goType := range types {
+		goType := goType
+		dataMap[dbType] = func(gorm.ColumnType) string { return goType }
+	}
+	return dataMap
+}
+
+// relateFunc 创建指向某张表的关联字段
+type relateFunc func(relationship field.RelationshipType, fieldName string, config *field.RelateConfig) gen.ModelOpt
+
+// modelOpts 将表配置转换为 gen 的模型选项，顺序固定以保证生成结果稳定
+func (t *TableConfig) modelOpts(relates map[string]relateFunc) []gen.ModelOpt {
+	var opts []gen.ModelOpt
+	if t.SoftDelete {
+		opts = append(opts, gen.FieldType("deleted_at", "gorm.DeletedAt"))
+	}
+	for _, column := range t.Encrypted {
+		opts = append(opts, gen.FieldGORMTag(column, func(tag field.GormTag) field.GormTag {
+			return tag.Set("serializer", crypto.EncryptedSerializerName)
+		}))
+	}
+	for _, column := range t.BlindIndex {
+		opts = append(opts, gen.FieldJSONTag(column, "-"))
+	}
+
+	columns := make([]string, 0, len(t.Types))
+	for column := range t.Types {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	for _, column := range columns {
+		opts = append(opts, gen.FieldType(column, t.Types[column]))
+	}
+
+	for _, r := range t.Relations {
+		opts = append(opts, r.modelOpt(relates[r.Table]))
+	}
+	return opts
+}
+
+// modelOpt 将关联配置转换为 gen 的关联字段
+func (r *RelationConfig) modelOpt(relate relateFunc) gen.ModelOpt {
+	references := r.References
+	if references == "" {
+		references = "ID"
+	}
+	jsonTag := r.JSON
+	if jsonTag == "" {
+		jsonTag = schema.NamingStrategy{}.ColumnName("", r.Name)
+	}
+
+	config := &field.RelateConfig{
+		GORMTag: field.GormTag{
+			"foreignKey": {r.ForeignKey},
+			"references": {references},
+		},
+		JSONTag: jsonTag,
+	}
+	var relationship field.RelationshipType
+	switch r.Type {
+	case RelationBelongsTo:
+		relationship, config.RelatePointer = field.BelongsTo, true
+	case RelationHasOne:
+		relationship, config.RelatePointer = field.HasOne, true
+	case RelationHasMany:
+		relationship, config.RelateSlicePointer = field.HasMany, true
+	case RelationManyToMany:
+		relationship, config.RelateSlicePointer = field.Many2Many, true
+		config.GORMTag.Set("many2many", r.JoinTable)
+	}
+	return relate(relationship, r.Name, config)
+}
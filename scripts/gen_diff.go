@@ -0,0 +1,170 @@
+package scripts
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gorm.io/gorm"
+)
+
+// DryRun 在模块内的临时目录生成代码，与现有文件对比后返回统一diff格式的差异，不修改现有文件
+// 返回空字符串表示生成结果与现有代码一致
+func DryRun(config *GenConfig, db *gorm.DB) (string, error) {
+	outPath, err := filepath.Abs(config.OutPath)
+	if err != nil {
+		return "", err
+	}
+	modelPath, err := filepath.Abs(config.ModelPkgPath)
+	if err != nil {
+		return "", err
+	}
+	if filepath.Base(outPath) == filepath.Base(modelPath) {
+		return "", fmt.Errorf("out_path 与 model_pkg_path 的目录名不能相同")
+	}
+
+	// 临时目录必须位于模块内，query 代码才能解析出 entity 的导入路径
+	root, module, err := findModule(outPath)
+	if err != nil {
+		return "", err
+	}
+	tmp, err := os.MkdirTemp(root, "_gen_dryrun_")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	tmpConfig := *config
+	tmpConfig.OutPath = filepath.Join(tmp, filepath.Base(outPath))
+	tmpConfig.ModelPkgPath = filepath.Join(tmp, filepath.Base(modelPath))
+	if err := NewGeneratorWithDB(&tmpConfig, db).GenerateModelsWithRelations(); err != nil {
+		return "", err
+	}
+
+	// 临时目录的导入路径替换为实际路径
+	replacer := strings.NewReplacer(importPath(root, module, tmpConfig.ModelPkgPath), importPath(root, module, modelPath))
+
+	var buf bytes.Buffer
+	for _, dir := range [][2]string{{modelPath, tmpConfig.ModelPkgPath}, {outPath, tmpConfig.OutPath}} {
+		if err := diffDir(&buf, root, dir[0], dir[1], replacer); err != nil {
+			return "", err
+		}
+	}
+	return buf.String(), nil
+}
+
+// diffDir 比较现有目录与新生成目录中的生成文件
+func diffDir(w *bytes.Buffer, root, current, generated string, replacer *strings.Replacer) error {
+	names := make(map[string]struct{})
+	for _, dir := range []string{current, generated} {
+		entries, err := os.ReadDir(dir)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		for _, e := range entries {
+			if !e.IsDir() && isGeneratedFile(e.Name()) {
+				names[e.Name()] = struct{}{}
+			}
+		}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		before, err := readOptional(filepath.Join(current, name))
+		if err != nil {
+			return err
+		}
+		after, err := readOptional(filepath.Join(generated, name))
+		if err != nil {
+			return err
+		}
+		after = replacer.Replace(after)
+		if before == after {
+			continue
+		}
+
+		rel, err := filepath.Rel(root, filepath.Join(current, name))
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		from, to := "a/"+rel, "b/"+rel
+		if before == "" {
+			from = "/dev/null"
+		}
+		if after == "" {
+			to = "/dev/null"
+		}
+		err = difflib.WriteUnifiedDiff(w, difflib.UnifiedDiff{
+			A:        splitLines(before),
+			B:        splitLines(after),
+			FromFile: from,
+			ToFile:   to,
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitLines 按行拆分，空内容返回空切片
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return difflib.SplitLines(s)
+}
+
+// isGeneratedFile 是否为 gen 生成的文件
+func isGeneratedFile(name string) bool {
+	return name == "gen.go" || strings.HasSuffix(name, ".gen.go")
+}
+
+// readOptional 读取文件，不存在时返回空字符串
+func readOptional(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return string(data), err
+}
+
+// findModule 向上查找 go.mod，返回模块根目录与模块路径
+func findModule(dir string) (string, string, error) {
+	for current := dir; ; current = filepath.Dir(current) {
+		f, err := os.Open(filepath.Join(current, "go.mod"))
+		if err == nil {
+			defer f.Close()
+			scanner := bufio.NewScanner(f)
+			for scanner.Scan() {
+				if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+					return current, strings.Trim(strings.TrimSpace(module), `"`), nil
+				}
+			}
+			return "", "", fmt.Errorf("%s/go.mod 缺少 module 声明", current)
+		}
+		if parent := filepath.Dir(current); parent == current {
+			return "", "", fmt.Errorf("%s 不在 Go 模块中", dir)
+		}
+	}
+}
+
+// importPath 目录在模块中的导入路径
+func importPath(root, module, dir string) string {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == "." {
+		return module
+	}
+	return module + "/" + filepath.ToSlash(rel)
+}
@@ -0,0 +1,110 @@
+package scripts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const testGenConfig = `
+out_path: internal/query
+model_pkg_path: internal/entity
+table_prefix: t_
+tables:
+  - name: t_user
+    soft_delete: true
+    encrypted: [email]
+    types: { score: float32 }
+    relations:
+      - { name: Role, type: belongs_to, table: t_role, foreign_key: RoleID }
+  - name: t_role
+    relations:
+      - { name: Users, type: has_many, table: t_user, foreign_key: RoleID }
+`
+
+func TestDefaultGenConfig(t *testing.T) {
+	config, err := DefaultGenConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "sw_", config.TablePrefix)
+	assert.Equal(t, "./internal/models/query", config.OutPath)
+	require.NotEmpty(t, config.Tables)
+	assert.Equal(t, "sw_sys_user", config.Tables[0].Name)
+	assert.Len(t, config.Tables[0].Relations, 3)
+}
+
+func TestGenConfig_Validate(t *testing.T) {
+	cases := map[string]string{
+		"缺少输出路径": `tables: [{name: a}]`,
+		"缺少表":    `{out_path: q, model_pkg_path: m}`,
+		"表重复":    `{out_path: q, model_pkg_path: m, tables: [{name: a}, {name: a}]}`,
+		"关联表不存在": `{out_path: q, model_pkg_path: m, tables: [{name: a, relations: [{name: B, type: belongs_to, table: b, foreign_key: BID}]}]}`,
+		"关联类型错误": `{out_path: q, model_pkg_path: m, tables: [{name: a, relations: [{name: A, type: owns, table: a, foreign_key: AID}]}]}`,
+		"缺少中间表":  `{out_path: q, model_pkg_path: m, tables: [{name: a, relations: [{name: A, type: many_to_many, table: a, foreign_key: AID}]}]}`,
+		"缺少外键":   `{out_path: q, model_pkg_path: m, tables: [{name: a, relations: [{name: A, type: belongs_to, table: a}]}]}`,
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseGenConfig([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	// 在独立的临时模块中生成，避免依赖项目目录
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/gentest\n\ngo 1.24\n"), 0o644))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(root))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(root, "gen.db")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec(`CREATE TABLE t_role (id integer PRIMARY KEY, name varchar(32) NOT NULL)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE t_user (
+  id integer PRIMARY KEY, role_id integer, email varchar(255), score real, deleted_at datetime
+)`).Error)
+
+	config, err := ParseGenConfig([]byte(testGenConfig))
+	require.NoError(t, err)
+
+	diff, err := DryRun(config, db)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+++ b/internal/entity/t_user.gen.go")
+	assert.Contains(t, diff, "+++ b/internal/query/gen.go")
+	assert.Contains(t, diff, `+	"example.com/gentest/internal/entity"`, "导入路径应指向实际目录")
+	assert.NotContains(t, diff, "_gen_dryrun_")
+	assert.Contains(t, diff, "serializer:encrypted")
+	assert.Contains(t, diff, "Score     float32")
+	assert.Contains(t, diff, "gorm.DeletedAt")
+	_, err = os.Stat(filepath.Join(root, "internal"))
+	assert.True(t, os.IsNotExist(err), "dry-run 不应写入输出目录")
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.False(t, strings.HasPrefix(e.Name(), "_gen_dryrun_"), "临时目录应被删除")
+	}
+
+	// 实际生成后再次对比应无差异，保证生成结果稳定
+	require.NoError(t, NewGeneratorWithDB(config, db).GenerateModelsWithRelations())
+	diff, err = DryRun(config, db)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	// 手动修改生成文件后能检测到差异
+	path := filepath.Join(root, "internal", "entity", "t_role.gen.go")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, append(data, []byte("\n// edited\n")...), 0o644))
+	diff, err = DryRun(config, db)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "-// edited")
+}
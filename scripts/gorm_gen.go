@@ -4,22 +4,26 @@ import (
 	"fmt"
 	"strings"
 
-	"gorm.io/driver/mysql"
 	"gorm.io/gen"
 	"gorm.io/gen/field"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
+
+	"sweet/pkg/database"
 )
 
 // GenConfig 代码生成器配置
 type GenConfig struct {
-	DSN             string // 数据库连接串
-	OutPath         string // 输出路径
-	ModelPkgPath    string // 模型包路径
-	WithUnitTest    bool   // 是否生成单元测试
-	WithQueryFilter bool   // 是否生成查询过滤器
-	TablePrefix     string // 表前缀
-	SingularTable   bool   // 是否使用单数表名，默认为true
+	Driver          string            `yaml:"driver"`            // 数据库驱动：mysql、postgres、sqlite，为空时使用 mysql
+	DSN             string            `yaml:"dsn"`               // 数据库连接串
+	OutPath         string            `yaml:"out_path"`          // 输出路径
+	ModelPkgPath    string            `yaml:"model_pkg_path"`    // 模型包路径
+	WithUnitTest    bool              `yaml:"with_unit_test"`    // 是否生成单元测试
+	WithQueryFilter bool              `yaml:"with_query_filter"` // 是否生成查询过滤器
+	TablePrefix     string            `yaml:"table_prefix"`      // 表前缀
+	SingularTable   bool              `yaml:"singular_table"`    // 是否使用单数表名，默认为true
+	TypeMap         map[string]string `yaml:"type_map"`          // 数据库类型到Go类型的映射，覆盖默认映射
+	Tables          []*TableConfig    `yaml:"tables"`            // 生成的表（白名单），按顺序生成
 }
 
 // Generator GORM 生成器
@@ -34,15 +38,18 @@ func NewGenerator(config *GenConfig) (*Generator, error) {
 	if config == nil {
 		return nil, fmt.Errorf("config cannot be nil")
 	}
-
-	// 设置默认值
-	if !config.SingularTable {
-		config.SingularTable = true // 默认使用单数表名
+	db, err := Open(config)
+	if err != nil {
+		return nil, err
 	}
+	return NewGeneratorWithDB(config, db), nil
+}
 
+// Open 按配置连接数据库
+func Open(config *GenConfig) (*gorm.DB, error) {
 	// 设置命名策略
 	namingStrategy := schema.NamingStrategy{
-		SingularTable: config.SingularTable, // 使用单数表名
+		SingularTable: true, // 使用单数表名
 	}
 
 	if config.TablePrefix != "" {
@@ -56,10 +63,23 @@ func NewGenerator(config *GenConfig) (*Generator, error) {
 	}
 
 	// 连接数据库
-	db, err := gorm.Open(mysql.Open(config.DSN), gormConfig)
+	dialect, err := database.GetDialect(config.Driver)
+	if err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(dialect.Open(config.DSN), gormConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect database: %v", err)
 	}
+	return db, nil
+}
+
+// NewGeneratorWithDB 使用已有的数据库连接创建生成器
+func NewGeneratorWithDB(config *GenConfig, db *gorm.DB) *Generator {
+	// 设置默认值
+	if !config.SingularTable {
+		config.SingularTable = true // 默认使用单数表名
+	}
 
 	// 初始化生成器
 	g := gen.NewGenerator(gen.Config{
@@ -101,20 +121,13 @@ func NewGenerator(config *GenConfig) (*Generator, error) {
 	g.UseDB(db)
 
 	// 自定义字段的数据类型映射
-	dataMap := map[string]func(columnType gorm.ColumnType) (dataType string){
-		"tinyint":   func(columnType gorm.ColumnType) (dataType string) { return "int64" },
-		"smallint":  func(columnType gorm.ColumnType) (dataType string) { return "int64" },
-		"mediumint": func(columnType gorm.ColumnType) (dataType string) { return "int64" },
-		"bigint":    func(columnType gorm.ColumnType) (dataType string) { return "int64" },
-		"int":       func(columnType gorm.ColumnType) (dataType string) { return "int64" },
-	}
-	g.WithDataTypeMap(dataMap)
+	g.WithDataTypeMap(config.dataTypeMap())
 
 	return &Generator{
 		Config: config,
 		DB:     db,
 		Gen:    g,
-	}, nil
+	}
 }
 
 // GenerateModel 生成指定表的模型
@@ -136,268 +149,52 @@ func (g *Generator) Execute() {
 	g.Gen.Execute()
 }
 
-// SetupModelRelations 设置模型关联关系
-func (g *Generator) SetupModelRelations() {
-	// 定义软删除字段类型
-	softDeleteField := gen.FieldType("deleted_at", "gorm.DeletedAt")
-
-	// 解析表名 - 根据SQL文件中的实际表名
-	userTable := "sw_sys_user"
-	roleTable := "sw_sys_role"
-	menuTable := "sw_sys_menu"
-	roleMenuTable := "sw_sys_role_menu"
-	apiGroupTable := "sw_sys_api_group"
-	apiTable := "sw_sys_api"
-	roleApiTable := "sw_sys_role_api"
-	deptTable := "sw_sys_dept"
-	postTable := "sw_sys_post"
-	loginLogTable := "sw_sys_login_log"
-	operationLogTable := "sw_sys_operation_log"
-	// 文件管理相关表
-	fileTable := "sw_sys_file"
-
-	// 生成带关联的模型
-	user := g.Gen.GenerateModel(userTable)
-	role := g.Gen.GenerateModel(roleTable)
-	menu := g.Gen.GenerateModel(menuTable)
-	api := g.Gen.GenerateModel(apiTable)
-	dept := g.Gen.GenerateModel(deptTable)
-	post := g.Gen.GenerateModel(postTable)
-
-	// 设置系统用户表的关联
-	userOpts := []gen.ModelOpt{
-		softDeleteField,
-		// 用户与角色的多对一关系
-		gen.FieldRelate(field.BelongsTo, "Role", role, &field.RelateConfig{
-			RelatePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"RoleID"},
-				"references": {"ID"},
-			},
-			JSONTag: "role",
-		}),
-		// 用户与部门的多对一关系
-		gen.FieldRelate(field.BelongsTo, "Dept", dept, &field.RelateConfig{
-			RelatePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"DeptID"},
-				"references": {"ID"},
-			},
-			JSONTag: "dept",
-		}),
-		// 用户与岗位的多对一关系
-		gen.FieldRelate(field.BelongsTo, "Post", post, &field.RelateConfig{
-			RelatePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"PostID"},
-				"references": {"ID"},
-			},
-			JSONTag: "post",
-		}),
-	}
-
-	// 设置角色表的关联
-	roleOpts := []gen.ModelOpt{
-		softDeleteField,
-	}
-
-	// 设置菜单表的关联
-	menuOpts := []gen.ModelOpt{
-		softDeleteField,
-		// 菜单与父菜单的自引用关系
-		gen.FieldRelate(field.BelongsTo, "Parent", menu, &field.RelateConfig{
-			RelatePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"ParentID"},
-				"references": {"ID"},
-			},
-			JSONTag: "parent",
-		}),
-		// 菜单与子菜单的关系
-		gen.FieldRelate(field.HasMany, "Children", menu, &field.RelateConfig{
-			RelateSlicePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"ParentID"},
-				"references": {"ID"},
-			},
-			JSONTag: "children",
-		}),
-
-	}
-
-	// 设置角色菜单关联表的选项
-	roleMenuOpts := []gen.ModelOpt{
-		// 角色菜单关联表与角色的多对一关系
-		gen.FieldRelate(field.BelongsTo, "Role", role, &field.RelateConfig{
-			RelatePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"RoleID"},
-				"references": {"ID"},
-			},
-			JSONTag: "role",
-		}),
-		// 角色菜单关联表与菜单的多对一关系
-		gen.FieldRelate(field.BelongsTo, "Menu", menu, &field.RelateConfig{
-			RelatePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"MenuID"},
-				"references": {"ID"},
-			},
-			JSONTag: "menu",
-		}),
-	}
-
-
-
-	// 设置API分组表的选项
-	apiGroupOpts := []gen.ModelOpt{
-		softDeleteField,
+// SetupModelRelations 按配置中的 tables 设置字段选项与模型关联关系
+func (g *Generator) SetupModelRelations() error {
+	if err := g.Config.Validate(); err != nil {
+		return err
 	}
 
-	// 设置API表的选项
-	apiOpts := []gen.ModelOpt{
-		softDeleteField,
-	}
-
-	// 设置角色API关联表的选项
-	roleApiOpts := []gen.ModelOpt{
-		// 角色API关联表与角色的多对一关系
-		gen.FieldRelate(field.BelongsTo, "Role", role, &field.RelateConfig{
-			RelatePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"RoleID"},
-				"references": {"ID"},
-			},
-			JSONTag: "role",
-		}),
-		// 角色API关联表与API的多对一关系
-		gen.FieldRelate(field.BelongsTo, "Api", api, &field.RelateConfig{
-			RelatePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"ApiID"},
-				"references": {"ID"},
-			},
-			JSONTag: "api",
-		}),
-	}
-
-	// 设置登录日志表的选项
-	loginLogOpts := []gen.ModelOpt{
-		// 登录日志与用户的多对一关系
-		gen.FieldRelate(field.BelongsTo, "User", user, &field.RelateConfig{
-			RelatePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"UserID"},
-				"references": {"ID"},
-			},
-			JSONTag: "user",
-		}),
-	}
-
-	// 设置部门表的选项
-	deptOpts := []gen.ModelOpt{
-		softDeleteField,
-		// 部门与父部门的自引用关系
-		gen.FieldRelate(field.BelongsTo, "Parent", dept, &field.RelateConfig{
-			RelatePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"ParentID"},
-				"references": {"ID"},
-			},
-			JSONTag: "parent",
-		}),
-		// 部门与子部门的关系
-		gen.FieldRelate(field.HasMany, "Children", dept, &field.RelateConfig{
-			RelateSlicePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"ParentID"},
-				"references": {"ID"},
-			},
-			JSONTag: "children",
-		}),
-	}
-
-	// 设置岗位表的选项
-	postOpts := []gen.ModelOpt{
-		softDeleteField,
-		// 岗位与部门的多对一关系
-		gen.FieldRelate(field.BelongsTo, "Dept", dept, &field.RelateConfig{
-			RelatePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"DeptID"},
-				"references": {"ID"},
-			},
-			JSONTag: "dept",
-		}),
-	}
-
-	// 设置操作日志表的选项
-	operationLogOpts := []gen.ModelOpt{
-		// 操作日志与用户的多对一关系
-		gen.FieldRelate(field.BelongsTo, "User", user, &field.RelateConfig{
-			RelatePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"UserID"},
-				"references": {"ID"},
-			},
-			JSONTag: "user",
-		}),
+	// 先生成不带选项的基础模型，作为关联字段的目标
+	relates := make(map[string]relateFunc, len(g.Config.Tables))
+	for _, t := range g.Config.Tables {
+		meta := g.Gen.GenerateModel(t.Name)
+		relates[t.Name] = func(relationship field.RelationshipType, fieldName string, config *field.RelateConfig) gen.ModelOpt {
+			return gen.FieldRelate(relationship, fieldName, meta, config)
+		}
 	}
 
-	// 设置文件表的选项
-	fileOpts := []gen.ModelOpt{
-		softDeleteField,
-		// 文件与上传用户的多对一关系
-		gen.FieldRelate(field.BelongsTo, "UploadUser", user, &field.RelateConfig{
-			RelatePointer: true,
-			GORMTag: map[string][]string{
-				"foreignKey": {"UploadUserID"},
-				"references": {"ID"},
-			},
-			JSONTag: "upload_user",
-		}),
+	// 重新生成带选项的模型，按配置顺序应用
+	models := make([]interface{}, 0, len(g.Config.Tables))
+	for _, t := range g.Config.Tables {
+		models = append(models, g.Gen.GenerateModel(t.Name, t.modelOpts(relates)...))
 	}
-
-	// 重新生成带关联的模型
-	user = g.Gen.GenerateModel(userTable, userOpts...)
-	role = g.Gen.GenerateModel(roleTable, roleOpts...)
-	menu = g.Gen.GenerateModel(menuTable, menuOpts...)
-	roleMenu := g.Gen.GenerateModel(roleMenuTable, roleMenuOpts...)
-	apiGroup := g.Gen.GenerateModel(apiGroupTable, apiGroupOpts...)
-	api = g.Gen.GenerateModel(apiTable, apiOpts...)
-	roleApi := g.Gen.GenerateModel(roleApiTable, roleApiOpts...)
-	dept = g.Gen.GenerateModel(deptTable, deptOpts...)
-	post = g.Gen.GenerateModel(postTable, postOpts...)
-	loginLog := g.Gen.GenerateModel(loginLogTable, loginLogOpts...)
-	operationLog := g.Gen.GenerateModel(operationLogTable, operationLogOpts...)
-	// 文件管理相关模型
-	file := g.Gen.GenerateModel(fileTable, fileOpts...)
-
-	// 应用基本模型
-	g.Gen.ApplyBasic(user, role, menu, roleMenu, apiGroup, api, roleApi, dept, post, loginLog, operationLog, file)
+	g.Gen.ApplyBasic(models...)
+	return nil
 }
 
 // GenerateModelsWithRelations 生成带关联关系的模型
-func (g *Generator) GenerateModelsWithRelations() {
+func (g *Generator) GenerateModelsWithRelations() error {
 	// 设置模型关联关系
-	g.SetupModelRelations()
+	if err := g.SetupModelRelations(); err != nil {
+		return err
+	}
 
 	// 执行代码生成
 	g.Execute()
+	return nil
 }
 
 // GenerateSystemModels 生成系统模块的所有模型（便捷方法）
 func GenerateSystemModels(dsn, outPath, modelPkgPath string) error {
-	// 创建生成器配置
-	config := &GenConfig{
-		DSN:             dsn,
-		OutPath:         outPath,
-		ModelPkgPath:    modelPkgPath,
-		WithUnitTest:    false,
-		WithQueryFilter: true,
-		TablePrefix:     "sw_",
-		SingularTable:   true,
+	// 使用内置配置
+	config, err := DefaultGenConfig()
+	if err != nil {
+		return err
 	}
+	config.DSN = dsn
+	config.OutPath = outPath
+	config.ModelPkgPath = modelPkgPath
 
 	// 创建生成器实例
 	generator, err := NewGenerator(config)
@@ -406,9 +203,7 @@ func GenerateSystemModels(dsn, outPath, modelPkgPath string) error {
 	}
 
 	// 生成带关联关系的模型
-	generator.GenerateModelsWithRelations()
-
-	return nil
+	return generator.GenerateModelsWithRelations()
 }
 
 // GenerateAllSystemTables 生成系统所有表的模型（不包含关联关系）
@@ -0,0 +1,497 @@
+package scripts
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gorm.io/gorm/schema"
+)
+
+// ScaffoldOptions 脚手架生成选项
+type ScaffoldOptions struct {
+	Root      string // 项目根目录，需包含 go.mod
+	EntityDir string // entity 目录，相对于 Root，默认 internal/models/entity
+	Entity    string // entity 结构体名，如 SysPost
+	Module    string // 业务模块，决定 dto/service/api/router 的子包，默认 system
+	Name      string // 资源名，默认去掉 entity 的 Sys 前缀，如 Post
+	Title     string // 中文名称，默认取 entity 注释并去掉末尾的“表”
+	Path      string // 路由路径（不含 /api/v1），默认为资源名的复数短横线形式，如 posts
+	Force     bool   // 覆盖已存在的文件
+}
+
+// EntityField entity 的数据库字段
+type EntityField struct {
+	Name    string // Go 字段名
+	Type    string // Go 类型
+	Column  string // 列名
+	JSON    string // JSON 标签
+	Comment string // 字段注释
+	NotNull bool   // 是否非空
+}
+
+// Entity 从 entity 源码中解析出的模型
+type Entity struct {
+	Name    string         // 结构体名
+	Table   string         // 表名
+	Comment string         // 结构体注释，不含结构体名
+	Fields  []*EntityField // 数据库字段，不含关联字段
+}
+
+// ScaffoldFile 脚手架生成的文件
+type ScaffoldFile struct {
+	Path    string // 相对于 Root 的路径
+	Content []byte
+	Skipped bool // 文件已存在且未指定 Force，未写入
+}
+
+// ParseEntity 解析 entity 目录中名为 name 的结构体
+func ParseEntity(dir, name string) (*Entity, error) {
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("读取 entity 目录失败: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") || strings.HasSuffix(e.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, e.Name()), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if entity := findEntity(file, name); entity != nil {
+			return entity, nil
+		}
+	}
+	return nil, fmt.Errorf("%s 中未找到 entity %s", dir, name)
+}
+
+// findEntity 在文件中查找结构体及其表名常量
+func findEntity(file *ast.File, name string) *Entity {
+	var entity *Entity
+	table := ""
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			switch s := spec.(type) {
+			case *ast.ValueSpec:
+				// gen 生成的表名常量：const TableNameSysPost = "sw_sys_post"
+				if len(s.Names) == 1 && s.Names[0].Name == "TableName"+name && len(s.Values) == 1 {
+					if lit, ok := s.Values[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						table, _ = strconv.Unquote(lit.Value)
+					}
+				}
+			case *ast.TypeSpec:
+				st, ok := s.Type.(*ast.StructType)
+				if !ok || s.Name.Name != name {
+					continue
+				}
+				entity = &Entity{Name: name}
+				if gen.Doc != nil {
+					entity.Comment = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(gen.Doc.Text()), name))
+				}
+				entity.Fields = entityFields(st)
+			}
+		}
+	}
+	if entity != nil {
+		entity.Table = table
+	}
+	return entity
+}
+
+// entityFields 提取带 column 标签的字段，跳过关联字段
+func entityFields(st *ast.StructType) []*EntityField {
+	var fields []*EntityField
+	for _, f := range st.Fields.List {
+		if len(f.Names) != 1 || f.Tag == nil {
+			continue
+		}
+		raw, err := strconv.Unquote(f.Tag.Value)
+		if err != nil {
+			continue
+		}
+		tag := reflect.StructTag(raw)
+		settings := schema.ParseTagSetting(tag.Get("gorm"), ";")
+		column := settings["COLUMN"]
+		if column == "" {
+			continue
+		}
+		_, notNull := settings["NOT NULL"]
+		field := &EntityField{
+			Name:    f.Names[0].Name,
+			Type:    typeString(f.Type),
+			Column:  column,
+			JSON:    strings.Split(tag.Get("json"), ",")[0],
+			Comment: settings["COMMENT"],
+			NotNull: notNull,
+		}
+		if f.Comment != nil {
+			field.Comment = strings.TrimSpace(f.Comment.Text())
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// typeString 类型表达式的源码形式
+func typeString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = format.Node(&buf, token.NewFileSet(), expr)
+	return buf.String()
+}
+
+// Scaffold 根据 entity 生成 DTO、服务、接口处理器、路由与 sw_sys_api 种子数据
+// 服务接口写入 service/<module>/interface.go：已存在同名空接口时填充，不存在时追加，非空时保留不动
+func Scaffold(opts ScaffoldOptions) ([]*ScaffoldFile, error) {
+	data, err := newScaffoldData(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*ScaffoldFile, 0, 6)
+	outputs := []struct {
+		path string
+		tpl  *template.Template
+	}{
+		{filepath.Join("internal", "models", "dto", data.Module, data.Snake+".go"), dtoTemplate},
+		{filepath.Join("internal", "service", data.Module, data.Snake+".go"), serviceTemplate},
+		{filepath.Join("internal", "api", data.Module, data.Snake+".go"), apiTemplate},
+		{filepath.Join("internal", "router", data.Module, data.Snake+".go"), routerTemplate},
+		{filepath.Join("resource", "seed", data.Snake+".yaml"), seedTemplate},
+	}
+	for _, o := range outputs {
+		content, err := render(o.tpl, data, strings.HasSuffix(o.path, ".go"))
+		if err != nil {
+			return nil, fmt.Errorf("生成 %s 失败: %w", o.path, err)
+		}
+		files = append(files, &ScaffoldFile{Path: o.path, Content: content})
+	}
+
+	iface, err := patchServiceInterface(opts.Root, data)
+	if err != nil {
+		return nil, err
+	}
+	if iface != nil {
+		files = append(files, iface)
+	}
+
+	for _, f := range files {
+		path := filepath.Join(opts.Root, f.Path)
+		if _, err := os.Stat(path); err == nil && !opts.Force && !strings.HasSuffix(f.Path, "interface.go") {
+			f.Skipped = true
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, f.Content, 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// render 执行模板，Go 源码需通过 gofmt
+func render(tpl *template.Template, data *scaffoldData, gofmt bool) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	if !gofmt {
+		return buf.Bytes(), nil
+	}
+	return format.Source(buf.Bytes())
+}
+
+// patchServiceInterface 在 interface.go 中补充服务接口，无需修改时返回 nil
+func patchServiceInterface(root string, data *scaffoldData) (*ScaffoldFile, error) {
+	path := filepath.Join("internal", "service", data.Module, "interface.go")
+	current, err := os.ReadFile(filepath.Join(root, path))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := serviceInterfaceTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	decl := buf.String()
+
+	var src string
+	switch {
+	case len(current) == 0:
+		src = fmt.Sprintf("package %s\n\nimport (\n\t\"context\"\n\t\"%s/internal/models\"\n\t%s \"%s\"\n)\n\n%s",
+			data.Module, data.ModulePath, data.DTOAlias, data.DTOImport, decl)
+	default:
+		src = string(current)
+		empty := fmt.Sprintf("type I%sService interface {\n}\n", data.Name)
+		idx := strings.Index(src, empty)
+		if idx < 0 && strings.Contains(src, fmt.Sprintf("type I%sService interface {", data.Name)) {
+			// 接口已有方法，保留手写内容
+			return nil, nil
+		}
+		// 去掉声明部分，保留原有的文档注释
+		_, body, _ := strings.Cut(decl, "type ")
+		if idx >= 0 {
+			src = src[:idx] + "type " + body + src[idx+len(empty):]
+		} else {
+			src = strings.TrimRight(src, "\n") + "\n\n" + decl
+		}
+		src = ensureImports(src, map[string]string{
+			"context":                            "",
+			data.ModulePath + "/internal/models": "",
+			data.DTOImport:                       data.DTOAlias,
+		})
+	}
+
+	content, err := format.Source([]byte(src))
+	if err != nil {
+		return nil, fmt.Errorf("生成 %s 失败: %w", path, err)
+	}
+	return &ScaffoldFile{Path: path, Content: content}, nil
+}
+
+// ensureImports 为源码补充缺失的导入
+func ensureImports(src string, imports map[string]string) string {
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ImportsOnly)
+	if err != nil {
+		return src
+	}
+	existing := make(map[string]struct{}, len(file.Imports))
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		existing[path] = struct{}{}
+	}
+
+	var missing []string
+	for path, alias := range imports {
+		if _, ok := existing[path]; ok {
+			continue
+		}
+		missing = append(missing, strings.TrimSpace(alias+" "+strconv.Quote(path)))
+	}
+	if len(missing) == 0 {
+		return src
+	}
+	block := "\t" + strings.Join(missing, "\n\t") + "\n"
+	if idx := strings.Index(src, "import (\n"); idx >= 0 {
+		idx += len("import (\n")
+		return src[:idx] + block + src[idx:]
+	}
+	// 没有导入块时插入到 package 声明之后
+	idx := strings.Index(src, "\n")
+	return src[:idx+1] + "\nimport (\n" + block + ")\n" + src[idx+1:]
+}
+
+// scaffoldData 模板数据
+type scaffoldData struct {
+	ModulePath    string // Go 模块路径
+	Module        string
+	Name          string
+	Title         string
+	Entity        string
+	Snake         string // 资源名的蛇形命名，用于文件名与接口分组
+	Path          string
+	DTOAlias      string
+	DTOImport     string
+	ServiceAlias  string
+	ServiceImport string
+	APIAlias      string
+	APIImport     string
+
+	Fields    []*scaffoldField // 可写字段
+	Filters   []*scaffoldField // 列表过滤字段
+	Sorts     []*scaffoldField // 可排序字段
+	CreatedAt *EntityField
+	UpdatedAt *EntityField
+	Version   *EntityField // 乐观锁版本号，更新时需带上读取到的版本
+	HasSort   bool         // 存在 sort 字段，默认按其升序排列
+	NeedTime  bool         // DTO 需要导入 time
+}
+
+// scaffoldField 模板中的字段
+type scaffoldField struct {
+	*EntityField
+	Required bool // 创建时必填
+	Like     bool // 列表按模糊匹配过滤
+}
+
+// Pointer 字段是否为指针类型
+func (f *scaffoldField) Pointer() bool {
+	return strings.HasPrefix(f.Type, "*")
+}
+
+// BaseType 去掉指针后的类型
+func (f *scaffoldField) BaseType() string {
+	return strings.TrimPrefix(f.Type, "*")
+}
+
+// newScaffoldData 解析 entity 并补全默认选项
+func newScaffoldData(opts *ScaffoldOptions) (*scaffoldData, error) {
+	if opts.Entity == "" {
+		return nil, errors.New("未指定 entity")
+	}
+	if opts.Root == "" {
+		opts.Root = "."
+	}
+	if opts.EntityDir == "" {
+		opts.EntityDir = filepath.Join("internal", "models", "entity")
+	}
+	if opts.Module == "" {
+		opts.Module = "system"
+	}
+	if opts.Name == "" {
+		opts.Name = strings.TrimPrefix(opts.Entity, "Sys")
+	}
+	if !token.IsIdentifier(opts.Module) || !token.IsExported(opts.Name) {
+		return nil, fmt.Errorf("模块名 %q 或资源名 %q 不合法", opts.Module, opts.Name)
+	}
+
+	root, err := filepath.Abs(opts.Root)
+	if err != nil {
+		return nil, err
+	}
+	_, modulePath, err := findModule(root)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := ParseEntity(filepath.Join(root, opts.EntityDir), opts.Entity)
+	if err != nil {
+		return nil, err
+	}
+
+	snake := schema.NamingStrategy{}.ColumnName("", opts.Name)
+	if opts.Title == "" {
+		opts.Title = strings.TrimSuffix(entity.Comment, "表")
+	}
+	if opts.Title == "" {
+		opts.Title = opts.Name
+	}
+	if opts.Path == "" {
+		opts.Path = strings.ReplaceAll(pluralize(snake), "_", "-")
+	}
+	opts.Path = strings.Trim(opts.Path, "/")
+
+	data := &scaffoldData{
+		ModulePath:    modulePath,
+		Module:        opts.Module,
+		Name:          opts.Name,
+		Title:         opts.Title,
+		Entity:        opts.Entity,
+		Snake:         snake,
+		Path:          opts.Path,
+		DTOAlias:      opts.Module + "DTO",
+		DTOImport:     modulePath + "/internal/models/dto/" + opts.Module,
+		ServiceAlias:  opts.Module + "Service",
+		ServiceImport: modulePath + "/internal/service/" + opts.Module,
+		APIAlias:      opts.Module + "Api",
+		APIImport:     modulePath + "/internal/api/" + opts.Module,
+	}
+
+	hasID := false
+	for _, f := range entity.Fields {
+		switch f.Name {
+		case "ID":
+			hasID = f.Type == "int64"
+			continue
+		case "CreatedAt":
+			data.CreatedAt = f
+			continue
+		case "UpdatedAt":
+			data.UpdatedAt = f
+			continue
+		case "DeletedAt":
+			continue
+		case "Version":
+			if f.Type == "int64" {
+				data.Version = f
+				continue
+			}
+		}
+		// 跳过不输出的字段与需要额外导入的类型
+		if f.JSON == "-" || strings.Contains(strings.TrimPrefix(f.Type, "*"), ".") && !strings.Contains(f.Type, "time.") {
+			continue
+		}
+
+		field := &scaffoldField{EntityField: f}
+		field.Required = f.NotNull && f.Type == "string"
+		data.Fields = append(data.Fields, field)
+		if strings.Contains(f.Type, "time.") {
+			data.NeedTime = true
+		}
+
+		switch {
+		case f.Column == "sort":
+			data.HasSort = true
+			data.Sorts = append(data.Sorts, field)
+		case field.BaseType() == "string":
+			data.Filters = append(data.Filters, &scaffoldField{EntityField: f, Like: true})
+		case isIntType(field.BaseType()):
+			data.Filters = append(data.Filters, field)
+		}
+	}
+	if !hasID {
+		return nil, fmt.Errorf("entity %s 缺少 int64 类型的 ID 主键", opts.Entity)
+	}
+	if data.CreatedAt != nil {
+		data.NeedTime = true
+		data.Sorts = append(data.Sorts, &scaffoldField{EntityField: data.CreatedAt})
+	}
+	if data.UpdatedAt != nil {
+		data.NeedTime = true
+	}
+	if alias := importAlias(filepath.Join(root, "internal", "service", data.Module, "interface.go"), data.DTOImport); alias != "" {
+		data.DTOAlias = alias
+	}
+	return data, nil
+}
+
+// importAlias 文件中导入 path 时使用的别名，未导入或无别名时返回空字符串
+func importAlias(file, path string) string {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ImportsOnly)
+	if err != nil {
+		return ""
+	}
+	for _, spec := range f.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); p == path && spec.Name != nil {
+			return spec.Name.Name
+		}
+	}
+	return ""
+}
+
+// isIntType 是否为整数类型
+func isIntType(t string) bool {
+	switch t {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// pluralize 英文名词的复数形式，用于生成 REST 路径
+func pluralize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	}
+	return word + "s"
+}
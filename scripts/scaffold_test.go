@@ -0,0 +1,210 @@
+package scripts
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEntity = `package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const TableNameSysNotice = "sw_sys_notice"
+
+// SysNotice 通知公告表
+type SysNotice struct {
+	ID        int64          ` + "`gorm:\"column:id;type:bigint unsigned;primaryKey;autoIncrement:true;comment:公告ID\" json:\"id\"`" + ` // 公告ID
+	Title     string         ` + "`gorm:\"column:title;type:varchar(64);not null;comment:标题\" json:\"title\"`" + `                          // 标题
+	Content   *string        ` + "`gorm:\"column:content;type:text;comment:内容\" json:\"content\"`" + `                                    // 内容
+	Secret    string         ` + "`gorm:\"column:secret;type:varchar(64);not null\" json:\"-\"`" + `
+	Sort      int64          ` + "`gorm:\"column:sort;type:int unsigned;not null;comment:排序\" json:\"sort\"`" + `                         // 排序
+	Status    *int64         ` + "`gorm:\"column:status;type:tinyint unsigned;not null;default:1;comment:状态\" json:\"status\"`" + `         // 状态
+	CreatedAt *time.Time     ` + "`gorm:\"column:created_at;type:datetime;comment:创建时间\" json:\"created_at\"`" + `                       // 创建时间
+	UpdatedAt *time.Time     ` + "`gorm:\"column:updated_at;type:datetime;comment:更新时间\" json:\"updated_at\"`" + `                       // 更新时间
+	DeletedAt gorm.DeletedAt ` + "`gorm:\"column:deleted_at;type:datetime;comment:删除时间\" json:\"deleted_at\"`" + `                       // 删除时间
+	User      *SysUser       ` + "`gorm:\"foreignKey:UserID;references:ID\" json:\"user\"`" + `
+}
+`
+
+const testInterface = `package system
+
+import (
+	"context"
+)
+
+// ISystemService 系统服务接口
+type ISystemService interface {
+	Ping(ctx context.Context) error
+}
+
+// INoticeService 通知公告服务接口
+type INoticeService interface {
+}
+`
+
+// newScaffoldRoot 创建包含 entity 与服务接口的临时模块
+func newScaffoldRoot(t *testing.T) string {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":                               "module example.com/app\n\ngo 1.24\n",
+		"internal/models/entity/notice.gen.go": testEntity,
+		"internal/service/system/interface.go": testInterface,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return root
+}
+
+func TestParseEntity(t *testing.T) {
+	root := newScaffoldRoot(t)
+	entity, err := ParseEntity(filepath.Join(root, "internal/models/entity"), "SysNotice")
+	require.NoError(t, err)
+	assert.Equal(t, "sw_sys_notice", entity.Table)
+	assert.Equal(t, "通知公告表", entity.Comment)
+
+	names := make([]string, 0, len(entity.Fields))
+	for _, f := range entity.Fields {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"ID", "Title", "Content", "Secret", "Sort", "Status", "CreatedAt", "UpdatedAt", "DeletedAt"}, names, "关联字段应被跳过")
+	assert.Equal(t, &EntityField{Name: "Title", Type: "string", Column: "title", JSON: "title", Comment: "标题", NotNull: true}, entity.Fields[1])
+	assert.Equal(t, "*time.Time", entity.Fields[6].Type)
+
+	_, err = ParseEntity(filepath.Join(root, "internal/models/entity"), "SysMissing")
+	assert.Error(t, err)
+}
+
+func TestScaffold(t *testing.T) {
+	root := newScaffoldRoot(t)
+	files, err := Scaffold(ScaffoldOptions{Root: root, Entity: "SysNotice"})
+	require.NoError(t, err)
+	require.Len(t, files, 6)
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(root, name))
+		require.NoError(t, err)
+		if filepath.Ext(name) == ".go" {
+			_, err = parser.ParseFile(token.NewFileSet(), name, data, 0)
+			require.NoError(t, err, name)
+		}
+		return string(data)
+	}
+
+	dto := read("internal/models/dto/system/notice.go")
+	assert.Contains(t, dto, "// CreateNoticeReq 创建通知公告")
+	assert.Contains(t, dto, "Title   string  `json:\"title\" binding:\"required\"` // 标题")
+	assert.Contains(t, dto, "Title   *string `json:\"title\"`")
+	assert.Contains(t, dto, "type NoticeListRes models.PageRes[NoticeListItem]")
+	assert.Contains(t, dto, "`json:\"title\" form:\"title\" filter:\"title,like\"`")
+	assert.Contains(t, dto, "`json:\"status\" form:\"status\" filter:\"status\"`")
+	assert.NotContains(t, dto, "Secret", "json:\"-\" 的字段不应出现在 DTO 中")
+	assert.NotContains(t, dto, "DeletedAt")
+
+	service := read("internal/service/system/notice.go")
+	assert.Contains(t, service, "func NewNoticeService() INoticeService")
+	assert.Contains(t, service, "dao := global.Query.SysNotice")
+	assert.Contains(t, service, `Sorts:       []string{"id", "sort", "created_at"},`)
+	assert.Contains(t, service, "DefaultSort: []field.Expr{dao.Sort, dao.ID.Desc()},")
+	assert.Contains(t, service, "listing.Find(dao.WithContext(ctx), &dao, req, opts,")
+	assert.Contains(t, service, `updateData["updated_at"] = time.Now()`)
+
+	api := read("internal/api/system/notice.go")
+	assert.Contains(t, api, "common.Gin.BindUri(c, &req.IDReq)")
+	assert.Contains(t, api, "common.Gin.Res(c, err, res)")
+
+	router := read("internal/router/system/notice.go")
+	assert.Contains(t, router, `group := r.Group("/notices")`)
+	assert.Contains(t, router, `group.PUT("/:id", api.UpdateNotice)`)
+
+	seed := read("resource/seed/notice.yaml")
+	assert.Contains(t, seed, "path: /api/v1/notices/:id")
+
+	iface := read("internal/service/system/interface.go")
+	assert.Contains(t, iface, "// INoticeService 通知公告服务接口\ntype INoticeService interface {\n\t// CreateNotice 创建通知公告")
+	assert.Contains(t, iface, `systemDTO "example.com/app/internal/models/dto/system"`)
+	assert.Contains(t, iface, `"example.com/app/internal/models"`)
+
+	// 再次生成时不覆盖已有文件，也不重复添加接口方法
+	require.NoError(t, os.WriteFile(filepath.Join(root, "internal/api/system/notice.go"), []byte("package system\n"), 0o644))
+	files, err = Scaffold(ScaffoldOptions{Root: root, Entity: "SysNotice"})
+	require.NoError(t, err)
+	assert.Len(t, files, 5, "接口已填充，不再修改 interface.go")
+	for _, f := range files {
+		assert.True(t, f.Skipped, f.Path)
+	}
+	assert.Equal(t, "package system\n", read("internal/api/system/notice.go"))
+
+	_, err = Scaffold(ScaffoldOptions{Root: root, Entity: "SysNotice", Force: true})
+	require.NoError(t, err)
+	assert.Contains(t, read("internal/api/system/notice.go"), "type NoticeApi struct")
+}
+
+func TestScaffold_AppendInterface(t *testing.T) {
+	root := newScaffoldRoot(t)
+	// 模块中尚无该接口时追加到 interface.go，并沿用已有的 DTO 导入别名
+	src := "package basic\n\nimport (\n\t\"context\"\n\tbasicDto \"example.com/app/internal/models/dto/basic\"\n)\n\ntype IBasicService interface {\n\tPing(ctx context.Context, req *basicDto.PingReq) error\n}\n"
+	path := filepath.Join(root, "internal/service/basic/interface.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(src), 0o644))
+
+	_, err := Scaffold(ScaffoldOptions{Root: root, Entity: "SysNotice", Module: "basic", Name: "Announcement", Title: "公告"})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	iface := string(data)
+	assert.Contains(t, iface, "// IAnnouncementService 公告服务接口")
+	assert.Contains(t, iface, "CreateAnnouncement(ctx context.Context, req *basicDto.CreateAnnouncementReq) error")
+	assert.Contains(t, iface, "\"example.com/app/internal/models\"")
+
+	service, err := os.ReadFile(filepath.Join(root, "internal/service/basic/announcement.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(service), `basicDto "example.com/app/internal/models/dto/basic"`)
+	router, err := os.ReadFile(filepath.Join(root, "internal/router/basic/announcement.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(router), `r.Group("/announcements")`)
+}
+
+func TestScaffold_Version(t *testing.T) {
+	root := newScaffoldRoot(t)
+	// entity 含 version 列时生成乐观锁更新
+	path := filepath.Join(root, "internal/models/entity/notice.gen.go")
+	versioned := strings.Replace(testEntity, "\tCreatedAt ", "\tVersion   int64          `gorm:\"column:version;type:int unsigned;not null;default:1;comment:版本号\" json:\"version\"` // 版本号\n\tCreatedAt ", 1)
+	require.NoError(t, os.WriteFile(path, []byte(versioned), 0o644))
+
+	_, err := Scaffold(ScaffoldOptions{Root: root, Entity: "SysNotice"})
+	require.NoError(t, err)
+
+	dto, err := os.ReadFile(filepath.Join(root, "internal/models/dto/system/notice.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(dto), "`json:\"version\" binding:\"required\"`")
+	assert.NotContains(t, string(dto), "Version   *int64", "版本号不作为普通可写字段")
+
+	service, err := os.ReadFile(filepath.Join(root, "internal/service/system/notice.go"))
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "notice.go", service, 0)
+	require.NoError(t, err)
+	assert.Contains(t, string(service), `updateData["version"] = req.Version + 1`)
+	assert.Contains(t, string(service), "Where(dao.ID.Eq(req.ID), dao.Version.Eq(req.Version)).Updates(updateData)")
+	assert.Contains(t, string(service), "return errs.ErrVersionConflict")
+}
+
+func TestPluralize(t *testing.T) {
+	for word, want := range map[string]string{"post": "posts", "api_key": "api_keys", "dictionary": "dictionaries", "day": "days", "status": "statuses", "box": "boxes"} {
+		assert.Equal(t, want, pluralize(word), word)
+	}
+}
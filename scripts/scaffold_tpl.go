@@ -0,0 +1,409 @@
+package scripts
+
+import "text/template"
+
+// 脚手架模板，Go 源码在写入前会经过 gofmt
+var (
+	dtoTemplate              = template.Must(template.New("dto").Parse(dtoTpl))
+	serviceTemplate          = template.Must(template.New("service").Parse(serviceTpl))
+	serviceInterfaceTemplate = template.Must(template.New("interface").Parse(serviceInterfaceTpl))
+	apiTemplate              = template.Must(template.New("api").Parse(apiTpl))
+	routerTemplate           = template.Must(template.New("router").Parse(routerTpl))
+	seedTemplate             = template.Must(template.New("seed").Parse(seedTpl))
+)
+
+const dtoTpl = `package {{.Module}}
+
+import (
+	"{{.ModulePath}}/internal/models"
+{{- if .NeedTime}}
+	"time"
+{{- end}}
+)
+
+// Create{{.Name}}Req 创建{{.Title}}
+type Create{{.Name}}Req struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.JSON}}"{{if .Required}} binding:"required"{{end}}` + "`" + ` // {{.Comment}}
+{{- end}}
+}
+
+// Delete{{.Name}}Req 删除{{.Title}}
+type Delete{{.Name}}Req models.IdsReq
+
+// Update{{.Name}}Req 更新{{.Title}}，未传的字段不更新
+type Update{{.Name}}Req struct {
+	models.IDReq
+{{- range .Fields}}
+	{{.Name}} *{{.BaseType}} ` + "`" + `json:"{{.JSON}}"` + "`" + ` // {{.Comment}}
+{{- end}}
+{{- with .Version}}
+	Version int64 ` + "`" + `json:"{{.JSON}}" binding:"required"` + "`" + ` // 读取时的版本号，数据已被修改时更新失败
+{{- end}}
+}
+
+// {{.Name}}ListReq {{.Title}}列表
+type {{.Name}}ListReq struct {
+{{- range .Filters}}
+	{{.Name}} {{if .Like}}string{{else}}*{{.BaseType}}{{end}} ` + "`" + `json:"{{.JSON}}" form:"{{.JSON}}" filter:"{{.Column}}{{if .Like}},like{{end}}"` + "`" + ` // {{.Comment}}
+{{- end}}
+{{- if .CreatedAt}}
+	models.TimeRangeReq
+{{- end}}
+	models.PageReq
+	models.SortReq
+}
+
+// {{.Name}}ListItem {{.Title}}列表项
+type {{.Name}}ListItem struct {
+	ID int64 ` + "`" + `json:"id"` + "`" + ` // {{.Title}}ID
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.JSON}}"` + "`" + ` // {{.Comment}}
+{{- end}}
+{{- with .Version}}
+	Version int64 ` + "`" + `json:"{{.JSON}}"` + "`" + ` // {{.Comment}}
+{{- end}}
+{{- with .CreatedAt}}
+	CreatedAt {{.Type}} ` + "`" + `json:"{{.JSON}}"` + "`" + ` // {{.Comment}}
+{{- end}}
+}
+
+// {{.Name}}ListRes {{.Title}}列表响应
+type {{.Name}}ListRes models.PageRes[{{.Name}}ListItem]
+
+// {{.Name}}DetailRes {{.Title}}详情响应
+type {{.Name}}DetailRes struct {
+	ID int64 ` + "`" + `json:"id"` + "`" + ` // {{.Title}}ID
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.JSON}}"` + "`" + ` // {{.Comment}}
+{{- end}}
+{{- with .Version}}
+	Version int64 ` + "`" + `json:"{{.JSON}}"` + "`" + ` // {{.Comment}}
+{{- end}}
+{{- with .CreatedAt}}
+	CreatedAt {{.Type}} ` + "`" + `json:"{{.JSON}}"` + "`" + ` // {{.Comment}}
+{{- end}}
+{{- with .UpdatedAt}}
+	UpdatedAt {{.Type}} ` + "`" + `json:"{{.JSON}}"` + "`" + ` // {{.Comment}}
+{{- end}}
+}
+`
+
+const serviceInterfaceTpl = `// I{{.Name}}Service {{.Title}}服务接口
+type I{{.Name}}Service interface {
+	// Create{{.Name}} 创建{{.Title}}
+	Create{{.Name}}(ctx context.Context, req *{{.DTOAlias}}.Create{{.Name}}Req) error
+	// Delete{{.Name}} 删除{{.Title}}
+	Delete{{.Name}}(ctx context.Context, req *{{.DTOAlias}}.Delete{{.Name}}Req) error
+	// Update{{.Name}} 更新{{.Title}}
+	Update{{.Name}}(ctx context.Context, req *{{.DTOAlias}}.Update{{.Name}}Req) error
+	// List{{.Name}} {{.Title}}列表
+	List{{.Name}}(ctx context.Context, req *{{.DTOAlias}}.{{.Name}}ListReq) (*{{.DTOAlias}}.{{.Name}}ListRes, error)
+	// Get{{.Name}}Detail {{.Title}}详情
+	Get{{.Name}}Detail(ctx context.Context, req *models.IDReq) (*{{.DTOAlias}}.{{.Name}}DetailRes, error)
+}
+`
+
+const serviceTpl = `package {{.Module}}
+
+import (
+	"context"
+	"errors"
+{{- if .UpdatedAt}}
+	"time"
+{{- end}}
+
+	"{{.ModulePath}}/internal/global"
+	"{{.ModulePath}}/internal/listing"
+	"{{.ModulePath}}/internal/models"
+	{{.DTOAlias}} "{{.DTOImport}}"
+	"{{.ModulePath}}/internal/models/entity"
+	"{{.ModulePath}}/pkg/errs"
+
+	"go.uber.org/zap"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+type {{.Name}}Service struct{}
+
+func New{{.Name}}Service() I{{.Name}}Service {
+	return &{{.Name}}Service{}
+}
+
+func (s *{{.Name}}Service) Create{{.Name}}(ctx context.Context, req *{{.DTOAlias}}.Create{{.Name}}Req) error {
+	dao := global.Query.{{.Entity}}
+	record := entity.{{.Entity}}{
+{{- range .Fields}}
+		{{.Name}}: req.{{.Name}},
+{{- end}}
+	}
+	if err := dao.WithContext(ctx).Create(&record); err != nil {
+		global.Logger.Error(
+			"创建{{.Title}}失败",
+			zap.Any("req", req),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+	return nil
+}
+
+func (s *{{.Name}}Service) Delete{{.Name}}(ctx context.Context, req *{{.DTOAlias}}.Delete{{.Name}}Req) error {
+	dao := global.Query.{{.Entity}}
+	if _, err := dao.WithContext(ctx).Where(dao.ID.In(req.Ids...)).Delete(); err != nil {
+		global.Logger.Error(
+			"删除{{.Title}}失败",
+			zap.Int64s("ids", req.Ids),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+	return nil
+}
+
+func (s *{{.Name}}Service) Update{{.Name}}(ctx context.Context, req *{{.DTOAlias}}.Update{{.Name}}Req) error {
+	dao := global.Query.{{.Entity}}
+
+	// 检查{{.Title}}是否存在
+{{- if .Version}}
+	record, err := s.get(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	// 版本号不一致说明读取后已被他人修改
+	if record.Version != req.Version {
+		return errs.ErrVersionConflict
+	}
+{{- else}}
+	if _, err := s.get(ctx, req.ID); err != nil {
+		return err
+	}
+{{- end}}
+
+	// 构建更新数据
+	updateData := make(map[string]interface{})
+{{- range .Fields}}
+	if req.{{.Name}} != nil {
+		updateData["{{.Column}}"] = *req.{{.Name}}
+	}
+{{- end}}
+	if len(updateData) == 0 {
+		return nil
+	}
+{{- with .UpdatedAt}}
+	updateData["{{.Column}}"] = time.Now()
+{{- end}}
+{{- if .Version}}
+	updateData["{{.Version.Column}}"] = req.Version + 1
+
+	// 以版本号为条件更新，期间被并发修改时不会命中
+	info, err := dao.WithContext(ctx).Where(dao.ID.Eq(req.ID), dao.Version.Eq(req.Version)).Updates(updateData)
+	if err != nil {
+		global.Logger.Error(
+			"更新{{.Title}}失败",
+			zap.Int64("id", req.ID),
+			zap.Any("updateData", updateData),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+	if info.RowsAffected == 0 {
+		return errs.ErrVersionConflict
+	}
+{{- else}}
+
+	if _, err := dao.WithContext(ctx).Where(dao.ID.Eq(req.ID)).Updates(updateData); err != nil {
+		global.Logger.Error(
+			"更新{{.Title}}失败",
+			zap.Int64("id", req.ID),
+			zap.Any("updateData", updateData),
+			zap.Error(err),
+		)
+		return errs.ErrServer
+	}
+{{- end}}
+	return nil
+}
+
+func (s *{{.Name}}Service) List{{.Name}}(ctx context.Context, req *{{.DTOAlias}}.{{.Name}}ListReq) (*{{.DTOAlias}}.{{.Name}}ListRes, error) {
+	dao := global.Query.{{.Entity}}
+	opts := listing.Options{
+		Sorts:       []string{"id"{{range .Sorts}}, "{{.Column}}"{{end}}},
+		DefaultSort: []field.Expr{ {{- if .HasSort}}dao.Sort, {{end}}dao.ID.Desc()},
+	}
+	res, err := listing.Find(dao.WithContext(ctx), &dao, req, opts, func(record *entity.{{.Entity}}) *{{.DTOAlias}}.{{.Name}}ListItem {
+		return &{{.DTOAlias}}.{{.Name}}ListItem{
+			ID: record.ID,
+{{- range .Fields}}
+			{{.Name}}: record.{{.Name}},
+{{- end}}
+{{- if .Version}}
+			Version: record.Version,
+{{- end}}
+{{- with .CreatedAt}}
+			CreatedAt: record.CreatedAt,
+{{- end}}
+		}
+	})
+	if err != nil {
+		global.Logger.Error(
+			"查询{{.Title}}列表失败",
+			zap.Any("req", req),
+			zap.Error(err),
+		)
+		return nil, errs.ErrServer
+	}
+	return (*{{.DTOAlias}}.{{.Name}}ListRes)(res), nil
+}
+
+func (s *{{.Name}}Service) Get{{.Name}}Detail(ctx context.Context, req *models.IDReq) (*{{.DTOAlias}}.{{.Name}}DetailRes, error) {
+	record, err := s.get(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &{{.DTOAlias}}.{{.Name}}DetailRes{
+		ID: record.ID,
+{{- range .Fields}}
+		{{.Name}}: record.{{.Name}},
+{{- end}}
+{{- if .Version}}
+		Version: record.Version,
+{{- end}}
+{{- with .CreatedAt}}
+		CreatedAt: record.CreatedAt,
+{{- end}}
+{{- with .UpdatedAt}}
+		UpdatedAt: record.UpdatedAt,
+{{- end}}
+	}, nil
+}
+
+// get 按ID查询{{.Title}}，不存在时返回 errs.ErrNotFound
+func (s *{{.Name}}Service) get(ctx context.Context, id int64) (*entity.{{.Entity}}, error) {
+	dao := global.Query.{{.Entity}}
+	record, err := dao.WithContext(ctx).Where(dao.ID.Eq(id)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		global.Logger.Error(
+			"查询{{.Title}}失败",
+			zap.Int64("id", id),
+			zap.Error(err),
+		)
+		return nil, errs.ErrServer
+	}
+	return record, nil
+}
+`
+
+const apiTpl = `package {{.Module}}
+
+import (
+	"{{.ModulePath}}/common"
+	"{{.ModulePath}}/internal/models"
+	{{.DTOAlias}} "{{.DTOImport}}"
+	{{.ServiceAlias}} "{{.ServiceImport}}"
+
+	"github.com/gin-gonic/gin"
+)
+
+// {{.Name}}Api {{.Title}}接口
+type {{.Name}}Api struct {
+	service {{.ServiceAlias}}.I{{.Name}}Service
+}
+
+// New{{.Name}}Api 创建{{.Title}}接口
+func New{{.Name}}Api(service {{.ServiceAlias}}.I{{.Name}}Service) *{{.Name}}Api {
+	return &{{.Name}}Api{service: service}
+}
+
+// Create{{.Name}} 创建{{.Title}}
+func (a *{{.Name}}Api) Create{{.Name}}(c *gin.Context) {
+	var req {{.DTOAlias}}.Create{{.Name}}Req
+	if err := common.Gin.Bind(c, &req); err != nil {
+		common.Gin.Res(c, err)
+		return
+	}
+	common.Gin.Res(c, a.service.Create{{.Name}}(c.Request.Context(), &req))
+}
+
+// Delete{{.Name}} 删除{{.Title}}
+func (a *{{.Name}}Api) Delete{{.Name}}(c *gin.Context) {
+	var req {{.DTOAlias}}.Delete{{.Name}}Req
+	if err := common.Gin.Bind(c, &req); err != nil {
+		common.Gin.Res(c, err)
+		return
+	}
+	common.Gin.Res(c, a.service.Delete{{.Name}}(c.Request.Context(), &req))
+}
+
+// Update{{.Name}} 更新{{.Title}}，ID取自路径参数
+func (a *{{.Name}}Api) Update{{.Name}}(c *gin.Context) {
+	var req {{.DTOAlias}}.Update{{.Name}}Req
+	if err := common.Gin.BindUri(c, &req.IDReq); err != nil {
+		common.Gin.Res(c, err)
+		return
+	}
+	if err := common.Gin.Bind(c, &req); err != nil {
+		common.Gin.Res(c, err)
+		return
+	}
+	common.Gin.Res(c, a.service.Update{{.Name}}(c.Request.Context(), &req))
+}
+
+// List{{.Name}} {{.Title}}列表
+func (a *{{.Name}}Api) List{{.Name}}(c *gin.Context) {
+	var req {{.DTOAlias}}.{{.Name}}ListReq
+	if err := common.Gin.BindQuery(c, &req); err != nil {
+		common.Gin.Res(c, err)
+		return
+	}
+	res, err := a.service.List{{.Name}}(c.Request.Context(), &req)
+	common.Gin.Res(c, err, res)
+}
+
+// Get{{.Name}}Detail {{.Title}}详情
+func (a *{{.Name}}Api) Get{{.Name}}Detail(c *gin.Context) {
+	var req models.IDReq
+	if err := common.Gin.BindUri(c, &req); err != nil {
+		common.Gin.Res(c, err)
+		return
+	}
+	res, err := a.service.Get{{.Name}}Detail(c.Request.Context(), &req)
+	common.Gin.Res(c, err, res)
+}
+`
+
+const routerTpl = `package {{.Module}}
+
+import (
+	{{.APIAlias}} "{{.APIImport}}"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Register{{.Name}}Router 注册{{.Title}}路由，r 为 /api/v1 分组
+func Register{{.Name}}Router(r *gin.RouterGroup, api *{{.APIAlias}}.{{.Name}}Api) {
+	group := r.Group("/{{.Path}}")
+	group.GET("", api.List{{.Name}})
+	group.GET("/:id", api.Get{{.Name}}Detail)
+	group.POST("", api.Create{{.Name}})
+	group.PUT("/:id", api.Update{{.Name}})
+	group.DELETE("", api.Delete{{.Name}})
+}
+`
+
+const seedTpl = `# {{.Title}}接口，由 sweet scaffold 生成
+# 写入 sw_sys_api_group/sw_sys_api: sweet seed --file resource/seed/{{.Snake}}.yaml
+api_groups:
+  - code: {{.Snake}}
+    name: {{.Title}}管理
+    apis:
+      - { name: {{.Title}}列表, method: GET, path: /api/v1/{{.Path}} }
+      - { name: {{.Title}}详情, method: GET, path: /api/v1/{{.Path}}/:id }
+      - { name: 创建{{.Title}}, method: POST, path: /api/v1/{{.Path}} }
+      - { name: 更新{{.Title}}, method: PUT, path: /api/v1/{{.Path}}/:id }
+      - { name: 删除{{.Title}}, method: DELETE, path: /api/v1/{{.Path}} }
+`
@@ -1,24 +1,33 @@
 package test
 
 import (
+	"os"
 	"sweet/scripts"
 	"testing"
 )
 
+// TestGorm 使用内置生成配置重新生成 internal/models，需设置 SWEET_GEN_DSN
 func TestGorm(t *testing.T) {
-	config := scripts.GenConfig{
-		DSN:             "root:wui11413@tcp(localhost:3306)/sweet?charset=utf8mb4&parseTime=True&loc=Local",
-		OutPath:         "/Users/aikzy/Desktop/sweet/internal/models/query",
-		ModelPkgPath:    "/Users/aikzy/Desktop/sweet/internal/models/entity",
-		WithUnitTest:    false,
-		WithQueryFilter: false,
-		TablePrefix:     "sw_",
-		SingularTable:   false,
+	dsn := os.Getenv("SWEET_GEN_DSN")
+	if dsn == "" {
+		t.Skip("未设置 SWEET_GEN_DSN，跳过代码生成")
 	}
-	generator, err := scripts.NewGenerator(&config)
+
+	config, err := scripts.DefaultGenConfig()
 	if err != nil {
 		t.Fatal(err)
 	}
+	config.DSN = dsn
+	// 测试在 test 目录下执行，路径相对于该目录
+	config.OutPath = "../internal/models/query"
+	config.ModelPkgPath = "../internal/models/entity"
 
-	generator.GenerateModelsWithRelations()
+	generator, err := scripts.NewGenerator(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := generator.GenerateModelsWithRelations(); err != nil {
+		t.Fatal(err)
+	}
 }